@@ -0,0 +1,479 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v4.22.3
+// source: api/proto/dispatcher/v1/dispatcher.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ServiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceRequest) Reset() {
+	*x = ServiceRequest{}
+	mi := &file_api_proto_dispatcher_v1_dispatcher_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceRequest) ProtoMessage() {}
+
+func (x *ServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_dispatcher_v1_dispatcher_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceRequest.ProtoReflect.Descriptor instead.
+func (*ServiceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_dispatcher_v1_dispatcher_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ServiceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ServiceStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Workers       int32                  `protobuf:"varint,2,opt,name=workers,proto3" json:"workers,omitempty"`
+	QueueLength   int32                  `protobuf:"varint,3,opt,name=queue_length,json=queueLength,proto3" json:"queue_length,omitempty"`
+	QueueCapacity int32                  `protobuf:"varint,4,opt,name=queue_capacity,json=queueCapacity,proto3" json:"queue_capacity,omitempty"`
+	Busy          int32                  `protobuf:"varint,5,opt,name=busy,proto3" json:"busy,omitempty"`
+	Paused        bool                   `protobuf:"varint,6,opt,name=paused,proto3" json:"paused,omitempty"`
+	Draining      bool                   `protobuf:"varint,7,opt,name=draining,proto3" json:"draining,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceStatus) Reset() {
+	*x = ServiceStatus{}
+	mi := &file_api_proto_dispatcher_v1_dispatcher_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceStatus) ProtoMessage() {}
+
+func (x *ServiceStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_dispatcher_v1_dispatcher_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceStatus.ProtoReflect.Descriptor instead.
+func (*ServiceStatus) Descriptor() ([]byte, []int) {
+	return file_api_proto_dispatcher_v1_dispatcher_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ServiceStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ServiceStatus) GetWorkers() int32 {
+	if x != nil {
+		return x.Workers
+	}
+	return 0
+}
+
+func (x *ServiceStatus) GetQueueLength() int32 {
+	if x != nil {
+		return x.QueueLength
+	}
+	return 0
+}
+
+func (x *ServiceStatus) GetQueueCapacity() int32 {
+	if x != nil {
+		return x.QueueCapacity
+	}
+	return 0
+}
+
+func (x *ServiceStatus) GetBusy() int32 {
+	if x != nil {
+		return x.Busy
+	}
+	return 0
+}
+
+func (x *ServiceStatus) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *ServiceStatus) GetDraining() bool {
+	if x != nil {
+		return x.Draining
+	}
+	return false
+}
+
+type ListServicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Services      []*ServiceStatus       `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServicesResponse) Reset() {
+	*x = ListServicesResponse{}
+	mi := &file_api_proto_dispatcher_v1_dispatcher_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServicesResponse) ProtoMessage() {}
+
+func (x *ListServicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_dispatcher_v1_dispatcher_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListServicesResponse.ProtoReflect.Descriptor instead.
+func (*ListServicesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_dispatcher_v1_dispatcher_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListServicesResponse) GetServices() []*ServiceStatus {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+type ListTenantsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// only return this tenant when set
+	Tenant        string `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTenantsRequest) Reset() {
+	*x = ListTenantsRequest{}
+	mi := &file_api_proto_dispatcher_v1_dispatcher_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTenantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenantsRequest) ProtoMessage() {}
+
+func (x *ListTenantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_dispatcher_v1_dispatcher_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenantsRequest.ProtoReflect.Descriptor instead.
+func (*ListTenantsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_dispatcher_v1_dispatcher_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListTenantsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListTenantsRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type TenantStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenant        string                 `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Queued        int32                  `protobuf:"varint,2,opt,name=queued,proto3" json:"queued,omitempty"`
+	Running       int32                  `protobuf:"varint,3,opt,name=running,proto3" json:"running,omitempty"`
+	MaxQueued     int32                  `protobuf:"varint,4,opt,name=max_queued,json=maxQueued,proto3" json:"max_queued,omitempty"`
+	MaxRunning    int32                  `protobuf:"varint,5,opt,name=max_running,json=maxRunning,proto3" json:"max_running,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TenantStatus) Reset() {
+	*x = TenantStatus{}
+	mi := &file_api_proto_dispatcher_v1_dispatcher_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantStatus) ProtoMessage() {}
+
+func (x *TenantStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_dispatcher_v1_dispatcher_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantStatus.ProtoReflect.Descriptor instead.
+func (*TenantStatus) Descriptor() ([]byte, []int) {
+	return file_api_proto_dispatcher_v1_dispatcher_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TenantStatus) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *TenantStatus) GetQueued() int32 {
+	if x != nil {
+		return x.Queued
+	}
+	return 0
+}
+
+func (x *TenantStatus) GetRunning() int32 {
+	if x != nil {
+		return x.Running
+	}
+	return 0
+}
+
+func (x *TenantStatus) GetMaxQueued() int32 {
+	if x != nil {
+		return x.MaxQueued
+	}
+	return 0
+}
+
+func (x *TenantStatus) GetMaxRunning() int32 {
+	if x != nil {
+		return x.MaxRunning
+	}
+	return 0
+}
+
+type ListTenantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenants       []*TenantStatus        `protobuf:"bytes,1,rep,name=tenants,proto3" json:"tenants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTenantsResponse) Reset() {
+	*x = ListTenantsResponse{}
+	mi := &file_api_proto_dispatcher_v1_dispatcher_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTenantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenantsResponse) ProtoMessage() {}
+
+func (x *ListTenantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_dispatcher_v1_dispatcher_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenantsResponse.ProtoReflect.Descriptor instead.
+func (*ListTenantsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_dispatcher_v1_dispatcher_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListTenantsResponse) GetTenants() []*TenantStatus {
+	if x != nil {
+		return x.Tenants
+	}
+	return nil
+}
+
+var File_api_proto_dispatcher_v1_dispatcher_proto protoreflect.FileDescriptor
+
+const file_api_proto_dispatcher_v1_dispatcher_proto_rawDesc = "" +
+	"\n" +
+	"(api/proto/dispatcher/v1/dispatcher.proto\x12\rdispatcher.v1\x1a\x1bgoogle/protobuf/empty.proto\"$\n" +
+	"\x0eServiceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xcf\x01\n" +
+	"\rServiceStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aworkers\x18\x02 \x01(\x05R\aworkers\x12!\n" +
+	"\fqueue_length\x18\x03 \x01(\x05R\vqueueLength\x12%\n" +
+	"\x0equeue_capacity\x18\x04 \x01(\x05R\rqueueCapacity\x12\x12\n" +
+	"\x04busy\x18\x05 \x01(\x05R\x04busy\x12\x16\n" +
+	"\x06paused\x18\x06 \x01(\bR\x06paused\x12\x1a\n" +
+	"\bdraining\x18\a \x01(\bR\bdraining\"P\n" +
+	"\x14ListServicesResponse\x128\n" +
+	"\bservices\x18\x01 \x03(\v2\x1c.dispatcher.v1.ServiceStatusR\bservices\"@\n" +
+	"\x12ListTenantsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06tenant\x18\x02 \x01(\tR\x06tenant\"\x98\x01\n" +
+	"\fTenantStatus\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12\x16\n" +
+	"\x06queued\x18\x02 \x01(\x05R\x06queued\x12\x18\n" +
+	"\arunning\x18\x03 \x01(\x05R\arunning\x12\x1d\n" +
+	"\n" +
+	"max_queued\x18\x04 \x01(\x05R\tmaxQueued\x12\x1f\n" +
+	"\vmax_running\x18\x05 \x01(\x05R\n" +
+	"maxRunning\"L\n" +
+	"\x13ListTenantsResponse\x125\n" +
+	"\atenants\x18\x01 \x03(\v2\x1b.dispatcher.v1.TenantStatusR\atenants2\x9e\x03\n" +
+	"\x11DispatcherService\x12K\n" +
+	"\fListServices\x12\x16.google.protobuf.Empty\x1a#.dispatcher.v1.ListServicesResponse\x12K\n" +
+	"\fPauseService\x12\x1d.dispatcher.v1.ServiceRequest\x1a\x1c.dispatcher.v1.ServiceStatus\x12L\n" +
+	"\rResumeService\x12\x1d.dispatcher.v1.ServiceRequest\x1a\x1c.dispatcher.v1.ServiceStatus\x12K\n" +
+	"\fDrainService\x12\x1d.dispatcher.v1.ServiceRequest\x1a\x1c.dispatcher.v1.ServiceStatus\x12T\n" +
+	"\vListTenants\x12!.dispatcher.v1.ListTenantsRequest\x1a\".dispatcher.v1.ListTenantsResponseB7Z5github.com/mobintmu/go-simple/api/proto/dispatcher/v1b\x06proto3"
+
+var (
+	file_api_proto_dispatcher_v1_dispatcher_proto_rawDescOnce sync.Once
+	file_api_proto_dispatcher_v1_dispatcher_proto_rawDescData []byte
+)
+
+func file_api_proto_dispatcher_v1_dispatcher_proto_rawDescGZIP() []byte {
+	file_api_proto_dispatcher_v1_dispatcher_proto_rawDescOnce.Do(func() {
+		file_api_proto_dispatcher_v1_dispatcher_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_dispatcher_v1_dispatcher_proto_rawDesc), len(file_api_proto_dispatcher_v1_dispatcher_proto_rawDesc)))
+	})
+	return file_api_proto_dispatcher_v1_dispatcher_proto_rawDescData
+}
+
+var file_api_proto_dispatcher_v1_dispatcher_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_api_proto_dispatcher_v1_dispatcher_proto_goTypes = []any{
+	(*ServiceRequest)(nil),       // 0: dispatcher.v1.ServiceRequest
+	(*ServiceStatus)(nil),        // 1: dispatcher.v1.ServiceStatus
+	(*ListServicesResponse)(nil), // 2: dispatcher.v1.ListServicesResponse
+	(*ListTenantsRequest)(nil),   // 3: dispatcher.v1.ListTenantsRequest
+	(*TenantStatus)(nil),         // 4: dispatcher.v1.TenantStatus
+	(*ListTenantsResponse)(nil),  // 5: dispatcher.v1.ListTenantsResponse
+	(*emptypb.Empty)(nil),        // 6: google.protobuf.Empty
+}
+var file_api_proto_dispatcher_v1_dispatcher_proto_depIdxs = []int32{
+	1, // 0: dispatcher.v1.ListServicesResponse.services:type_name -> dispatcher.v1.ServiceStatus
+	4, // 1: dispatcher.v1.ListTenantsResponse.tenants:type_name -> dispatcher.v1.TenantStatus
+	6, // 2: dispatcher.v1.DispatcherService.ListServices:input_type -> google.protobuf.Empty
+	0, // 3: dispatcher.v1.DispatcherService.PauseService:input_type -> dispatcher.v1.ServiceRequest
+	0, // 4: dispatcher.v1.DispatcherService.ResumeService:input_type -> dispatcher.v1.ServiceRequest
+	0, // 5: dispatcher.v1.DispatcherService.DrainService:input_type -> dispatcher.v1.ServiceRequest
+	3, // 6: dispatcher.v1.DispatcherService.ListTenants:input_type -> dispatcher.v1.ListTenantsRequest
+	2, // 7: dispatcher.v1.DispatcherService.ListServices:output_type -> dispatcher.v1.ListServicesResponse
+	1, // 8: dispatcher.v1.DispatcherService.PauseService:output_type -> dispatcher.v1.ServiceStatus
+	1, // 9: dispatcher.v1.DispatcherService.ResumeService:output_type -> dispatcher.v1.ServiceStatus
+	1, // 10: dispatcher.v1.DispatcherService.DrainService:output_type -> dispatcher.v1.ServiceStatus
+	5, // 11: dispatcher.v1.DispatcherService.ListTenants:output_type -> dispatcher.v1.ListTenantsResponse
+	7, // [7:12] is the sub-list for method output_type
+	2, // [2:7] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_dispatcher_v1_dispatcher_proto_init() }
+func file_api_proto_dispatcher_v1_dispatcher_proto_init() {
+	if File_api_proto_dispatcher_v1_dispatcher_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_dispatcher_v1_dispatcher_proto_rawDesc), len(file_api_proto_dispatcher_v1_dispatcher_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_proto_dispatcher_v1_dispatcher_proto_goTypes,
+		DependencyIndexes: file_api_proto_dispatcher_v1_dispatcher_proto_depIdxs,
+		MessageInfos:      file_api_proto_dispatcher_v1_dispatcher_proto_msgTypes,
+	}.Build()
+	File_api_proto_dispatcher_v1_dispatcher_proto = out.File
+	file_api_proto_dispatcher_v1_dispatcher_proto_goTypes = nil
+	file_api_proto_dispatcher_v1_dispatcher_proto_depIdxs = nil
+}
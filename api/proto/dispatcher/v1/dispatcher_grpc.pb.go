@@ -0,0 +1,274 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v4.22.3
+// source: api/proto/dispatcher/v1/dispatcher.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DispatcherService_ListServices_FullMethodName  = "/dispatcher.v1.DispatcherService/ListServices"
+	DispatcherService_PauseService_FullMethodName  = "/dispatcher.v1.DispatcherService/PauseService"
+	DispatcherService_ResumeService_FullMethodName = "/dispatcher.v1.DispatcherService/ResumeService"
+	DispatcherService_DrainService_FullMethodName  = "/dispatcher.v1.DispatcherService/DrainService"
+	DispatcherService_ListTenants_FullMethodName   = "/dispatcher.v1.DispatcherService/ListTenants"
+)
+
+// DispatcherServiceClient is the client API for DispatcherService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DispatcherServiceClient interface {
+	ListServices(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListServicesResponse, error)
+	PauseService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceStatus, error)
+	ResumeService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceStatus, error)
+	DrainService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceStatus, error)
+	ListTenants(ctx context.Context, in *ListTenantsRequest, opts ...grpc.CallOption) (*ListTenantsResponse, error)
+}
+
+type dispatcherServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDispatcherServiceClient(cc grpc.ClientConnInterface) DispatcherServiceClient {
+	return &dispatcherServiceClient{cc}
+}
+
+func (c *dispatcherServiceClient) ListServices(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListServicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListServicesResponse)
+	err := c.cc.Invoke(ctx, DispatcherService_ListServices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dispatcherServiceClient) PauseService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceStatus)
+	err := c.cc.Invoke(ctx, DispatcherService_PauseService_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dispatcherServiceClient) ResumeService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceStatus)
+	err := c.cc.Invoke(ctx, DispatcherService_ResumeService_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dispatcherServiceClient) DrainService(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*ServiceStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceStatus)
+	err := c.cc.Invoke(ctx, DispatcherService_DrainService_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dispatcherServiceClient) ListTenants(ctx context.Context, in *ListTenantsRequest, opts ...grpc.CallOption) (*ListTenantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTenantsResponse)
+	err := c.cc.Invoke(ctx, DispatcherService_ListTenants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DispatcherServiceServer is the server API for DispatcherService service.
+// All implementations must embed UnimplementedDispatcherServiceServer
+// for forward compatibility.
+type DispatcherServiceServer interface {
+	ListServices(context.Context, *emptypb.Empty) (*ListServicesResponse, error)
+	PauseService(context.Context, *ServiceRequest) (*ServiceStatus, error)
+	ResumeService(context.Context, *ServiceRequest) (*ServiceStatus, error)
+	DrainService(context.Context, *ServiceRequest) (*ServiceStatus, error)
+	ListTenants(context.Context, *ListTenantsRequest) (*ListTenantsResponse, error)
+	mustEmbedUnimplementedDispatcherServiceServer()
+}
+
+// UnimplementedDispatcherServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDispatcherServiceServer struct{}
+
+func (UnimplementedDispatcherServiceServer) ListServices(context.Context, *emptypb.Empty) (*ListServicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServices not implemented")
+}
+func (UnimplementedDispatcherServiceServer) PauseService(context.Context, *ServiceRequest) (*ServiceStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseService not implemented")
+}
+func (UnimplementedDispatcherServiceServer) ResumeService(context.Context, *ServiceRequest) (*ServiceStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeService not implemented")
+}
+func (UnimplementedDispatcherServiceServer) DrainService(context.Context, *ServiceRequest) (*ServiceStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DrainService not implemented")
+}
+func (UnimplementedDispatcherServiceServer) ListTenants(context.Context, *ListTenantsRequest) (*ListTenantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTenants not implemented")
+}
+func (UnimplementedDispatcherServiceServer) mustEmbedUnimplementedDispatcherServiceServer() {}
+func (UnimplementedDispatcherServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeDispatcherServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DispatcherServiceServer will
+// result in compilation errors.
+type UnsafeDispatcherServiceServer interface {
+	mustEmbedUnimplementedDispatcherServiceServer()
+}
+
+func RegisterDispatcherServiceServer(s grpc.ServiceRegistrar, srv DispatcherServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDispatcherServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DispatcherService_ServiceDesc, srv)
+}
+
+func _DispatcherService_ListServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DispatcherServiceServer).ListServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DispatcherService_ListServices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DispatcherServiceServer).ListServices(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DispatcherService_PauseService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DispatcherServiceServer).PauseService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DispatcherService_PauseService_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DispatcherServiceServer).PauseService(ctx, req.(*ServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DispatcherService_ResumeService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DispatcherServiceServer).ResumeService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DispatcherService_ResumeService_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DispatcherServiceServer).ResumeService(ctx, req.(*ServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DispatcherService_DrainService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DispatcherServiceServer).DrainService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DispatcherService_DrainService_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DispatcherServiceServer).DrainService(ctx, req.(*ServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DispatcherService_ListTenants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTenantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DispatcherServiceServer).ListTenants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DispatcherService_ListTenants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DispatcherServiceServer).ListTenants(ctx, req.(*ListTenantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DispatcherService_ServiceDesc is the grpc.ServiceDesc for DispatcherService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DispatcherService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dispatcher.v1.DispatcherService",
+	HandlerType: (*DispatcherServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListServices",
+			Handler:    _DispatcherService_ListServices_Handler,
+		},
+		{
+			MethodName: "PauseService",
+			Handler:    _DispatcherService_PauseService_Handler,
+		},
+		{
+			MethodName: "ResumeService",
+			Handler:    _DispatcherService_ResumeService_Handler,
+		},
+		{
+			MethodName: "DrainService",
+			Handler:    _DispatcherService_DrainService_Handler,
+		},
+		{
+			MethodName: "ListTenants",
+			Handler:    _DispatcherService_ListTenants_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/dispatcher/v1/dispatcher.proto",
+}
@@ -9,7 +9,8 @@ package v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -66,19 +67,80 @@ func (x *ProductRequest) GetId() int32 {
 	return 0
 }
 
+// amount in the minor units of currency_code, 1050 USD is $10.50
+type Money struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ISO 4217 code such as USD
+	CurrencyCode  string `protobuf:"bytes,1,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	Amount        int64  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Money) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
 type ProductResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         int64                  `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// amount of base_price, kept for older clients
+	Price int64 `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	// changes on every update, send it as expected_version to update
+	Version int32 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	// only set on the product returned by a change, reads only return active products
+	IsActive      bool                   `protobuf:"varint,6,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	BasePrice     *Money                 `protobuf:"bytes,8,opt,name=base_price,json=basePrice,proto3" json:"base_price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ProductResponse) Reset() {
 	*x = ProductResponse{}
-	mi := &file_api_proto_product_v1_product_proto_msgTypes[1]
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -90,7 +152,7 @@ func (x *ProductResponse) String() string {
 func (*ProductResponse) ProtoMessage() {}
 
 func (x *ProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_product_v1_product_proto_msgTypes[1]
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -103,7 +165,7 @@ func (x *ProductResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProductResponse.ProtoReflect.Descriptor instead.
 func (*ProductResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{1}
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ProductResponse) GetId() int32 {
@@ -134,18 +196,49 @@ func (x *ProductResponse) GetPrice() int64 {
 	return 0
 }
 
+func (x *ProductResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ProductResponse) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *ProductResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ProductResponse) GetBasePrice() *Money {
+	if x != nil {
+		return x.BasePrice
+	}
+	return nil
+}
+
 type CreateProductRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Price         int64                  `protobuf:"varint,3,opt,name=price,proto3" json:"price,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// minor units of currency
+	Price int64 `protobuf:"varint,3,opt,name=price,proto3" json:"price,omitempty"`
+	// ISO 4217 code, USD when empty
+	Currency      string `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateProductRequest) Reset() {
 	*x = CreateProductRequest{}
-	mi := &file_api_proto_product_v1_product_proto_msgTypes[2]
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -157,7 +250,7 @@ func (x *CreateProductRequest) String() string {
 func (*CreateProductRequest) ProtoMessage() {}
 
 func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_product_v1_product_proto_msgTypes[2]
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -170,7 +263,7 @@ func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
 func (*CreateProductRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{2}
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CreateProductRequest) GetName() string {
@@ -194,20 +287,32 @@ func (x *CreateProductRequest) GetPrice() int64 {
 	return 0
 }
 
+func (x *CreateProductRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
 type UpdateProductRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         int64                  `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
-	IsActive      bool                   `protobuf:"varint,5,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price       int64                  `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	IsActive    bool                   `protobuf:"varint,5,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	// version the change was made against, the update is aborted if the
+	// product changed since; 0 updates unconditionally
+	ExpectedVersion int32 `protobuf:"varint,6,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// ISO 4217 code, the current one is kept when empty
+	Currency      string `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateProductRequest) Reset() {
 	*x = UpdateProductRequest{}
-	mi := &file_api_proto_product_v1_product_proto_msgTypes[3]
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -219,7 +324,7 @@ func (x *UpdateProductRequest) String() string {
 func (*UpdateProductRequest) ProtoMessage() {}
 
 func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_product_v1_product_proto_msgTypes[3]
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -232,7 +337,7 @@ func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
 func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *UpdateProductRequest) GetId() int32 {
@@ -270,6 +375,123 @@ func (x *UpdateProductRequest) GetIsActive() bool {
 	return false
 }
 
+func (x *UpdateProductRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type PatchProductRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price       int64                  `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	IsActive    bool                   `protobuf:"varint,5,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	// fields to change: name, description, price, is_active and currency
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,6,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// version the change was made against, the update is aborted if the
+	// product changed since; 0 updates unconditionally
+	ExpectedVersion int32  `protobuf:"varint,7,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	Currency        string `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PatchProductRequest) Reset() {
+	*x = PatchProductRequest{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchProductRequest) ProtoMessage() {}
+
+func (x *PatchProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchProductRequest.ProtoReflect.Descriptor instead.
+func (*PatchProductRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PatchProductRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PatchProductRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PatchProductRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PatchProductRequest) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PatchProductRequest) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *PatchProductRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *PatchProductRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *PatchProductRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
 type DeleteProductRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -279,7 +501,7 @@ type DeleteProductRequest struct {
 
 func (x *DeleteProductRequest) Reset() {
 	*x = DeleteProductRequest{}
-	mi := &file_api_proto_product_v1_product_proto_msgTypes[4]
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -291,7 +513,7 @@ func (x *DeleteProductRequest) String() string {
 func (*DeleteProductRequest) ProtoMessage() {}
 
 func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_product_v1_product_proto_msgTypes[4]
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -304,7 +526,7 @@ func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
 func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{4}
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DeleteProductRequest) GetId() int32 {
@@ -314,16 +536,134 @@ func (x *DeleteProductRequest) GetId() int32 {
 	return 0
 }
 
+type ListProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// next_cursor of the previous page, empty for the first page
+	Cursor string `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// page size, default 20, max 100
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// ignored, only active products are listed
+	Active   *bool  `protobuf:"varint,3,opt,name=active,proto3,oneof" json:"active,omitempty"`
+	MinPrice *int64 `protobuf:"varint,4,opt,name=min_price,json=minPrice,proto3,oneof" json:"min_price,omitempty"`
+	MaxPrice *int64 `protobuf:"varint,5,opt,name=max_price,json=maxPrice,proto3,oneof" json:"max_price,omitempty"`
+	// name contains, case insensitive
+	Name string `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	// created_at_desc (default), created_at_asc, price_asc, price_desc, name_asc or name_desc
+	Sort string `protobuf:"bytes,7,opt,name=sort,proto3" json:"sort,omitempty"`
+	// category, its subcategories included
+	CategoryId    *int32 `protobuf:"varint,8,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	Tag           string `protobuf:"bytes,9,opt,name=tag,proto3" json:"tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListProductsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetActive() bool {
+	if x != nil && x.Active != nil {
+		return *x.Active
+	}
+	return false
+}
+
+func (x *ListProductsRequest) GetMinPrice() int64 {
+	if x != nil && x.MinPrice != nil {
+		return *x.MinPrice
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetMaxPrice() int64 {
+	if x != nil && x.MaxPrice != nil {
+		return *x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetCategoryId() int32 {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
 type ListProductsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Products      []*ProductResponse     `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Products []*ProductResponse     `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	// empty on the last page
+	NextCursor string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// number of products matching the filters across all pages
+	Total         int64 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListProductsResponse) Reset() {
 	*x = ListProductsResponse{}
-	mi := &file_api_proto_product_v1_product_proto_msgTypes[5]
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -335,7 +675,7 @@ func (x *ListProductsResponse) String() string {
 func (*ListProductsResponse) ProtoMessage() {}
 
 func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_product_v1_product_proto_msgTypes[5]
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -348,7 +688,7 @@ func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
 func (*ListProductsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{5}
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ListProductsResponse) GetProducts() []*ProductResponse {
@@ -358,39 +698,1730 @@ func (x *ListProductsResponse) GetProducts() []*ProductResponse {
 	return nil
 }
 
-var File_api_proto_product_v1_product_proto protoreflect.FileDescriptor
+func (x *ListProductsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
 
-const file_api_proto_product_v1_product_proto_rawDesc = "" +
-	"\n" +
-	"\"api/proto/product/v1/product.proto\x12\n" +
-	"product.v1\x1a\x1bgoogle/protobuf/empty.proto\" \n" +
-	"\x0eProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"m\n" +
-	"\x0fProductResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x03R\x05price\"b\n" +
-	"\x14CreateProductRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x03R\x05price\"\x8f\x01\n" +
-	"\x14UpdateProductRequest\x12\x0e\n" +
+func (x *ListProductsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type SearchProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// every word must match, as a whole word or as a prefix
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// next_cursor of the previous page, empty for the first page
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// page size, default 20, max 100
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProductsRequest) Reset() {
+	*x = SearchProductsRequest{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProductsRequest) ProtoMessage() {}
+
+func (x *SearchProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProductsRequest.ProtoReflect.Descriptor instead.
+func (*SearchProductsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchProductsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Product *ProductResponse       `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Rank    float32                `protobuf:"fixed32,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	// matched words are wrapped in <mark></mark>
+	NameHighlight        string `protobuf:"bytes,3,opt,name=name_highlight,json=nameHighlight,proto3" json:"name_highlight,omitempty"`
+	DescriptionHighlight string `protobuf:"bytes,4,opt,name=description_highlight,json=descriptionHighlight,proto3" json:"description_highlight,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchResult) GetProduct() *ProductResponse {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *SearchResult) GetRank() float32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *SearchResult) GetNameHighlight() string {
+	if x != nil {
+		return x.NameHighlight
+	}
+	return ""
+}
+
+func (x *SearchResult) GetDescriptionHighlight() string {
+	if x != nil {
+		return x.DescriptionHighlight
+	}
+	return ""
+}
+
+type SearchProductsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Results []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// empty on the last page
+	NextCursor string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// number of matching products across all pages
+	Total         int64 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProductsResponse) Reset() {
+	*x = SearchProductsResponse{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProductsResponse) ProtoMessage() {}
+
+func (x *SearchProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProductsResponse.ProtoReflect.Descriptor instead.
+func (*SearchProductsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchProductsResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchProductsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *SearchProductsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type ProductSnapshot struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price       int64                  `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	IsActive    bool                   `protobuf:"varint,5,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// set while the product is deleted
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	Currency      string                 `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductSnapshot) Reset() {
+	*x = ProductSnapshot{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductSnapshot) ProtoMessage() {}
+
+func (x *ProductSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductSnapshot.ProtoReflect.Descriptor instead.
+func (*ProductSnapshot) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ProductSnapshot) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ProductSnapshot) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProductSnapshot) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ProductSnapshot) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *ProductSnapshot) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *ProductSnapshot) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ProductSnapshot) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *ProductSnapshot) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ProductHistoryEntry struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId int32                  `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// create, update, delete or restore
+	Action  string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	AdminId string `protobuf:"bytes,4,opt,name=admin_id,json=adminId,proto3" json:"admin_id,omitempty"`
+	// not set for create
+	Before        *ProductSnapshot       `protobuf:"bytes,5,opt,name=before,proto3" json:"before,omitempty"`
+	After         *ProductSnapshot       `protobuf:"bytes,6,opt,name=after,proto3" json:"after,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductHistoryEntry) Reset() {
+	*x = ProductHistoryEntry{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductHistoryEntry) ProtoMessage() {}
+
+func (x *ProductHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductHistoryEntry.ProtoReflect.Descriptor instead.
+func (*ProductHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ProductHistoryEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ProductHistoryEntry) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ProductHistoryEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ProductHistoryEntry) GetAdminId() string {
+	if x != nil {
+		return x.AdminId
+	}
+	return ""
+}
+
+func (x *ProductHistoryEntry) GetBefore() *ProductSnapshot {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *ProductHistoryEntry) GetAfter() *ProductSnapshot {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *ProductHistoryEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ProductHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// newest first
+	Entries       []*ProductHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductHistoryResponse) Reset() {
+	*x = ProductHistoryResponse{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductHistoryResponse) ProtoMessage() {}
+
+func (x *ProductHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ProductHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ProductHistoryResponse) GetEntries() []*ProductHistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type Category struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Slug  string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	// 0 for a top level category
+	ParentId      int32                  `protobuf:"varint,4,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Children      []*Category            `protobuf:"bytes,6,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Category) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Category) GetParentId() int32 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *Category) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Category) GetChildren() []*Category {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type CategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryRequest) Reset() {
+	*x = CategoryRequest{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryRequest) ProtoMessage() {}
+
+func (x *CategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryRequest.ProtoReflect.Descriptor instead.
+func (*CategoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CategoryRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CreateCategoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// made from the name when empty
+	Slug          string `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	ParentId      *int32 `protobuf:"varint,3,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCategoryRequest) Reset() {
+	*x = CreateCategoryRequest{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategoryRequest) ProtoMessage() {}
+
+func (x *CreateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*CreateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateCategoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCategoryRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *CreateCategoryRequest) GetParentId() int32 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return 0
+}
+
+type UpdateCategoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// made from the name when empty
+	Slug          string `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	ParentId      *int32 `protobuf:"varint,4,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCategoryRequest) Reset() {
+	*x = UpdateCategoryRequest{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCategoryRequest) ProtoMessage() {}
+
+func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateCategoryRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateCategoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateCategoryRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *UpdateCategoryRequest) GetParentId() int32 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return 0
+}
+
+type ListCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesRequest) Reset() {
+	*x = ListCategoriesRequest{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesRequest) ProtoMessage() {}
+
+func (x *ListCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{19}
+}
+
+type ListCategoriesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// top level categories, each with its subcategories
+	Categories    []*Category `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesResponse) Reset() {
+	*x = ListCategoriesResponse{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesResponse) ProtoMessage() {}
+
+func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListCategoriesResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type DeleteCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCategoryResponse) Reset() {
+	*x = DeleteCategoryResponse{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCategoryResponse) ProtoMessage() {}
+
+func (x *DeleteCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCategoryResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{21}
+}
+
+type SetProductCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	CategoryIds   []int32                `protobuf:"varint,2,rep,packed,name=category_ids,json=categoryIds,proto3" json:"category_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetProductCategoriesRequest) Reset() {
+	*x = SetProductCategoriesRequest{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetProductCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProductCategoriesRequest) ProtoMessage() {}
+
+func (x *SetProductCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetProductCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*SetProductCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SetProductCategoriesRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *SetProductCategoriesRequest) GetCategoryIds() []int32 {
+	if x != nil {
+		return x.CategoryIds
+	}
+	return nil
+}
+
+type ProductCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductCategoriesResponse) Reset() {
+	*x = ProductCategoriesResponse{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductCategoriesResponse) ProtoMessage() {}
+
+func (x *ProductCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ProductCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ProductCategoriesResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type SetProductTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetProductTagsRequest) Reset() {
+	*x = SetProductTagsRequest{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetProductTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProductTagsRequest) ProtoMessage() {}
+
+func (x *SetProductTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetProductTagsRequest.ProtoReflect.Descriptor instead.
+func (*SetProductTagsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetProductTagsRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *SetProductTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type ProductTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []string               `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductTagsResponse) Reset() {
+	*x = ProductTagsResponse{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductTagsResponse) ProtoMessage() {}
+
+func (x *ProductTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductTagsResponse.ProtoReflect.Descriptor instead.
+func (*ProductTagsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ProductTagsResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// price of a product in one currency from valid_from until valid_to
+type ProductPrice struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId int32                  `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Price     *Money                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	ValidFrom *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	// not set for a price that does not end
+	ValidTo       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=valid_to,json=validTo,proto3" json:"valid_to,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductPrice) Reset() {
+	*x = ProductPrice{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductPrice) ProtoMessage() {}
+
+func (x *ProductPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductPrice.ProtoReflect.Descriptor instead.
+func (*ProductPrice) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ProductPrice) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ProductPrice) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ProductPrice) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *ProductPrice) GetValidFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return nil
+}
+
+func (x *ProductPrice) GetValidTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidTo
+	}
+	return nil
+}
+
+func (x *ProductPrice) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListProductPricesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// only the prices in effect now
+	Current       bool `protobuf:"varint,2,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductPricesRequest) Reset() {
+	*x = ListProductPricesRequest{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductPricesRequest) ProtoMessage() {}
+
+func (x *ListProductPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductPricesRequest.ProtoReflect.Descriptor instead.
+func (*ListProductPricesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListProductPricesRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ListProductPricesRequest) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type ListProductPricesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prices        []*ProductPrice        `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductPricesResponse) Reset() {
+	*x = ListProductPricesResponse{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductPricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductPricesResponse) ProtoMessage() {}
+
+func (x *ListProductPricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductPricesResponse.ProtoReflect.Descriptor instead.
+func (*ListProductPricesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListProductPricesResponse) GetPrices() []*ProductPrice {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+type AddProductPriceRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Price     *Money                 `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	// now when not set
+	ValidFrom *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	// not set for a price that does not end
+	ValidTo       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=valid_to,json=validTo,proto3" json:"valid_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddProductPriceRequest) Reset() {
+	*x = AddProductPriceRequest{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddProductPriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProductPriceRequest) ProtoMessage() {}
+
+func (x *AddProductPriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProductPriceRequest.ProtoReflect.Descriptor instead.
+func (*AddProductPriceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AddProductPriceRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *AddProductPriceRequest) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *AddProductPriceRequest) GetValidFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return nil
+}
+
+func (x *AddProductPriceRequest) GetValidTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidTo
+	}
+	return nil
+}
+
+type DeleteProductPriceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	PriceId       int64                  `protobuf:"varint,2,opt,name=price_id,json=priceId,proto3" json:"price_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductPriceRequest) Reset() {
+	*x = DeleteProductPriceRequest{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductPriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductPriceRequest) ProtoMessage() {}
+
+func (x *DeleteProductPriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductPriceRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductPriceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeleteProductPriceRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *DeleteProductPriceRequest) GetPriceId() int64 {
+	if x != nil {
+		return x.PriceId
+	}
+	return 0
+}
+
+type DeleteProductPriceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductPriceResponse) Reset() {
+	*x = DeleteProductPriceResponse{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductPriceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductPriceResponse) ProtoMessage() {}
+
+func (x *DeleteProductPriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductPriceResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProductPriceResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{31}
+}
+
+type PriceHistoryEntry struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId int32                  `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	PriceId   int64                  `protobuf:"varint,3,opt,name=price_id,json=priceId,proto3" json:"price_id,omitempty"`
+	// add or remove
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	AdminId       string                 `protobuf:"bytes,5,opt,name=admin_id,json=adminId,proto3" json:"admin_id,omitempty"`
+	Price         *Money                 `protobuf:"bytes,6,opt,name=price,proto3" json:"price,omitempty"`
+	ValidFrom     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidTo       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=valid_to,json=validTo,proto3" json:"valid_to,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceHistoryEntry) Reset() {
+	*x = PriceHistoryEntry{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceHistoryEntry) ProtoMessage() {}
+
+func (x *PriceHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceHistoryEntry.ProtoReflect.Descriptor instead.
+func (*PriceHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PriceHistoryEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PriceHistoryEntry) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *PriceHistoryEntry) GetPriceId() int64 {
+	if x != nil {
+		return x.PriceId
+	}
+	return 0
+}
+
+func (x *PriceHistoryEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *PriceHistoryEntry) GetAdminId() string {
+	if x != nil {
+		return x.AdminId
+	}
+	return ""
+}
+
+func (x *PriceHistoryEntry) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *PriceHistoryEntry) GetValidFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return nil
+}
+
+func (x *PriceHistoryEntry) GetValidTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidTo
+	}
+	return nil
+}
+
+func (x *PriceHistoryEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type PriceHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// newest first
+	Entries       []*PriceHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceHistoryResponse) Reset() {
+	*x = PriceHistoryResponse{}
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceHistoryResponse) ProtoMessage() {}
+
+func (x *PriceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_v1_product_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*PriceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_v1_product_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PriceHistoryResponse) GetEntries() []*PriceHistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+var File_api_proto_product_v1_product_proto protoreflect.FileDescriptor
+
+const file_api_proto_product_v1_product_proto_rawDesc = "" +
+	"\n" +
+	"\"api/proto/product/v1/product.proto\x12\n" +
+	"product.v1\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\" \n" +
+	"\x0eProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"D\n" +
+	"\x05Money\x12#\n" +
+	"\rcurrency_code\x18\x01 \x01(\tR\fcurrencyCode\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\"\x91\x02\n" +
+	"\x0fProductResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x03R\x05price\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x05R\aversion\x12\x1b\n" +
+	"\tis_active\x18\x06 \x01(\bR\bisActive\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x120\n" +
+	"\n" +
+	"base_price\x18\b \x01(\v2\x11.product.v1.MoneyR\tbasePrice\"~\n" +
+	"\x14CreateProductRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x03R\x05price\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\"\xd6\x01\n" +
+	"\x14UpdateProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x03R\x05price\x12\x1b\n" +
+	"\tis_active\x18\x05 \x01(\bR\bisActive\x12)\n" +
+	"\x10expected_version\x18\x06 \x01(\x05R\x0fexpectedVersion\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\"\x92\x02\n" +
+	"\x13PatchProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
 	"\x05price\x18\x04 \x01(\x03R\x05price\x12\x1b\n" +
-	"\tis_active\x18\x05 \x01(\bR\bisActive\"&\n" +
+	"\tis_active\x18\x05 \x01(\bR\bisActive\x12;\n" +
+	"\vupdate_mask\x18\x06 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12)\n" +
+	"\x10expected_version\x18\a \x01(\x05R\x0fexpectedVersion\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\"&\n" +
 	"\x14DeleteProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"O\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\xbb\x02\n" +
+	"\x13ListProductsRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1b\n" +
+	"\x06active\x18\x03 \x01(\bH\x00R\x06active\x88\x01\x01\x12 \n" +
+	"\tmin_price\x18\x04 \x01(\x03H\x01R\bminPrice\x88\x01\x01\x12 \n" +
+	"\tmax_price\x18\x05 \x01(\x03H\x02R\bmaxPrice\x88\x01\x01\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12\x12\n" +
+	"\x04sort\x18\a \x01(\tR\x04sort\x12$\n" +
+	"\vcategory_id\x18\b \x01(\x05H\x03R\n" +
+	"categoryId\x88\x01\x01\x12\x10\n" +
+	"\x03tag\x18\t \x01(\tR\x03tagB\t\n" +
+	"\a_activeB\f\n" +
+	"\n" +
+	"_min_priceB\f\n" +
+	"\n" +
+	"_max_priceB\x0e\n" +
+	"\f_category_id\"\x86\x01\n" +
 	"\x14ListProductsResponse\x127\n" +
-	"\bproducts\x18\x01 \x03(\v2\x1b.product.v1.ProductResponseR\bproducts2\x95\x03\n" +
+	"\bproducts\x18\x01 \x03(\v2\x1b.product.v1.ProductResponseR\bproducts\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\"[\n" +
+	"\x15SearchProductsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xb5\x01\n" +
+	"\fSearchResult\x125\n" +
+	"\aproduct\x18\x01 \x01(\v2\x1b.product.v1.ProductResponseR\aproduct\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x02R\x04rank\x12%\n" +
+	"\x0ename_highlight\x18\x03 \x01(\tR\rnameHighlight\x123\n" +
+	"\x15description_highlight\x18\x04 \x01(\tR\x14descriptionHighlight\"\x83\x01\n" +
+	"\x16SearchProductsResponse\x122\n" +
+	"\aresults\x18\x01 \x03(\v2\x18.product.v1.SearchResultR\aresults\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\"\x9c\x02\n" +
+	"\x0fProductSnapshot\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x03R\x05price\x12\x1b\n" +
+	"\tis_active\x18\x05 \x01(\bR\bisActive\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\"\x9a\x02\n" +
+	"\x13ProductHistoryEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\x05R\tproductId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x19\n" +
+	"\badmin_id\x18\x04 \x01(\tR\aadminId\x123\n" +
+	"\x06before\x18\x05 \x01(\v2\x1b.product.v1.ProductSnapshotR\x06before\x121\n" +
+	"\x05after\x18\x06 \x01(\v2\x1b.product.v1.ProductSnapshotR\x05after\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"S\n" +
+	"\x16ProductHistoryResponse\x129\n" +
+	"\aentries\x18\x01 \x03(\v2\x1f.product.v1.ProductHistoryEntryR\aentries\"\xcc\x01\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\x12\x1b\n" +
+	"\tparent_id\x18\x04 \x01(\x05R\bparentId\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x120\n" +
+	"\bchildren\x18\x06 \x03(\v2\x14.product.v1.CategoryR\bchildren\"!\n" +
+	"\x0fCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"o\n" +
+	"\x15CreateCategoryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x12 \n" +
+	"\tparent_id\x18\x03 \x01(\x05H\x00R\bparentId\x88\x01\x01B\f\n" +
+	"\n" +
+	"_parent_id\"\x7f\n" +
+	"\x15UpdateCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\x12 \n" +
+	"\tparent_id\x18\x04 \x01(\x05H\x00R\bparentId\x88\x01\x01B\f\n" +
+	"\n" +
+	"_parent_id\"\x17\n" +
+	"\x15ListCategoriesRequest\"N\n" +
+	"\x16ListCategoriesResponse\x124\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x14.product.v1.CategoryR\n" +
+	"categories\"\x18\n" +
+	"\x16DeleteCategoryResponse\"_\n" +
+	"\x1bSetProductCategoriesRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12!\n" +
+	"\fcategory_ids\x18\x02 \x03(\x05R\vcategoryIds\"Q\n" +
+	"\x19ProductCategoriesResponse\x124\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x14.product.v1.CategoryR\n" +
+	"categories\"J\n" +
+	"\x15SetProductTagsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\")\n" +
+	"\x13ProductTagsResponse\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\"\x93\x02\n" +
+	"\fProductPrice\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\x05R\tproductId\x12'\n" +
+	"\x05price\x18\x03 \x01(\v2\x11.product.v1.MoneyR\x05price\x129\n" +
+	"\n" +
+	"valid_from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tvalidFrom\x125\n" +
+	"\bvalid_to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\avalidTo\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"S\n" +
+	"\x18ListProductPricesRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x18\n" +
+	"\acurrent\x18\x02 \x01(\bR\acurrent\"M\n" +
+	"\x19ListProductPricesResponse\x120\n" +
+	"\x06prices\x18\x01 \x03(\v2\x18.product.v1.ProductPriceR\x06prices\"\xd2\x01\n" +
+	"\x16AddProductPriceRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12'\n" +
+	"\x05price\x18\x02 \x01(\v2\x11.product.v1.MoneyR\x05price\x129\n" +
+	"\n" +
+	"valid_from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tvalidFrom\x125\n" +
+	"\bvalid_to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\avalidTo\"U\n" +
+	"\x19DeleteProductPriceRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x19\n" +
+	"\bprice_id\x18\x02 \x01(\x03R\apriceId\"\x1c\n" +
+	"\x1aDeleteProductPriceResponse\"\xe6\x02\n" +
+	"\x11PriceHistoryEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\x05R\tproductId\x12\x19\n" +
+	"\bprice_id\x18\x03 \x01(\x03R\apriceId\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x19\n" +
+	"\badmin_id\x18\x05 \x01(\tR\aadminId\x12'\n" +
+	"\x05price\x18\x06 \x01(\v2\x11.product.v1.MoneyR\x05price\x129\n" +
+	"\n" +
+	"valid_from\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tvalidFrom\x125\n" +
+	"\bvalid_to\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\avalidTo\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"O\n" +
+	"\x14PriceHistoryResponse\x127\n" +
+	"\aentries\x18\x01 \x03(\v2\x1d.product.v1.PriceHistoryEntryR\aentries2\x97\r\n" +
 	"\x0eProductService\x12I\n" +
 	"\x0eGetProductByID\x12\x1a.product.v1.ProductRequest\x1a\x1b.product.v1.ProductResponse\x12N\n" +
 	"\rCreateProduct\x12 .product.v1.CreateProductRequest\x1a\x1b.product.v1.ProductResponse\x12N\n" +
-	"\rUpdateProduct\x12 .product.v1.UpdateProductRequest\x1a\x1b.product.v1.ProductResponse\x12N\n" +
-	"\rDeleteProduct\x12 .product.v1.DeleteProductRequest\x1a\x1b.product.v1.ProductResponse\x12H\n" +
-	"\fListProducts\x12\x16.google.protobuf.Empty\x1a .product.v1.ListProductsResponseB4Z2github.com/mobintmu/go-simple/api/proto/product/v1b\x06proto3"
+	"\rUpdateProduct\x12 .product.v1.UpdateProductRequest\x1a\x1b.product.v1.ProductResponse\x12L\n" +
+	"\fPatchProduct\x12\x1f.product.v1.PatchProductRequest\x1a\x1b.product.v1.ProductResponse\x12N\n" +
+	"\rDeleteProduct\x12 .product.v1.DeleteProductRequest\x1a\x1b.product.v1.ProductResponse\x12I\n" +
+	"\x0eRestoreProduct\x12\x1a.product.v1.ProductRequest\x1a\x1b.product.v1.ProductResponse\x12S\n" +
+	"\x11GetProductHistory\x12\x1a.product.v1.ProductRequest\x1a\".product.v1.ProductHistoryResponse\x12Q\n" +
+	"\fListProducts\x12\x1f.product.v1.ListProductsRequest\x1a .product.v1.ListProductsResponse\x12W\n" +
+	"\x0eSearchProducts\x12!.product.v1.SearchProductsRequest\x1a\".product.v1.SearchProductsResponse\x12I\n" +
+	"\x0eCreateCategory\x12!.product.v1.CreateCategoryRequest\x1a\x14.product.v1.Category\x12@\n" +
+	"\vGetCategory\x12\x1b.product.v1.CategoryRequest\x1a\x14.product.v1.Category\x12W\n" +
+	"\x0eListCategories\x12!.product.v1.ListCategoriesRequest\x1a\".product.v1.ListCategoriesResponse\x12I\n" +
+	"\x0eUpdateCategory\x12!.product.v1.UpdateCategoryRequest\x1a\x14.product.v1.Category\x12Q\n" +
+	"\x0eDeleteCategory\x12\x1b.product.v1.CategoryRequest\x1a\".product.v1.DeleteCategoryResponse\x12f\n" +
+	"\x14SetProductCategories\x12'.product.v1.SetProductCategoriesRequest\x1a%.product.v1.ProductCategoriesResponse\x12T\n" +
+	"\x0eSetProductTags\x12!.product.v1.SetProductTagsRequest\x1a\x1f.product.v1.ProductTagsResponse\x12`\n" +
+	"\x11ListProductPrices\x12$.product.v1.ListProductPricesRequest\x1a%.product.v1.ListProductPricesResponse\x12O\n" +
+	"\x0fAddProductPrice\x12\".product.v1.AddProductPriceRequest\x1a\x18.product.v1.ProductPrice\x12c\n" +
+	"\x12DeleteProductPrice\x12%.product.v1.DeleteProductPriceRequest\x1a&.product.v1.DeleteProductPriceResponse\x12V\n" +
+	"\x16GetProductPriceHistory\x12\x1a.product.v1.ProductRequest\x1a .product.v1.PriceHistoryResponseB4Z2github.com/mobintmu/go-simple/api/proto/product/v1b\x06proto3"
 
 var (
 	file_api_proto_product_v1_product_proto_rawDescOnce sync.Once
@@ -404,33 +2435,120 @@ func file_api_proto_product_v1_product_proto_rawDescGZIP() []byte {
 	return file_api_proto_product_v1_product_proto_rawDescData
 }
 
-var file_api_proto_product_v1_product_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_api_proto_product_v1_product_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_api_proto_product_v1_product_proto_goTypes = []any{
-	(*ProductRequest)(nil),       // 0: product.v1.ProductRequest
-	(*ProductResponse)(nil),      // 1: product.v1.ProductResponse
-	(*CreateProductRequest)(nil), // 2: product.v1.CreateProductRequest
-	(*UpdateProductRequest)(nil), // 3: product.v1.UpdateProductRequest
-	(*DeleteProductRequest)(nil), // 4: product.v1.DeleteProductRequest
-	(*ListProductsResponse)(nil), // 5: product.v1.ListProductsResponse
-	(*emptypb.Empty)(nil),        // 6: google.protobuf.Empty
+	(*ProductRequest)(nil),              // 0: product.v1.ProductRequest
+	(*Money)(nil),                       // 1: product.v1.Money
+	(*ProductResponse)(nil),             // 2: product.v1.ProductResponse
+	(*CreateProductRequest)(nil),        // 3: product.v1.CreateProductRequest
+	(*UpdateProductRequest)(nil),        // 4: product.v1.UpdateProductRequest
+	(*PatchProductRequest)(nil),         // 5: product.v1.PatchProductRequest
+	(*DeleteProductRequest)(nil),        // 6: product.v1.DeleteProductRequest
+	(*ListProductsRequest)(nil),         // 7: product.v1.ListProductsRequest
+	(*ListProductsResponse)(nil),        // 8: product.v1.ListProductsResponse
+	(*SearchProductsRequest)(nil),       // 9: product.v1.SearchProductsRequest
+	(*SearchResult)(nil),                // 10: product.v1.SearchResult
+	(*SearchProductsResponse)(nil),      // 11: product.v1.SearchProductsResponse
+	(*ProductSnapshot)(nil),             // 12: product.v1.ProductSnapshot
+	(*ProductHistoryEntry)(nil),         // 13: product.v1.ProductHistoryEntry
+	(*ProductHistoryResponse)(nil),      // 14: product.v1.ProductHistoryResponse
+	(*Category)(nil),                    // 15: product.v1.Category
+	(*CategoryRequest)(nil),             // 16: product.v1.CategoryRequest
+	(*CreateCategoryRequest)(nil),       // 17: product.v1.CreateCategoryRequest
+	(*UpdateCategoryRequest)(nil),       // 18: product.v1.UpdateCategoryRequest
+	(*ListCategoriesRequest)(nil),       // 19: product.v1.ListCategoriesRequest
+	(*ListCategoriesResponse)(nil),      // 20: product.v1.ListCategoriesResponse
+	(*DeleteCategoryResponse)(nil),      // 21: product.v1.DeleteCategoryResponse
+	(*SetProductCategoriesRequest)(nil), // 22: product.v1.SetProductCategoriesRequest
+	(*ProductCategoriesResponse)(nil),   // 23: product.v1.ProductCategoriesResponse
+	(*SetProductTagsRequest)(nil),       // 24: product.v1.SetProductTagsRequest
+	(*ProductTagsResponse)(nil),         // 25: product.v1.ProductTagsResponse
+	(*ProductPrice)(nil),                // 26: product.v1.ProductPrice
+	(*ListProductPricesRequest)(nil),    // 27: product.v1.ListProductPricesRequest
+	(*ListProductPricesResponse)(nil),   // 28: product.v1.ListProductPricesResponse
+	(*AddProductPriceRequest)(nil),      // 29: product.v1.AddProductPriceRequest
+	(*DeleteProductPriceRequest)(nil),   // 30: product.v1.DeleteProductPriceRequest
+	(*DeleteProductPriceResponse)(nil),  // 31: product.v1.DeleteProductPriceResponse
+	(*PriceHistoryEntry)(nil),           // 32: product.v1.PriceHistoryEntry
+	(*PriceHistoryResponse)(nil),        // 33: product.v1.PriceHistoryResponse
+	(*timestamppb.Timestamp)(nil),       // 34: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),       // 35: google.protobuf.FieldMask
 }
 var file_api_proto_product_v1_product_proto_depIdxs = []int32{
-	1, // 0: product.v1.ListProductsResponse.products:type_name -> product.v1.ProductResponse
-	0, // 1: product.v1.ProductService.GetProductByID:input_type -> product.v1.ProductRequest
-	2, // 2: product.v1.ProductService.CreateProduct:input_type -> product.v1.CreateProductRequest
-	3, // 3: product.v1.ProductService.UpdateProduct:input_type -> product.v1.UpdateProductRequest
-	4, // 4: product.v1.ProductService.DeleteProduct:input_type -> product.v1.DeleteProductRequest
-	6, // 5: product.v1.ProductService.ListProducts:input_type -> google.protobuf.Empty
-	1, // 6: product.v1.ProductService.GetProductByID:output_type -> product.v1.ProductResponse
-	1, // 7: product.v1.ProductService.CreateProduct:output_type -> product.v1.ProductResponse
-	1, // 8: product.v1.ProductService.UpdateProduct:output_type -> product.v1.ProductResponse
-	1, // 9: product.v1.ProductService.DeleteProduct:output_type -> product.v1.ProductResponse
-	5, // 10: product.v1.ProductService.ListProducts:output_type -> product.v1.ListProductsResponse
-	6, // [6:11] is the sub-list for method output_type
-	1, // [1:6] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	34, // 0: product.v1.ProductResponse.created_at:type_name -> google.protobuf.Timestamp
+	1,  // 1: product.v1.ProductResponse.base_price:type_name -> product.v1.Money
+	35, // 2: product.v1.PatchProductRequest.update_mask:type_name -> google.protobuf.FieldMask
+	2,  // 3: product.v1.ListProductsResponse.products:type_name -> product.v1.ProductResponse
+	2,  // 4: product.v1.SearchResult.product:type_name -> product.v1.ProductResponse
+	10, // 5: product.v1.SearchProductsResponse.results:type_name -> product.v1.SearchResult
+	34, // 6: product.v1.ProductSnapshot.created_at:type_name -> google.protobuf.Timestamp
+	34, // 7: product.v1.ProductSnapshot.deleted_at:type_name -> google.protobuf.Timestamp
+	12, // 8: product.v1.ProductHistoryEntry.before:type_name -> product.v1.ProductSnapshot
+	12, // 9: product.v1.ProductHistoryEntry.after:type_name -> product.v1.ProductSnapshot
+	34, // 10: product.v1.ProductHistoryEntry.created_at:type_name -> google.protobuf.Timestamp
+	13, // 11: product.v1.ProductHistoryResponse.entries:type_name -> product.v1.ProductHistoryEntry
+	34, // 12: product.v1.Category.created_at:type_name -> google.protobuf.Timestamp
+	15, // 13: product.v1.Category.children:type_name -> product.v1.Category
+	15, // 14: product.v1.ListCategoriesResponse.categories:type_name -> product.v1.Category
+	15, // 15: product.v1.ProductCategoriesResponse.categories:type_name -> product.v1.Category
+	1,  // 16: product.v1.ProductPrice.price:type_name -> product.v1.Money
+	34, // 17: product.v1.ProductPrice.valid_from:type_name -> google.protobuf.Timestamp
+	34, // 18: product.v1.ProductPrice.valid_to:type_name -> google.protobuf.Timestamp
+	34, // 19: product.v1.ProductPrice.created_at:type_name -> google.protobuf.Timestamp
+	26, // 20: product.v1.ListProductPricesResponse.prices:type_name -> product.v1.ProductPrice
+	1,  // 21: product.v1.AddProductPriceRequest.price:type_name -> product.v1.Money
+	34, // 22: product.v1.AddProductPriceRequest.valid_from:type_name -> google.protobuf.Timestamp
+	34, // 23: product.v1.AddProductPriceRequest.valid_to:type_name -> google.protobuf.Timestamp
+	1,  // 24: product.v1.PriceHistoryEntry.price:type_name -> product.v1.Money
+	34, // 25: product.v1.PriceHistoryEntry.valid_from:type_name -> google.protobuf.Timestamp
+	34, // 26: product.v1.PriceHistoryEntry.valid_to:type_name -> google.protobuf.Timestamp
+	34, // 27: product.v1.PriceHistoryEntry.created_at:type_name -> google.protobuf.Timestamp
+	32, // 28: product.v1.PriceHistoryResponse.entries:type_name -> product.v1.PriceHistoryEntry
+	0,  // 29: product.v1.ProductService.GetProductByID:input_type -> product.v1.ProductRequest
+	3,  // 30: product.v1.ProductService.CreateProduct:input_type -> product.v1.CreateProductRequest
+	4,  // 31: product.v1.ProductService.UpdateProduct:input_type -> product.v1.UpdateProductRequest
+	5,  // 32: product.v1.ProductService.PatchProduct:input_type -> product.v1.PatchProductRequest
+	6,  // 33: product.v1.ProductService.DeleteProduct:input_type -> product.v1.DeleteProductRequest
+	0,  // 34: product.v1.ProductService.RestoreProduct:input_type -> product.v1.ProductRequest
+	0,  // 35: product.v1.ProductService.GetProductHistory:input_type -> product.v1.ProductRequest
+	7,  // 36: product.v1.ProductService.ListProducts:input_type -> product.v1.ListProductsRequest
+	9,  // 37: product.v1.ProductService.SearchProducts:input_type -> product.v1.SearchProductsRequest
+	17, // 38: product.v1.ProductService.CreateCategory:input_type -> product.v1.CreateCategoryRequest
+	16, // 39: product.v1.ProductService.GetCategory:input_type -> product.v1.CategoryRequest
+	19, // 40: product.v1.ProductService.ListCategories:input_type -> product.v1.ListCategoriesRequest
+	18, // 41: product.v1.ProductService.UpdateCategory:input_type -> product.v1.UpdateCategoryRequest
+	16, // 42: product.v1.ProductService.DeleteCategory:input_type -> product.v1.CategoryRequest
+	22, // 43: product.v1.ProductService.SetProductCategories:input_type -> product.v1.SetProductCategoriesRequest
+	24, // 44: product.v1.ProductService.SetProductTags:input_type -> product.v1.SetProductTagsRequest
+	27, // 45: product.v1.ProductService.ListProductPrices:input_type -> product.v1.ListProductPricesRequest
+	29, // 46: product.v1.ProductService.AddProductPrice:input_type -> product.v1.AddProductPriceRequest
+	30, // 47: product.v1.ProductService.DeleteProductPrice:input_type -> product.v1.DeleteProductPriceRequest
+	0,  // 48: product.v1.ProductService.GetProductPriceHistory:input_type -> product.v1.ProductRequest
+	2,  // 49: product.v1.ProductService.GetProductByID:output_type -> product.v1.ProductResponse
+	2,  // 50: product.v1.ProductService.CreateProduct:output_type -> product.v1.ProductResponse
+	2,  // 51: product.v1.ProductService.UpdateProduct:output_type -> product.v1.ProductResponse
+	2,  // 52: product.v1.ProductService.PatchProduct:output_type -> product.v1.ProductResponse
+	2,  // 53: product.v1.ProductService.DeleteProduct:output_type -> product.v1.ProductResponse
+	2,  // 54: product.v1.ProductService.RestoreProduct:output_type -> product.v1.ProductResponse
+	14, // 55: product.v1.ProductService.GetProductHistory:output_type -> product.v1.ProductHistoryResponse
+	8,  // 56: product.v1.ProductService.ListProducts:output_type -> product.v1.ListProductsResponse
+	11, // 57: product.v1.ProductService.SearchProducts:output_type -> product.v1.SearchProductsResponse
+	15, // 58: product.v1.ProductService.CreateCategory:output_type -> product.v1.Category
+	15, // 59: product.v1.ProductService.GetCategory:output_type -> product.v1.Category
+	20, // 60: product.v1.ProductService.ListCategories:output_type -> product.v1.ListCategoriesResponse
+	15, // 61: product.v1.ProductService.UpdateCategory:output_type -> product.v1.Category
+	21, // 62: product.v1.ProductService.DeleteCategory:output_type -> product.v1.DeleteCategoryResponse
+	23, // 63: product.v1.ProductService.SetProductCategories:output_type -> product.v1.ProductCategoriesResponse
+	25, // 64: product.v1.ProductService.SetProductTags:output_type -> product.v1.ProductTagsResponse
+	28, // 65: product.v1.ProductService.ListProductPrices:output_type -> product.v1.ListProductPricesResponse
+	26, // 66: product.v1.ProductService.AddProductPrice:output_type -> product.v1.ProductPrice
+	31, // 67: product.v1.ProductService.DeleteProductPrice:output_type -> product.v1.DeleteProductPriceResponse
+	33, // 68: product.v1.ProductService.GetProductPriceHistory:output_type -> product.v1.PriceHistoryResponse
+	49, // [49:69] is the sub-list for method output_type
+	29, // [29:49] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_product_v1_product_proto_init() }
@@ -438,13 +2556,16 @@ func file_api_proto_product_v1_product_proto_init() {
 	if File_api_proto_product_v1_product_proto != nil {
 		return
 	}
+	file_api_proto_product_v1_product_proto_msgTypes[7].OneofWrappers = []any{}
+	file_api_proto_product_v1_product_proto_msgTypes[17].OneofWrappers = []any{}
+	file_api_proto_product_v1_product_proto_msgTypes[18].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_product_v1_product_proto_rawDesc), len(file_api_proto_product_v1_product_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -3,7 +3,11 @@ package app
 import (
 	"go-worker/internal/config"
 	"go-worker/internal/health"
+	inventoryController "go-worker/internal/inventory/controller"
+	inventoryService "go-worker/internal/inventory/service"
+	"go-worker/internal/outbox"
 	"go-worker/internal/poller"
+	dispatcherController "go-worker/internal/poller/controller"
 	"go-worker/internal/poller/dispatcher"
 	productController "go-worker/internal/product/controller"
 	productService "go-worker/internal/product/service"
@@ -12,6 +16,7 @@ import (
 	"go-worker/internal/storage/sql"
 	"go-worker/internal/storage/sql/migrate"
 	"go-worker/internal/storage/sql/sqlc"
+	"go-worker/internal/storage/sql/tx"
 	"go-worker/pkg/logger"
 
 	"go.uber.org/fx"
@@ -22,6 +27,8 @@ func NewApp() *fx.App {
 		fx.Provide(
 			logger.NewLogger,
 			config.NewConfig,
+			config.NewWatcher,
+			config.NewLogLevel,
 			sql.InitialDB,
 			//server
 			health.New,
@@ -31,26 +38,51 @@ func NewApp() *fx.App {
 			//db
 			migrate.NewRunner, // migration runner
 			sqlc.New,
+			tx.NewManager, // transactions for the services
 			//cache
 			cache.NewClient,
 			cache.NewCacheStore,
 			//controller
 			productController.NewAdmin,
 			productController.NewClient,
+			productController.NewAdminCategory,
+			productController.NewClientCategory,
 			productController.NewGRPC,
+			inventoryController.NewAdmin,
+			inventoryController.NewGRPC,
+			dispatcherController.NewAdmin,
+			dispatcherController.NewGRPC,
 			//service
 			productService.New,
+			inventoryService.New,
+			// outbox
+			outbox.NewSink,
+			outbox.NewRelay,
 			// dispatcher
 			dispatcher.New,
+			dispatcher.NewRedisStateStore,
+			dispatcher.NewRedisOverflowStore,
 			poller.New,
 		),
 		fx.Invoke(
+			// config reload
+			config.RegisterWatcherLifecycle,
+			config.RegisterLogLevelReload,
+			cache.RegisterReload,
+			cache.RegisterInvalidation,
+
 			// dispatcher
 			dispatcher.RegisterServices,
+			dispatcher.RegisterReload,
 			dispatcher.RegisterLifecycle,
 
 			// poller
+			poller.RegisterDecoders,
+			poller.RegisterInterval,
 			poller.RegisterLifecycle,
+			inventoryService.RegisterExpiry,
+			productService.RegisterWarmUp,
+			outbox.RegisterRelay,
 
 			//server
 			server.RegisterRoutes,
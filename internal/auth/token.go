@@ -9,10 +9,19 @@ import (
 )
 
 func GenerateToken(cfg *config.Config, adminID string) (string, error) {
+	return GenerateTenantToken(cfg, adminID, "")
+}
+
+// GenerateTenantToken creates a token whose holder only sees the given tenant,
+// an empty tenantID creates an unscoped token like GenerateToken
+func GenerateTenantToken(cfg *config.Config, adminID, tenantID string) (string, error) {
 	claims := jwt.MapClaims{
 		"admin_id": adminID,
 		"exp":      time.Now().Add(time.Hour * time.Duration(cfg.JWTExpiryHours)).Unix(),
 	}
+	if tenantID != "" {
+		claims["tenant_id"] = tenantID
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(cfg.JWTSecret))
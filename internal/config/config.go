@@ -1,10 +1,14 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
@@ -19,34 +23,134 @@ type Config struct {
 	JWTSecret      string
 	JWTExpiryHours int
 	Redis          RedisCfg
+	Outbox         OutboxCfg
+	Dispatcher     DispatcherCfg
+	PollerInterval time.Duration // zero keeps the poller default
+	LogLevel       string        // debug, info, warn or error, empty means info
 }
 
 type DatabaseCfg struct {
-	DSN string
+	DSN              string
+	MaxOpenConns     int           // zero means 25
+	MaxIdleConns     int           // zero means 5
+	ConnMaxLifetime  time.Duration // zero means 30m
+	StatementTimeout time.Duration // zero means statements never time out
+	ConnectTimeout   time.Duration // how long startup retries to reach the database, zero means 30s
 }
 
 type RedisCfg struct {
-	DSN        string
+	Mode       string // single, sentinel or cluster, empty means single
+	DSN        string // host:port, a comma separated list of sentinels or cluster nodes
+	MasterName string // name of the master the sentinels monitor
+	Username   string
+	Password   string
 	DB         int
 	Prefix     string
-	DefaultTTL int // in minute
+	DefaultTTL int           // in minute
+	LocalSize  int           // entries cached in process in front of redis, 0 disables the local tier
+	LocalTTL   time.Duration // how long a local entry is trusted, zero means 30s
+
+	SentinelPassword string        // authenticates to the sentinels, Password to the master
+	TLS              bool          // connect over TLS
+	TLSCAFile        string        // PEM file of the CAs to trust, empty trusts the system CAs
+	PoolSize         int           // connections per node, zero means 10 per CPU
+	MinIdleConns     int           // idle connections kept open per node
+	DialTimeout      time.Duration // zero means 5s
+	ReadTimeout      time.Duration // zero means 3s
+	WriteTimeout     time.Duration // zero means the read timeout
+
+	Codec             string // json or msgpack, empty means json
+	Compression       string // none, zstd or snappy, empty means none
+	CompressThreshold int    // smallest value compressed in bytes, zero means 1024
+}
+
+// String formats the config like %+v with the passwords redacted, so the
+// config can be logged
+func (c RedisCfg) String() string {
+	type plain RedisCfg
+	redacted := plain(c)
+	if redacted.Password != "" {
+		redacted.Password = "[redacted]"
+	}
+	if redacted.SentinelPassword != "" {
+		redacted.SentinelPassword = "[redacted]"
+	}
+	return fmt.Sprintf("%+v", redacted)
+}
+
+// OutboxCfg configures where the outbox relay publishes domain events
+type OutboxCfg struct {
+	Sink           string        // redis, webhook or log, empty means log
+	WebhookURL     string        // endpoint the webhook sink posts every event to
+	WebhookTimeout time.Duration // zero means 10s
+	StreamMaxLen   int64         // entries kept per redis stream, roughly, zero keeps every entry
+	BatchSize      int           // events claimed per relay round, zero means 100
+	MaxAttempts    int           // publish attempts before an event is parked, zero means 10
+}
+
+type DispatcherCfg struct {
+	Services []ServiceCfg
+}
+
+// ServiceCfg declares a dispatcher service and how its jobs are run
+type ServiceCfg struct {
+	Name             string
+	Workers          int
+	QueueSize        int
+	Overflow         string        // block, reject, drop_oldest or spill
+	BlockTimeout     time.Duration // only used by the block overflow policy
+	RejectWhenPaused bool
+	Timeout          time.Duration
+	Retry            RetryCfg
+	RateLimit        float64 // jobs per second, 0 means unlimited
+	RateBurst        int
+	Backend          string // overflow store: memory or redis
+	TenantMaxQueued  int    // queued jobs per tenant, 0 means unlimited
+	TenantMaxRunning int    // running jobs per tenant, 0 means unlimited
+}
+
+type RetryCfg struct {
+	MaxAttempts int
+	Backoff     time.Duration
 }
 
 func NewConfig() (*Config, error) {
-	v := viper.New()
-	v.SetEnvPrefix("APP")
-	v.AutomaticEnv()
+	cfg, _, err := load()
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("✅ Loaded config: %+v\n", cfg)
+
+	return cfg, nil
+}
+
+// load reads the config file and env vars into a validated Config,
+// it also returns the viper instance so callers can watch the file
+func load() (*Config, *viper.Viper, error) {
+	v := newViper()
+
+	if err := readConfigFile(v); err != nil {
+		return nil, nil, fmt.Errorf("⚠️ invalid config file: %w", err)
+	}
 
 	// Build config
 	cfg := buildConfig(v)
 
 	// Validate configuration
 	if err := ValidateConfig(cfg); err != nil {
-		return nil, fmt.Errorf("⚠️ invalid configuration: %w", err)
+		return nil, nil, fmt.Errorf("⚠️ invalid configuration: %w", err)
 	}
-	log.Printf("✅ Loaded config: %+v\n", cfg)
 
-	return cfg, nil
+	return cfg, v, nil
+}
+
+func newViper() *viper.Viper {
+	v := viper.New()
+	v.SetEnvPrefix("APP")
+	// dispatcher.services.email.workers -> APP_DISPATCHER_SERVICES_EMAIL_WORKERS
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	return v
 }
 
 // buildConfig constructs the Config struct from viper values
@@ -59,15 +163,126 @@ func buildConfig(v *viper.Viper) *Config {
 		JWTSecret:      v.GetString("JWT_SECRET"),
 		JWTExpiryHours: v.GetInt("JWT_EXPIRY_HOURS"),
 		Database: DatabaseCfg{
-			DSN: v.GetString("DATABASE_DSN"),
+			DSN:              v.GetString("DATABASE_DSN"),
+			MaxOpenConns:     v.GetInt("DATABASE_MAX_OPEN_CONNS"),
+			MaxIdleConns:     v.GetInt("DATABASE_MAX_IDLE_CONNS"),
+			ConnMaxLifetime:  v.GetDuration("DATABASE_CONN_MAX_LIFETIME"),
+			StatementTimeout: v.GetDuration("DATABASE_STATEMENT_TIMEOUT"),
+			ConnectTimeout:   v.GetDuration("DATABASE_CONNECT_TIMEOUT"),
 		},
 		Redis: RedisCfg{
+			Mode:       v.GetString("REDIS_MODE"),
 			DSN:        v.GetString("REDIS_DSN"),
+			MasterName: v.GetString("REDIS_MASTER_NAME"),
+			Username:   v.GetString("REDIS_USERNAME"),
+			Password:   v.GetString("REDIS_PASSWORD"),
 			DB:         v.GetInt("REDIS_DB"),
 			Prefix:     v.GetString("REDIS_PREFIX"),
 			DefaultTTL: v.GetInt("REDIS_DEFAULT_TTL"),
+			LocalSize:  v.GetInt("REDIS_LOCAL_SIZE"),
+			LocalTTL:   v.GetDuration("REDIS_LOCAL_TTL"),
+
+			SentinelPassword: v.GetString("REDIS_SENTINEL_PASSWORD"),
+			TLS:              v.GetBool("REDIS_TLS"),
+			TLSCAFile:        v.GetString("REDIS_TLS_CA_FILE"),
+			PoolSize:         v.GetInt("REDIS_POOL_SIZE"),
+			MinIdleConns:     v.GetInt("REDIS_MIN_IDLE_CONNS"),
+			DialTimeout:      v.GetDuration("REDIS_DIAL_TIMEOUT"),
+			ReadTimeout:      v.GetDuration("REDIS_READ_TIMEOUT"),
+			WriteTimeout:     v.GetDuration("REDIS_WRITE_TIMEOUT"),
+
+			Codec:             v.GetString("REDIS_CODEC"),
+			Compression:       v.GetString("REDIS_COMPRESSION"),
+			CompressThreshold: v.GetInt("REDIS_COMPRESS_THRESHOLD"),
 		},
+		Outbox: OutboxCfg{
+			Sink:           v.GetString("OUTBOX_SINK"),
+			WebhookURL:     v.GetString("OUTBOX_WEBHOOK_URL"),
+			WebhookTimeout: v.GetDuration("OUTBOX_WEBHOOK_TIMEOUT"),
+			StreamMaxLen:   v.GetInt64("OUTBOX_STREAM_MAX_LEN"),
+			BatchSize:      v.GetInt("OUTBOX_BATCH_SIZE"),
+			MaxAttempts:    v.GetInt("OUTBOX_MAX_ATTEMPTS"),
+		},
+		Dispatcher: DispatcherCfg{
+			Services: buildServices(v),
+		},
+		PollerInterval: v.GetDuration("POLLER_INTERVAL"),
+		LogLevel:       v.GetString("LOG_LEVEL"),
+	}
+}
+
+// readConfigFile reads the YAML/TOML file named by APP_CONFIG_FILE, or a
+// config.{yaml,toml} in the working or parent directory. A missing file is
+// not an error, env vars still apply and take precedence over the file.
+func readConfigFile(v *viper.Viper) error {
+	if path := os.Getenv("APP_CONFIG_FILE"); path != "" {
+		v.SetConfigFile(path)
+	} else {
+		v.SetConfigName("config")
+		v.AddConfigPath(".")
+		v.AddConfigPath("..")
+	}
+
+	if err := v.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if errors.As(err, &notFound) {
+			log.Printf("⚠️  No config file found, using environment only\n")
+			return nil
+		}
+		return err
+	}
+	log.Printf("✅ Loaded config file: %s\n", v.ConfigFileUsed())
+	return nil
+}
+
+// buildServices reads dispatcher.services from the config file, services
+// listed in APP_DISPATCHER_SERVICE_NAMES can be declared with env vars only
+func buildServices(v *viper.Viper) []ServiceCfg {
+	names := map[string]bool{}
+	for name := range v.GetStringMap("dispatcher.services") {
+		names[name] = true
+	}
+	for _, name := range strings.Split(v.GetString("DISPATCHER_SERVICE_NAMES"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names[strings.ToLower(name)] = true
+		}
+	}
+
+	services := make([]ServiceCfg, 0, len(names))
+	for name := range names {
+		key := "dispatcher.services." + name + "."
+		services = append(services, ServiceCfg{
+			Name:             name,
+			Workers:          v.GetInt(key + "workers"),
+			QueueSize:        v.GetInt(key + "queue_size"),
+			Overflow:         v.GetString(key + "overflow"),
+			BlockTimeout:     v.GetDuration(key + "block_timeout"),
+			RejectWhenPaused: v.GetBool(key + "reject_when_paused"),
+			Timeout:          v.GetDuration(key + "timeout"),
+			Retry: RetryCfg{
+				MaxAttempts: v.GetInt(key + "retry.max_attempts"),
+				Backoff:     v.GetDuration(key + "retry.backoff"),
+			},
+			RateLimit: v.GetFloat64(key + "rate_limit"),
+			RateBurst: v.GetInt(key + "rate_burst"),
+			Backend:   v.GetString(key + "backend"),
+
+			TenantMaxQueued:  v.GetInt(key + "tenant_max_queued"),
+			TenantMaxRunning: v.GetInt(key + "tenant_max_running"),
+		})
+	}
+	for i := range services {
+		if services[i].Overflow == "" {
+			services[i].Overflow = "block"
+		}
+		if services[i].Backend == "" {
+			services[i].Backend = "memory"
+		}
 	}
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Name < services[j].Name
+	})
+	return services
 }
 
 func (cfg *Config) IsTest() bool {
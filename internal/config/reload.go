@@ -0,0 +1,229 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Watcher reloads the configuration when the config file changes or the
+// process receives SIGHUP. A reload is validated with ValidateConfig and
+// rejected as a whole if it changes a value that needs a restart, otherwise
+// the new config is handed to every OnChange subscriber.
+//
+// The *Config provided at startup is never mutated, subscribers push the
+// live values into the parts they own.
+type Watcher struct {
+	reloading   sync.Mutex // keeps reloads and their subscriber calls in order
+	mu          sync.Mutex
+	current     *Config
+	subscribers []func(old, new *Config)
+
+	signals chan os.Signal
+	done    chan struct{}
+}
+
+func NewWatcher(cfg *Config) *Watcher {
+	return &Watcher{
+		current: cfg,
+		signals: make(chan os.Signal, 1),
+		done:    make(chan struct{}),
+	}
+}
+
+// OnChange registers fn to be called after every accepted reload
+func (w *Watcher) OnChange(fn func(old, new *Config)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// Current returns the last accepted configuration
+func (w *Watcher) Current() *Config {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current
+}
+
+// Reload reads the configuration again and applies it if it is valid and
+// only changes values that can be changed live
+func (w *Watcher) Reload() error {
+	next, _, err := load()
+	if err != nil {
+		log.Printf("⚠️ config reload rejected: %v\n", err)
+		return err
+	}
+
+	w.reloading.Lock()
+	defer w.reloading.Unlock()
+
+	w.mu.Lock()
+	if changed := restartRequired(w.current, next); len(changed) > 0 {
+		w.mu.Unlock()
+		err := fmt.Errorf("%s changed and requires a restart", strings.Join(changed, ", "))
+		log.Printf("⚠️ config reload rejected: %v\n", err)
+		return err
+	}
+	old := w.current
+	w.current = next
+	subscribers := append([]func(old, new *Config){}, w.subscribers...)
+	w.mu.Unlock()
+
+	// subscribers may call Current or OnChange
+	for _, fn := range subscribers {
+		fn(old, next)
+	}
+	log.Printf("✅ Config reloaded: %+v\n", next)
+	return nil
+}
+
+// restartRequired lists the values that differ between old and new and
+// cannot be applied without restarting the process
+func restartRequired(old, new *Config) []string {
+	var changed []string
+	check := func(name string, different bool) {
+		if different {
+			changed = append(changed, name)
+		}
+	}
+
+	check("HTTP_PORT", old.HTTPPort != new.HTTPPort)
+	check("HTTP_ADDRESS", old.HTTPAddress != new.HTTPAddress)
+	check("GRPC_PORT", old.GRPCPort != new.GRPCPort)
+	check("ENV", old.ENV != new.ENV)
+	check("JWT_SECRET", old.JWTSecret != new.JWTSecret)
+	check("JWT_EXPIRY_HOURS", old.JWTExpiryHours != new.JWTExpiryHours)
+	check("DATABASE_DSN", old.Database.DSN != new.Database.DSN)
+	check("DATABASE_MAX_OPEN_CONNS", old.Database.MaxOpenConns != new.Database.MaxOpenConns)
+	check("DATABASE_MAX_IDLE_CONNS", old.Database.MaxIdleConns != new.Database.MaxIdleConns)
+	check("DATABASE_CONN_MAX_LIFETIME", old.Database.ConnMaxLifetime != new.Database.ConnMaxLifetime)
+	check("DATABASE_STATEMENT_TIMEOUT", old.Database.StatementTimeout != new.Database.StatementTimeout)
+	check("DATABASE_CONNECT_TIMEOUT", old.Database.ConnectTimeout != new.Database.ConnectTimeout)
+	check("REDIS_MODE", old.Redis.Mode != new.Redis.Mode)
+	check("REDIS_DSN", old.Redis.DSN != new.Redis.DSN)
+	check("REDIS_MASTER_NAME", old.Redis.MasterName != new.Redis.MasterName)
+	check("REDIS_USERNAME", old.Redis.Username != new.Redis.Username)
+	check("REDIS_PASSWORD", old.Redis.Password != new.Redis.Password)
+	check("REDIS_SENTINEL_PASSWORD", old.Redis.SentinelPassword != new.Redis.SentinelPassword)
+	check("REDIS_TLS", old.Redis.TLS != new.Redis.TLS)
+	check("REDIS_TLS_CA_FILE", old.Redis.TLSCAFile != new.Redis.TLSCAFile)
+	check("REDIS_POOL_SIZE", old.Redis.PoolSize != new.Redis.PoolSize)
+	check("REDIS_MIN_IDLE_CONNS", old.Redis.MinIdleConns != new.Redis.MinIdleConns)
+	check("REDIS_DIAL_TIMEOUT", old.Redis.DialTimeout != new.Redis.DialTimeout)
+	check("REDIS_READ_TIMEOUT", old.Redis.ReadTimeout != new.Redis.ReadTimeout)
+	check("REDIS_WRITE_TIMEOUT", old.Redis.WriteTimeout != new.Redis.WriteTimeout)
+	check("REDIS_DB", old.Redis.DB != new.Redis.DB)
+	check("REDIS_PREFIX", old.Redis.Prefix != new.Redis.Prefix)
+	check("REDIS_LOCAL_SIZE", old.Redis.LocalSize != new.Redis.LocalSize)
+	check("REDIS_LOCAL_TTL", old.Redis.LocalTTL != new.Redis.LocalTTL)
+	check("REDIS_CODEC", old.Redis.Codec != new.Redis.Codec)
+	check("REDIS_COMPRESSION", old.Redis.Compression != new.Redis.Compression)
+	check("REDIS_COMPRESS_THRESHOLD", old.Redis.CompressThreshold != new.Redis.CompressThreshold)
+	check("OUTBOX_SINK", old.Outbox.Sink != new.Outbox.Sink)
+	check("OUTBOX_WEBHOOK_URL", old.Outbox.WebhookURL != new.Outbox.WebhookURL)
+	check("OUTBOX_WEBHOOK_TIMEOUT", old.Outbox.WebhookTimeout != new.Outbox.WebhookTimeout)
+	check("OUTBOX_STREAM_MAX_LEN", old.Outbox.StreamMaxLen != new.Outbox.StreamMaxLen)
+	check("OUTBOX_BATCH_SIZE", old.Outbox.BatchSize != new.Outbox.BatchSize)
+	check("OUTBOX_MAX_ATTEMPTS", old.Outbox.MaxAttempts != new.Outbox.MaxAttempts)
+
+	oldServices := map[string]ServiceCfg{}
+	for _, svc := range old.Dispatcher.Services {
+		oldServices[svc.Name] = svc
+	}
+	for _, svc := range new.Dispatcher.Services {
+		prev, ok := oldServices[svc.Name]
+		delete(oldServices, svc.Name)
+		if !ok {
+			changed = append(changed, "dispatcher.services."+svc.Name+" (added)")
+			continue
+		}
+		// worker counts, rate limits and tenant limits are applied live
+		prev.Workers, prev.RateLimit, prev.RateBurst = svc.Workers, svc.RateLimit, svc.RateBurst
+		prev.TenantMaxQueued, prev.TenantMaxRunning = svc.TenantMaxQueued, svc.TenantMaxRunning
+		check("dispatcher.services."+svc.Name, prev != svc)
+	}
+	for name := range oldServices {
+		changed = append(changed, "dispatcher.services."+name+" (removed)")
+	}
+
+	return changed
+}
+
+// RegisterWatcherLifecycle starts watching the config file and SIGHUP
+func RegisterWatcherLifecycle(lc fx.Lifecycle, w *Watcher) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			_, v, err := load()
+			if err != nil {
+				return err
+			}
+			if v.ConfigFileUsed() != "" {
+				v.OnConfigChange(func(e fsnotify.Event) {
+					select {
+					case <-w.done:
+						return
+					default:
+					}
+					log.Printf("📋 Config file changed: %s\n", e.Name)
+					_ = w.Reload()
+				})
+				v.WatchConfig()
+			}
+
+			signal.Notify(w.signals, syscall.SIGHUP)
+			go func() {
+				for {
+					select {
+					case <-w.done:
+						return
+					case <-w.signals:
+						log.Printf("📋 SIGHUP received, reloading config\n")
+						_ = w.Reload()
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			signal.Stop(w.signals)
+			close(w.done)
+			return nil
+		},
+	})
+}
+
+// NewLogLevel returns the level shared by the logger so it can be changed live
+func NewLogLevel(cfg *Config) zap.AtomicLevel {
+	level := zap.NewAtomicLevel()
+	if cfg.LogLevel != "" {
+		// already checked by validateLogLevel
+		lvl, _ := zapcore.ParseLevel(cfg.LogLevel)
+		level.SetLevel(lvl)
+	}
+	return level
+}
+
+// RegisterLogLevelReload applies LOG_LEVEL changes to the running logger
+func RegisterLogLevelReload(w *Watcher, level zap.AtomicLevel) {
+	w.OnChange(func(old, new *Config) {
+		if old.LogLevel == new.LogLevel {
+			return
+		}
+		lvl := zapcore.InfoLevel
+		if new.LogLevel != "" {
+			lvl, _ = zapcore.ParseLevel(new.LogLevel)
+		}
+		level.SetLevel(lvl)
+		log.Printf("✅ Log level set to %s\n", lvl)
+	})
+}
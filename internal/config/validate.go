@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"net/url"
 	"strings"
+
+	"go.uber.org/zap/zapcore"
 )
 
 // ValidateConfig validates all configuration values and returns detailed errors
@@ -19,10 +22,18 @@ func ValidateConfig(cfg *Config) error {
 		validateJWTSecret,
 		validateJWTExpiry,
 		validateDatabaseDSN,
+		validateDatabasePool,
 		validateRedisDSN,
 		validateRedisDB,
 		validateRedisPrefix,
 		validateRedisTTL,
+		validateRedisLocal,
+		validateRedisPool,
+		validateRedisEncoding,
+		validateOutbox,
+		validateDispatcherServices,
+		validatePollerInterval,
+		validateLogLevel,
 	}
 
 	for _, check := range checks {
@@ -174,12 +185,11 @@ func validateDatabaseDSN(cfg *Config) error {
 		)
 	}
 
-	// Validate DSN format
+	// Validate DSN format, the pgx driver only speaks postgres
 	if !strings.HasPrefix(cfg.Database.DSN, "postgresql://") &&
-		!strings.HasPrefix(cfg.Database.DSN, "postgres://") &&
-		!strings.HasPrefix(cfg.Database.DSN, "mysql://") {
+		!strings.HasPrefix(cfg.Database.DSN, "postgres://") {
 		return fmt.Errorf(
-			"invalid DATABASE_DSN format: must start with postgresql://, postgres://, or mysql://. "+
+			"invalid DATABASE_DSN format: must start with postgresql:// or postgres://. "+
 				"Provided: %q",
 			cfg.Database.DSN,
 		)
@@ -188,7 +198,35 @@ func validateDatabaseDSN(cfg *Config) error {
 	return nil
 }
 
-// validateRedisDSN validates Redis DSN is not empty
+// validateDatabasePool validates the database pool limits and timeouts are not negative
+func validateDatabasePool(cfg *Config) error {
+	db := cfg.Database
+	if db.MaxOpenConns < 0 || db.MaxIdleConns < 0 {
+		return fmt.Errorf(
+			"invalid DATABASE_MAX_OPEN_CONNS or DATABASE_MAX_IDLE_CONNS: %d, %d. Expected 0 (default) or a positive number. "+
+				"Set APP_DATABASE_MAX_OPEN_CONNS and APP_DATABASE_MAX_IDLE_CONNS environment variables",
+			db.MaxOpenConns, db.MaxIdleConns,
+		)
+	}
+	if db.MaxOpenConns > 0 && db.MaxIdleConns > db.MaxOpenConns {
+		return fmt.Errorf(
+			"invalid DATABASE_MAX_IDLE_CONNS: %d. Expected at most DATABASE_MAX_OPEN_CONNS (%d). "+
+				"Set APP_DATABASE_MAX_IDLE_CONNS environment variable",
+			db.MaxIdleConns, db.MaxOpenConns,
+		)
+	}
+	if db.ConnMaxLifetime < 0 || db.StatementTimeout < 0 || db.ConnectTimeout < 0 {
+		return fmt.Errorf(
+			"invalid database timeouts: lifetime %v, statement %v, connect %v. Expected 0 (default) or a positive duration. "+
+				"Set APP_DATABASE_CONN_MAX_LIFETIME, APP_DATABASE_STATEMENT_TIMEOUT and APP_DATABASE_CONNECT_TIMEOUT environment variables",
+			db.ConnMaxLifetime, db.StatementTimeout, db.ConnectTimeout,
+		)
+	}
+	return nil
+}
+
+// validateRedisDSN validates the Redis mode and that REDIS_DSN holds the
+// addresses that mode needs
 func validateRedisDSN(cfg *Config) error {
 	if cfg.Redis.DSN == "" {
 		return fmt.Errorf(
@@ -198,15 +236,75 @@ func validateRedisDSN(cfg *Config) error {
 	}
 
 	// Basic format check (host:port)
-	parts := strings.Split(cfg.Redis.DSN, ":")
-	if len(parts) < 2 {
+	addrs := strings.Split(cfg.Redis.DSN, ",")
+	for _, addr := range addrs {
+		parts := strings.Split(strings.TrimSpace(addr), ":")
+		if len(parts) < 2 || parts[0] == "" {
+			return fmt.Errorf(
+				"invalid REDIS_DSN format: expected host:port or a comma separated list of them. "+
+					"Provided: %q",
+				cfg.Redis.DSN,
+			)
+		}
+	}
+
+	switch cfg.Redis.Mode {
+	case "", "single":
+		if len(addrs) > 1 {
+			return fmt.Errorf(
+				"invalid REDIS_DSN: %q. Single mode takes one host:port, "+
+					"set APP_REDIS_MODE to sentinel or cluster for several addresses",
+				cfg.Redis.DSN,
+			)
+		}
+	case "sentinel":
+		if cfg.Redis.MasterName == "" {
+			return fmt.Errorf(
+				"REDIS_MASTER_NAME is empty. Sentinel mode needs the name of the monitored master. " +
+					"Set APP_REDIS_MASTER_NAME environment variable",
+			)
+		}
+	case "cluster":
+		if cfg.Redis.DB != 0 {
+			return fmt.Errorf(
+				"invalid REDIS_DB: %d. Redis Cluster only has database 0. "+
+					"Set APP_REDIS_DB environment variable to 0",
+				cfg.Redis.DB,
+			)
+		}
+	default:
 		return fmt.Errorf(
-			"invalid REDIS_DSN format: expected host:port. "+
-				"Provided: %q",
-			cfg.Redis.DSN,
+			"invalid REDIS_MODE: %s. Expected single, sentinel or cluster. "+
+				"Set APP_REDIS_MODE environment variable",
+			cfg.Redis.Mode,
 		)
 	}
 
+	if cfg.Redis.TLSCAFile != "" && !cfg.Redis.TLS {
+		return fmt.Errorf(
+			"REDIS_TLS_CA_FILE is set but REDIS_TLS is off. " +
+				"Set APP_REDIS_TLS environment variable to true",
+		)
+	}
+	return nil
+}
+
+// validateRedisPool validates the Redis pool size and timeouts are not negative
+func validateRedisPool(cfg *Config) error {
+	if cfg.Redis.PoolSize < 0 || cfg.Redis.MinIdleConns < 0 {
+		return fmt.Errorf(
+			"invalid REDIS_POOL_SIZE or REDIS_MIN_IDLE_CONNS: %d, %d. Expected 0 (default) or a positive number. "+
+				"Set APP_REDIS_POOL_SIZE and APP_REDIS_MIN_IDLE_CONNS environment variables",
+			cfg.Redis.PoolSize, cfg.Redis.MinIdleConns,
+		)
+	}
+	if cfg.Redis.DialTimeout < 0 || cfg.Redis.ReadTimeout < 0 || cfg.Redis.WriteTimeout < 0 {
+		return fmt.Errorf(
+			"invalid Redis timeouts: dial %v, read %v, write %v. Expected 0 (default) or a positive duration. "+
+				"Set APP_REDIS_DIAL_TIMEOUT, APP_REDIS_READ_TIMEOUT and APP_REDIS_WRITE_TIMEOUT environment variables",
+			cfg.Redis.DialTimeout, cfg.Redis.ReadTimeout, cfg.Redis.WriteTimeout,
+		)
+	}
 	return nil
 }
 
@@ -255,6 +353,209 @@ func validateRedisTTL(cfg *Config) error {
 	return nil
 }
 
+// validateRedisLocal validates the local cache tier size and TTL are not negative
+func validateRedisLocal(cfg *Config) error {
+	if cfg.Redis.LocalSize < 0 {
+		return fmt.Errorf(
+			"invalid REDIS_LOCAL_SIZE: %d. Expected 0 (disabled) or a positive number of entries. "+
+				"Set APP_REDIS_LOCAL_SIZE environment variable",
+			cfg.Redis.LocalSize,
+		)
+	}
+	if cfg.Redis.LocalTTL < 0 {
+		return fmt.Errorf(
+			"invalid REDIS_LOCAL_TTL: %v. Expected a positive duration such as 30s. "+
+				"Set APP_REDIS_LOCAL_TTL environment variable",
+			cfg.Redis.LocalTTL,
+		)
+	}
+	return nil
+}
+
+// validateRedisEncoding validates the cache codec, compression and compression threshold
+func validateRedisEncoding(cfg *Config) error {
+	switch cfg.Redis.Codec {
+	case "", "json", "msgpack":
+	default:
+		return fmt.Errorf(
+			"invalid REDIS_CODEC: %s. Expected json or msgpack. "+
+				"Set APP_REDIS_CODEC environment variable",
+			cfg.Redis.Codec,
+		)
+	}
+	switch cfg.Redis.Compression {
+	case "", "none", "zstd", "snappy":
+	default:
+		return fmt.Errorf(
+			"invalid REDIS_COMPRESSION: %s. Expected none, zstd or snappy. "+
+				"Set APP_REDIS_COMPRESSION environment variable",
+			cfg.Redis.Compression,
+		)
+	}
+	if cfg.Redis.CompressThreshold < 0 {
+		return fmt.Errorf(
+			"invalid REDIS_COMPRESS_THRESHOLD: %d. Expected a positive number of bytes. "+
+				"Set APP_REDIS_COMPRESS_THRESHOLD environment variable",
+			cfg.Redis.CompressThreshold,
+		)
+	}
+	return nil
+}
+
+// validateOutbox validates the sink the outbox relay publishes events to
+func validateOutbox(cfg *Config) error {
+	outbox := cfg.Outbox
+	switch outbox.Sink {
+	case "", "log", "redis":
+	case "webhook":
+		u, err := url.Parse(outbox.WebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf(
+				"invalid OUTBOX_WEBHOOK_URL: %q. Expected an http or https URL for the webhook sink. "+
+					"Set APP_OUTBOX_WEBHOOK_URL environment variable",
+				outbox.WebhookURL,
+			)
+		}
+	default:
+		return fmt.Errorf(
+			"invalid OUTBOX_SINK: %s. Expected redis, webhook or log. "+
+				"Set APP_OUTBOX_SINK environment variable",
+			outbox.Sink,
+		)
+	}
+	if outbox.WebhookTimeout < 0 || outbox.StreamMaxLen < 0 || outbox.BatchSize < 0 || outbox.MaxAttempts < 0 {
+		return fmt.Errorf(
+			"invalid outbox settings: webhook timeout %v, stream max len %d, batch size %d, max attempts %d. Expected 0 (default) or a positive value. "+
+				"Set APP_OUTBOX_WEBHOOK_TIMEOUT, APP_OUTBOX_STREAM_MAX_LEN, APP_OUTBOX_BATCH_SIZE and APP_OUTBOX_MAX_ATTEMPTS environment variables",
+			outbox.WebhookTimeout, outbox.StreamMaxLen, outbox.BatchSize, outbox.MaxAttempts,
+		)
+	}
+	return nil
+}
+
+// validatePollerInterval validates poller interval is not negative
+func validatePollerInterval(cfg *Config) error {
+	if cfg.PollerInterval < 0 {
+		return fmt.Errorf(
+			"invalid POLLER_INTERVAL: %v. Expected a duration greater than 0 (e.g., 500ms). "+
+				"Set APP_POLLER_INTERVAL environment variable",
+			cfg.PollerInterval,
+		)
+	}
+	return nil
+}
+
+// validateLogLevel validates log level is one zap understands
+func validateLogLevel(cfg *Config) error {
+	if cfg.LogLevel == "" {
+		return nil
+	}
+	if _, err := zapcore.ParseLevel(cfg.LogLevel); err != nil {
+		return fmt.Errorf(
+			"invalid LOG_LEVEL: %q. Expected one of: debug, info, warn, error. "+
+				"Set APP_LOG_LEVEL environment variable",
+			cfg.LogLevel,
+		)
+	}
+	return nil
+}
+
+// validateDispatcherServices validates every service declared under dispatcher.services
+func validateDispatcherServices(cfg *Config) error {
+	for _, svc := range cfg.Dispatcher.Services {
+		if err := validateService(svc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateService validates a single dispatcher service declaration
+func validateService(svc ServiceCfg) error {
+	if strings.ContainsAny(svc.Name, " .") {
+		return fmt.Errorf(
+			"invalid dispatcher service name: %q. Cannot contain spaces or dots. "+
+				"Rename it under dispatcher.services in the config file or APP_DISPATCHER_SERVICE_NAMES",
+			svc.Name,
+		)
+	}
+
+	if svc.Workers <= 0 {
+		return serviceError(svc.Name, "workers", svc.Workers, "Expected value greater than 0")
+	}
+
+	if svc.QueueSize <= 0 {
+		return serviceError(svc.Name, "queue_size", svc.QueueSize, "Expected value greater than 0")
+	}
+
+	validOverflows := []string{"block", "reject", "drop_oldest", "spill"}
+	if !contains(validOverflows, svc.Overflow) {
+		return serviceError(svc.Name, "overflow", fmt.Sprintf("%q", svc.Overflow),
+			"Expected one of: "+strings.Join(validOverflows, ", "))
+	}
+
+	if svc.BlockTimeout < 0 {
+		return serviceError(svc.Name, "block_timeout", svc.BlockTimeout, "Expected a duration of 0 or more (e.g., 2s)")
+	}
+
+	if svc.Timeout < 0 {
+		return serviceError(svc.Name, "timeout", svc.Timeout, "Expected a duration of 0 or more (e.g., 30s)")
+	}
+
+	if svc.Retry.MaxAttempts < 0 {
+		return serviceError(svc.Name, "retry.max_attempts", svc.Retry.MaxAttempts, "Expected value of 0 or more")
+	}
+
+	if svc.Retry.Backoff < 0 {
+		return serviceError(svc.Name, "retry.backoff", svc.Retry.Backoff, "Expected a duration of 0 or more (e.g., 1s)")
+	}
+
+	if svc.RateLimit < 0 {
+		return serviceError(svc.Name, "rate_limit", svc.RateLimit, "Expected jobs per second of 0 (unlimited) or more")
+	}
+
+	if svc.RateBurst < 0 {
+		return serviceError(svc.Name, "rate_burst", svc.RateBurst, "Expected value of 0 or more")
+	}
+
+	if svc.TenantMaxQueued < 0 {
+		return serviceError(svc.Name, "tenant_max_queued", svc.TenantMaxQueued, "Expected value of 0 (unlimited) or more")
+	}
+
+	if svc.TenantMaxRunning < 0 {
+		return serviceError(svc.Name, "tenant_max_running", svc.TenantMaxRunning, "Expected value of 0 (unlimited) or more")
+	}
+
+	validBackends := []string{"memory", "redis"}
+	if !contains(validBackends, svc.Backend) {
+		return serviceError(svc.Name, "backend", fmt.Sprintf("%q", svc.Backend),
+			"Expected one of: "+strings.Join(validBackends, ", "))
+	}
+
+	return nil
+}
+
+// serviceError builds an error for an invalid dispatcher service field,
+// naming both the config file key and the env var that sets it
+func serviceError(service, field string, value interface{}, expected string) error {
+	key := fmt.Sprintf("dispatcher.services.%s.%s", service, field)
+	env := "APP_" + strings.ToUpper(strings.NewReplacer(".", "_").Replace(key))
+	return fmt.Errorf(
+		"invalid %s: %v. %s. "+
+			"Set %s in the config file or %s environment variable",
+		key, value, expected, key, env,
+	)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 // validateWarnings logs non-critical warnings for configuration
 func validateWarnings(cfg *Config) {
 	// Warn about default JWT secret in production
@@ -283,6 +584,12 @@ func validateWarnings(cfg *Config) {
 		}
 	}
 
+	// Warn about a dispatcher without services
+	if len(cfg.Dispatcher.Services) == 0 {
+		log.Printf("⚠️  WARNING: no dispatcher services configured. " +
+			"Declare them under dispatcher.services in config.yaml or with APP_DISPATCHER_SERVICE_NAMES\n")
+	}
+
 	// Warn about short JWT expiry
 	if cfg.JWTExpiryHours < 1 {
 		log.Printf("⚠️  WARNING: JWT_EXPIRY_HOURS is very short (%d hours). "+
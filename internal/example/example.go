@@ -2,6 +2,8 @@ package example
 
 import (
 	"context"
+	"encoding/json"
+	"go-worker/internal/poller/job"
 	"log"
 	"math/rand"
 	"time"
@@ -12,6 +14,12 @@ type ExampleJob struct {
 	service string
 }
 
+// exampleJobData is the encoded form of an ExampleJob
+type exampleJobData struct {
+	ID      string `json:"id"`
+	Service string `json:"service"`
+}
+
 func NewExampleJob(id string, service string) *ExampleJob {
 	return &ExampleJob{
 		id:      id,
@@ -27,6 +35,19 @@ func (j *ExampleJob) Service() string {
 	return j.service
 }
 
+func (j *ExampleJob) Encode() ([]byte, error) {
+	return json.Marshal(exampleJobData{ID: j.id, Service: j.service})
+}
+
+// DecodeExampleJob is the job.Decoder for ExampleJob
+func DecodeExampleJob(data []byte) (job.Job, error) {
+	var d exampleJobData
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+	return NewExampleJob(d.ID, d.Service), nil
+}
+
 func (j *ExampleJob) Execute(ctx context.Context) error {
 	// real work goes here
 	log.Printf("executing job id=%s service=%s\n", j.id, j.service)
@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-worker/internal/config"
+	"go-worker/internal/http/response"
+	"go-worker/internal/inventory/dto"
+	"go-worker/internal/inventory/service"
+	"go-worker/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminInventory struct {
+	Service *service.Inventory
+}
+
+func NewAdmin(s *service.Inventory) *AdminInventory {
+	return &AdminInventory{Service: s}
+}
+
+func (c *AdminInventory) RegisterRoutes(rg *gin.RouterGroup, cfg *config.Config) {
+	auth := middleware.JWTAuth(cfg)
+
+	rg.GET("/stock/:productID", auth, c.GetStock)
+	rg.PUT("/stock/:productID", auth, c.SetStock)
+	rg.POST("/reservations", auth, c.Reserve)
+	rg.GET("/reservations/:id", auth, c.GetReservation)
+	rg.POST("/reservations/:id/commit", auth, c.CommitReservation)
+	rg.POST("/reservations/:id/release", auth, c.ReleaseReservation)
+}
+
+// GetStock godoc
+// @Summary Get the stock of a product
+// @Tags Admin Inventory
+// @Produce json
+// @Param productID path int true "Product ID"
+// @Success 200 {object} dto.StockResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/inventory/stock/{productID} [get]
+func (c *AdminInventory) GetStock(ctx *gin.Context) {
+	productID, err := strconv.Atoi(ctx.Param("productID"))
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, response.ErrInvalidID)
+		return
+	}
+	stock, err := c.Service.GetStock(ctx, int32(productID))
+	if err != nil {
+		inventoryError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, stock)
+}
+
+// SetStock godoc
+// @Summary Set the stock of a product
+// @Description Set the stock held of a product, it cannot go below what is reserved
+// @Tags Admin Inventory
+// @Accept json
+// @Produce json
+// @Param productID path int true "Product ID"
+// @Param stock body dto.SetStockRequest true "Stock held"
+// @Success 200 {object} dto.StockResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/inventory/stock/{productID} [put]
+func (c *AdminInventory) SetStock(ctx *gin.Context) {
+	productID, err := strconv.Atoi(ctx.Param("productID"))
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, response.ErrInvalidID)
+		return
+	}
+	var req dto.SetStockRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	stock, err := c.Service.SetStock(ctx, int32(productID), req.OnHand)
+	if err != nil {
+		inventoryError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, stock)
+}
+
+// Reserve godoc
+// @Summary Reserve stock
+// @Description Hold stock of every item or of none, the reservation expires after ttl_seconds unless it is committed
+// @Tags Admin Inventory
+// @Accept json
+// @Produce json
+// @Param reservation body dto.ReserveRequest true "Items to reserve"
+// @Success 201 {object} dto.ReservationResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/inventory/reservations [post]
+func (c *AdminInventory) Reserve(ctx *gin.Context) {
+	var req dto.ReserveRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	reservation, err := c.Service.Reserve(ctx, req)
+	if err != nil {
+		inventoryError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, reservation)
+}
+
+// GetReservation godoc
+// @Summary Get a reservation
+// @Tags Admin Inventory
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Success 200 {object} dto.ReservationResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/inventory/reservations/{id} [get]
+func (c *AdminInventory) GetReservation(ctx *gin.Context) {
+	reservation, err := c.Service.GetReservation(ctx, ctx.Param("id"))
+	if err != nil {
+		inventoryError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, reservation)
+}
+
+// CommitReservation godoc
+// @Summary Commit a reservation
+// @Description Take the reserved stock out of the stock held, only a pending reservation that has not expired can be committed
+// @Tags Admin Inventory
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Success 200 {object} dto.ReservationResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/inventory/reservations/{id}/commit [post]
+func (c *AdminInventory) CommitReservation(ctx *gin.Context) {
+	reservation, err := c.Service.Commit(ctx, ctx.Param("id"))
+	if err != nil {
+		inventoryError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, reservation)
+}
+
+// ReleaseReservation godoc
+// @Summary Release a reservation
+// @Description Give the reserved stock back, only a pending reservation can be released
+// @Tags Admin Inventory
+// @Produce json
+// @Param id path string true "Reservation ID"
+// @Success 200 {object} dto.ReservationResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/inventory/reservations/{id}/release [post]
+func (c *AdminInventory) ReleaseReservation(ctx *gin.Context) {
+	reservation, err := c.Service.Release(ctx, ctx.Param("id"))
+	if err != nil {
+		inventoryError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, reservation)
+}
+
+func inventoryError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrProductNotFound),
+		errors.Is(err, service.ErrStockNotFound),
+		errors.Is(err, service.ErrReservationNotFound):
+		response.JSONError(ctx, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrStockBelowReserved),
+		errors.Is(err, service.ErrInsufficientStock),
+		errors.Is(err, service.ErrReservationClosed),
+		errors.Is(err, service.ErrReservationExpired):
+		response.JSONError(ctx, http.StatusConflict, err)
+	case errors.Is(err, service.ErrInvalidTTL), errors.Is(err, service.ErrInvalidQuantity):
+		response.JSONError(ctx, http.StatusBadRequest, err)
+	default:
+		response.JSONError(ctx, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"context"
+	"errors"
+
+	pb "go-worker/api/proto/inventory/v1"
+	"go-worker/internal/inventory/dto"
+	"go-worker/internal/inventory/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type InventoryGRPC struct {
+	pb.UnimplementedInventoryServiceServer
+	svc *service.Inventory
+}
+
+func NewGRPC(svc *service.Inventory) pb.InventoryServiceServer {
+	return &InventoryGRPC{
+		svc: svc,
+	}
+}
+
+func (h *InventoryGRPC) GetStock(ctx context.Context, req *pb.StockRequest) (*pb.Stock, error) {
+	stock, err := h.svc.GetStock(ctx, req.GetProductId())
+	if err != nil {
+		return nil, inventoryStatus(err)
+	}
+	return toPBStock(stock), nil
+}
+
+func (h *InventoryGRPC) SetStock(ctx context.Context, req *pb.SetStockRequest) (*pb.Stock, error) {
+	if req.GetOnHand() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "on_hand cannot be negative")
+	}
+	stock, err := h.svc.SetStock(ctx, req.GetProductId(), req.GetOnHand())
+	if err != nil {
+		return nil, inventoryStatus(err)
+	}
+	return toPBStock(stock), nil
+}
+
+func (h *InventoryGRPC) Reserve(ctx context.Context, req *pb.ReserveRequest) (*pb.Reservation, error) {
+	if len(req.GetItems()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "items are required")
+	}
+	reserve := dto.ReserveRequest{TTLSeconds: int(req.GetTtlSeconds())}
+	for _, item := range req.GetItems() {
+		reserve.Items = append(reserve.Items, dto.ReservationItem{
+			ProductID: item.GetProductId(),
+			Quantity:  item.GetQuantity(),
+		})
+	}
+	reservation, err := h.svc.Reserve(ctx, reserve)
+	if err != nil {
+		return nil, inventoryStatus(err)
+	}
+	return toPBReservation(reservation), nil
+}
+
+func (h *InventoryGRPC) GetReservation(ctx context.Context, req *pb.ReservationRequest) (*pb.Reservation, error) {
+	reservation, err := h.svc.GetReservation(ctx, req.GetId())
+	if err != nil {
+		return nil, inventoryStatus(err)
+	}
+	return toPBReservation(reservation), nil
+}
+
+func (h *InventoryGRPC) CommitReservation(ctx context.Context, req *pb.ReservationRequest) (*pb.Reservation, error) {
+	reservation, err := h.svc.Commit(ctx, req.GetId())
+	if err != nil {
+		return nil, inventoryStatus(err)
+	}
+	return toPBReservation(reservation), nil
+}
+
+func (h *InventoryGRPC) ReleaseReservation(ctx context.Context, req *pb.ReservationRequest) (*pb.Reservation, error) {
+	reservation, err := h.svc.Release(ctx, req.GetId())
+	if err != nil {
+		return nil, inventoryStatus(err)
+	}
+	return toPBReservation(reservation), nil
+}
+
+func toPBStock(s dto.StockResponse) *pb.Stock {
+	return &pb.Stock{
+		ProductId: s.ProductID,
+		OnHand:    s.OnHand,
+		Reserved:  s.Reserved,
+		Available: s.Available,
+		UpdatedAt: timestamppb.New(s.UpdatedAt),
+	}
+}
+
+func toPBReservation(r dto.ReservationResponse) *pb.Reservation {
+	reservation := &pb.Reservation{
+		Id:        r.ID,
+		Status:    r.Status,
+		ExpiresAt: timestamppb.New(r.ExpiresAt),
+		CreatedAt: timestamppb.New(r.CreatedAt),
+		UpdatedAt: timestamppb.New(r.UpdatedAt),
+	}
+	for _, item := range r.Items {
+		reservation.Items = append(reservation.Items, &pb.ReservationItem{
+			ProductId: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+	}
+	return reservation
+}
+
+// inventoryStatus maps inventory errors to gRPC status codes
+func inventoryStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrProductNotFound),
+		errors.Is(err, service.ErrStockNotFound),
+		errors.Is(err, service.ErrReservationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInsufficientStock):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, service.ErrStockBelowReserved),
+		errors.Is(err, service.ErrReservationClosed),
+		errors.Is(err, service.ErrReservationExpired):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrInvalidTTL), errors.Is(err, service.ErrInvalidQuantity):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
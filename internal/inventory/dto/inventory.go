@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+type SetStockRequest struct {
+	// OnHand is the stock held, it cannot go below what is reserved
+	OnHand int32 `json:"on_hand" binding:"min=0"`
+}
+
+type StockResponse struct {
+	ProductID int32 `json:"product_id"`
+	OnHand    int32 `json:"on_hand"`
+	Reserved  int32 `json:"reserved"`
+	// Available is OnHand minus Reserved
+	Available int32     `json:"available"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ReservationItem struct {
+	ProductID int32 `json:"product_id" binding:"required"`
+	Quantity  int32 `json:"quantity" binding:"required,min=1"`
+}
+
+type ReserveRequest struct {
+	Items []ReservationItem `json:"items" binding:"required,min=1,dive"`
+	// TTLSeconds is how long the stock is held before it is released, 900 when 0
+	TTLSeconds int `json:"ttl_seconds"`
+}
+
+type ReservationResponse struct {
+	ID string `json:"id"`
+	// Status is pending, committed, released or expired
+	Status    string            `json:"status"`
+	Items     []ReservationItem `json:"items"`
+	ExpiresAt time.Time         `json:"expires_at"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-worker/internal/poller"
+	"go-worker/internal/poller/job"
+	"go-worker/internal/storage/sql/sqlc"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ExpiryService is the dispatcher service that releases expired reservations
+const ExpiryService = "inventory_expiry"
+
+const (
+	// expiryInterval is how often the poller dispatches an expiry job
+	expiryInterval = 30 * time.Second
+	// expiryBatchSize is how many expired reservations are read at a time
+	expiryBatchSize = 100
+)
+
+// expiryJob releases the reservations that were not committed in time
+type expiryJob struct {
+	job.BaseJob
+	svc *Inventory
+}
+
+func (j *expiryJob) Execute(ctx context.Context) error {
+	_, err := j.svc.ExpireReservations(ctx)
+	return err
+}
+
+// RegisterExpiry has the poller dispatch an expiry job every expiryInterval
+func RegisterExpiry(p *poller.Poller, s *Inventory) {
+	p.Every(expiryInterval, func() job.Job {
+		now := time.Now().UTC()
+		return &expiryJob{
+			BaseJob: job.BaseJob{
+				JobID:       "inventory-expiry-" + strconv.FormatInt(now.UnixNano(), 10),
+				ServiceName: ExpiryService,
+				CreatedAt:   now,
+			},
+			svc: s,
+		}
+	})
+}
+
+// ExpireReservations releases every pending reservation that expired and
+// returns how many were released
+func (s *Inventory) ExpireReservations(ctx context.Context) (int, error) {
+	expired := 0
+	for {
+		ids, err := s.query.ListExpiredReservations(ctx, sqlc.ListExpiredReservationsParams{
+			Now:       time.Now().UTC(),
+			BatchSize: expiryBatchSize,
+		})
+		if err != nil {
+			return expired, err
+		}
+		for _, id := range ids {
+			_, err := s.finish(ctx, id, ReservationExpired)
+			if errors.Is(err, ErrReservationClosed) {
+				// committed or released since it was listed
+				continue
+			}
+			if err != nil {
+				return expired, err
+			}
+			expired++
+		}
+		if len(ids) < expiryBatchSize {
+			if expired > 0 {
+				s.log.Info("Reservations expired", zap.Int("count", expired))
+			}
+			return expired, nil
+		}
+	}
+}
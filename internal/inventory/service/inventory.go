@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-worker/internal/inventory/dto"
+	"go-worker/internal/storage/sql/sqlc"
+	"go-worker/internal/storage/sql/tx"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrProductNotFound    = errors.New("product not found")
+	ErrStockNotFound      = errors.New("product has no stock")
+	ErrStockBelowReserved = errors.New("stock cannot go below what is reserved")
+)
+
+// postgres error codes
+const (
+	pgForeignKeyViolation = "23503"
+	pgCheckViolation      = "23514"
+)
+
+type Inventory struct {
+	query *sqlc.Queries
+	tx    *tx.Manager // holds the stock row locks
+	log   *zap.Logger
+}
+
+func New(q *sqlc.Queries, tx *tx.Manager, log *zap.Logger) *Inventory {
+	return &Inventory{
+		query: q,
+		tx:    tx,
+		log:   log,
+	}
+}
+
+// SetStock sets the stock held of a product, it cannot go below what is reserved
+func (s *Inventory) SetStock(ctx context.Context, productID, onHand int32) (dto.StockResponse, error) {
+	stock, err := s.query.SetStock(ctx, sqlc.SetStockParams{
+		ProductID: productID,
+		OnHand:    onHand,
+	})
+	if err != nil {
+		return dto.StockResponse{}, stockError(err)
+	}
+	s.log.Info("Stock set", zap.Int32("product_id", productID), zap.Int32("on_hand", onHand))
+	return toStockResponse(stock), nil
+}
+
+func (s *Inventory) GetStock(ctx context.Context, productID int32) (dto.StockResponse, error) {
+	stock, err := s.query.GetStock(ctx, productID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return dto.StockResponse{}, ErrStockNotFound
+	}
+	if err != nil {
+		return dto.StockResponse{}, err
+	}
+	return toStockResponse(stock), nil
+}
+
+func toStockResponse(i sqlc.Inventory) dto.StockResponse {
+	return dto.StockResponse{
+		ProductID: i.ProductID,
+		OnHand:    i.OnHand,
+		Reserved:  i.Reserved,
+		Available: i.OnHand - i.Reserved,
+		UpdatedAt: i.UpdatedAt,
+	}
+}
+
+// stockError maps constraint violations to stock errors
+func stockError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgForeignKeyViolation:
+			return ErrProductNotFound
+		case pgCheckViolation:
+			return ErrStockBelowReserved
+		}
+	}
+	return err
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-worker/internal/inventory/dto"
+	"go-worker/internal/storage/sql/sqlc"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// reservation statuses
+const (
+	ReservationPending   = "pending"
+	ReservationCommitted = "committed"
+	ReservationReleased  = "released"
+	ReservationExpired   = "expired"
+)
+
+const (
+	// DefaultReservationTTL is how long stock is held when the request does not say
+	DefaultReservationTTL = 15 * time.Minute
+	// MaxReservationTTL bounds how long stock can be held
+	MaxReservationTTL = 24 * time.Hour
+)
+
+var (
+	ErrInvalidTTL          = errors.New("ttl_seconds must be between 1 and 86400")
+	ErrInvalidQuantity     = errors.New("quantity must be positive")
+	ErrInsufficientStock   = errors.New("insufficient stock")
+	ErrReservationNotFound = errors.New("reservation not found")
+	ErrReservationClosed   = errors.New("reservation is no longer pending")
+	ErrReservationExpired  = errors.New("reservation expired")
+)
+
+// Reserve holds stock of every item or of none. The stock rows are locked in
+// product order, so concurrent reservations of the same products queue up
+// instead of overselling or deadlocking.
+func (s *Inventory) Reserve(ctx context.Context, req dto.ReserveRequest) (dto.ReservationResponse, error) {
+	ttl := DefaultReservationTTL
+	if req.TTLSeconds != 0 {
+		ttl = time.Duration(req.TTLSeconds) * time.Second
+		if ttl < 0 || ttl > MaxReservationTTL {
+			return dto.ReservationResponse{}, ErrInvalidTTL
+		}
+	}
+	items, err := mergeItems(req.Items)
+	if err != nil {
+		return dto.ReservationResponse{}, err
+	}
+	id, err := newReservationID()
+	if err != nil {
+		return dto.ReservationResponse{}, err
+	}
+
+	var reservation sqlc.StockReservation
+	err = s.tx.WithTx(ctx, func(ctx context.Context, q *sqlc.Queries) error {
+		productIDs, quantities := splitItems(items)
+		stock, err := q.LockStock(ctx, productIDs)
+		if err != nil {
+			return err
+		}
+		available := make(map[int32]int32, len(stock))
+		for _, st := range stock {
+			available[st.ProductID] = st.OnHand - st.Reserved
+		}
+		for _, item := range items {
+			if available[item.ProductID] < item.Quantity {
+				return fmt.Errorf("%w: product %d has %d available",
+					ErrInsufficientStock, item.ProductID, available[item.ProductID])
+			}
+		}
+		for _, item := range items {
+			err := q.ReserveStock(ctx, sqlc.ReserveStockParams{
+				Quantity:  item.Quantity,
+				ProductID: item.ProductID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		reservation, err = q.CreateReservation(ctx, sqlc.CreateReservationParams{
+			ID:        id,
+			Status:    ReservationPending,
+			ExpiresAt: time.Now().UTC().Add(ttl),
+		})
+		if err != nil {
+			return err
+		}
+		return q.CreateReservationItems(ctx, sqlc.CreateReservationItemsParams{
+			ReservationID: id,
+			ProductIds:    productIDs,
+			Quantities:    quantities,
+		})
+	})
+	if err != nil {
+		return dto.ReservationResponse{}, err
+	}
+	s.log.Info("Stock reserved", zap.String("id", id), zap.Int("items", len(items)))
+	return toReservationResponse(reservation, items), nil
+}
+
+func (s *Inventory) GetReservation(ctx context.Context, id string) (dto.ReservationResponse, error) {
+	reservation, err := s.query.GetReservation(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return dto.ReservationResponse{}, ErrReservationNotFound
+	}
+	if err != nil {
+		return dto.ReservationResponse{}, err
+	}
+	rows, err := s.query.ListReservationItems(ctx, id)
+	if err != nil {
+		return dto.ReservationResponse{}, err
+	}
+	return toReservationResponse(reservation, toItems(rows)), nil
+}
+
+// Commit takes the reserved stock out of the stock held, an expired reservation cannot be committed
+func (s *Inventory) Commit(ctx context.Context, id string) (dto.ReservationResponse, error) {
+	return s.finish(ctx, id, ReservationCommitted)
+}
+
+// Release gives the reserved stock back
+func (s *Inventory) Release(ctx context.Context, id string) (dto.ReservationResponse, error) {
+	return s.finish(ctx, id, ReservationReleased)
+}
+
+// finish closes a pending reservation with status. Committed takes its stock
+// out of the stock held, released and expired give it back.
+func (s *Inventory) finish(ctx context.Context, id, status string) (dto.ReservationResponse, error) {
+	var reservation sqlc.StockReservation
+	var items []dto.ReservationItem
+	err := s.tx.WithTx(ctx, func(ctx context.Context, q *sqlc.Queries) error {
+		current, err := q.LockReservation(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrReservationNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if current.Status != ReservationPending {
+			return fmt.Errorf("%w: it is %s", ErrReservationClosed, current.Status)
+		}
+		if status == ReservationCommitted && !current.ExpiresAt.After(time.Now().UTC()) {
+			return ErrReservationExpired
+		}
+
+		rows, err := q.ListReservationItems(ctx, id)
+		if err != nil {
+			return err
+		}
+		items = toItems(rows)
+		productIDs, _ := splitItems(items)
+		if _, err := q.LockStock(ctx, productIDs); err != nil {
+			return err
+		}
+		for _, item := range items {
+			if status == ReservationCommitted {
+				err = q.CommitStock(ctx, sqlc.CommitStockParams{
+					Quantity:  item.Quantity,
+					ProductID: item.ProductID,
+				})
+			} else {
+				err = q.ReleaseStock(ctx, sqlc.ReleaseStockParams{
+					Quantity:  item.Quantity,
+					ProductID: item.ProductID,
+				})
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		reservation, err = q.UpdateReservationStatus(ctx, sqlc.UpdateReservationStatusParams{
+			ID:     id,
+			Status: status,
+		})
+		return err
+	})
+	if err != nil {
+		return dto.ReservationResponse{}, err
+	}
+	s.log.Info("Reservation closed", zap.String("id", id), zap.String("status", status))
+	return toReservationResponse(reservation, items), nil
+}
+
+// mergeItems adds up the quantities of the same product and sorts the items by product
+func mergeItems(items []dto.ReservationItem) ([]dto.ReservationItem, error) {
+	quantities := map[int32]int32{}
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return nil, ErrInvalidQuantity
+		}
+		quantities[item.ProductID] += item.Quantity
+	}
+	merged := make([]dto.ReservationItem, 0, len(quantities))
+	for productID, quantity := range quantities {
+		merged = append(merged, dto.ReservationItem{ProductID: productID, Quantity: quantity})
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].ProductID < merged[j].ProductID })
+	return merged, nil
+}
+
+func splitItems(items []dto.ReservationItem) (productIDs, quantities []int32) {
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+		quantities = append(quantities, item.Quantity)
+	}
+	return productIDs, quantities
+}
+
+func toItems(rows []sqlc.StockReservationItem) []dto.ReservationItem {
+	items := make([]dto.ReservationItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, dto.ReservationItem{ProductID: row.ProductID, Quantity: row.Quantity})
+	}
+	return items
+}
+
+func toReservationResponse(r sqlc.StockReservation, items []dto.ReservationItem) dto.ReservationResponse {
+	return dto.ReservationResponse{
+		ID:        r.ID,
+		Status:    r.Status,
+		Items:     items,
+		ExpiresAt: r.ExpiresAt,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func newReservationID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"go-worker/internal/config"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type claimsKey struct{}
+
+// GRPCAuth checks the bearer token in the authorization metadata of calls to
+// the methods starting with one of prefixes, a whole service such as
+// "/dispatcher.v1.DispatcherService/" or a single method, and keeps its claims
+// in the call context. Other calls are passed through.
+func GRPCAuth(cfg *config.Config, prefixes ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !protected(info.FullMethod, prefixes) {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		authHeader := strings.Join(md.Get("authorization"), "")
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			return nil, status.Error(codes.Unauthenticated, "missing or invalid token")
+		}
+		claims, err := parseToken(cfg, strings.TrimPrefix(authHeader, "Bearer "))
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return handler(context.WithValue(ctx, claimsKey{}, claims), req)
+	}
+}
+
+func protected(method string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// GRPCClaims returns the claims GRPCAuth kept for the call
+func GRPCClaims(ctx context.Context) Claims {
+	claims, _ := ctx.Value(claimsKey{}).(Claims)
+	return claims
+}
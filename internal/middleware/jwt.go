@@ -9,6 +9,12 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// context keys set by JWTAuth from the token claims
+const (
+	ContextAdminID  = "admin_id"
+	ContextTenantID = "tenant_id"
+)
+
 func JWTAuth(cfg *config.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
@@ -16,15 +22,50 @@ func JWTAuth(cfg *config.Config) gin.HandlerFunc {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing or invalid token"})
 			return
 		}
-		tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
-		token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-			return []byte(cfg.JWTSecret), nil
-		})
-
-		if err != nil || !token.Valid {
+		claims, err := parseToken(cfg, strings.TrimPrefix(authHeader, "Bearer "))
+		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 			return
 		}
+		c.Set(ContextAdminID, claims.AdminID)
+		c.Set(ContextTenantID, claims.TenantID)
 		c.Next()
 	}
 }
+
+// Claims are the claims of a token the handlers use
+type Claims struct {
+	AdminID  string
+	TenantID string // empty when the token is not scoped to a tenant
+}
+
+// parseToken checks a token is signed with the JWT secret and returns its claims
+func parseToken(cfg *config.Config, tokenStr string) (Claims, error) {
+	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
+		return []byte(cfg.JWTSecret), nil
+	})
+	if err != nil {
+		return Claims{}, err
+	}
+	if !token.Valid {
+		return Claims{}, jwt.ErrTokenInvalidClaims
+	}
+
+	var claims Claims
+	if mapClaims, ok := token.Claims.(jwt.MapClaims); ok {
+		claims.AdminID, _ = mapClaims["admin_id"].(string)
+		claims.TenantID, _ = mapClaims["tenant_id"].(string)
+	}
+	return claims, nil
+}
+
+// AdminID returns the admin_id claim of the request token
+func AdminID(c *gin.Context) string {
+	return c.GetString(ContextAdminID)
+}
+
+// TenantID returns the tenant_id claim of the request token,
+// empty when the token is not scoped to a tenant
+func TenantID(c *gin.Context) string {
+	return c.GetString(ContextTenantID)
+}
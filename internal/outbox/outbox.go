@@ -0,0 +1,46 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"go-worker/internal/storage/sql/sqlc"
+	"time"
+)
+
+// Event is a domain event as it is handed to a sink
+type Event struct {
+	ID            int64           `json:"id"` // grows with every event, consumers drop copies by it
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	Type          string          `json:"type"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// Add stores an event in the outbox with q, which must be bound to the
+// transaction of the change the event describes so both commit or neither.
+// Add it after the change locked the row of its aggregate, events of one
+// aggregate are then numbered in the order their transactions commit.
+func Add(ctx context.Context, q *sqlc.Queries, aggregateType, aggregateID, eventType string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return q.CreateOutboxEvent(ctx, sqlc.CreateOutboxEventParams{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Payload:       data,
+	})
+}
+
+func toEvent(o sqlc.Outbox) Event {
+	return Event{
+		ID:            o.ID,
+		AggregateType: o.AggregateType,
+		AggregateID:   o.AggregateID,
+		Type:          o.EventType,
+		Payload:       o.Payload,
+		CreatedAt:     o.CreatedAt,
+	}
+}
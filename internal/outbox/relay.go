@@ -0,0 +1,180 @@
+package outbox
+
+import (
+	"context"
+	"go-worker/internal/config"
+	"go-worker/internal/poller"
+	"go-worker/internal/poller/job"
+	"go-worker/internal/storage/sql/sqlc"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RelayService is the dispatcher service that publishes the outbox
+const RelayService = "outbox_relay"
+
+const (
+	// relayInterval is how often the poller dispatches a relay job
+	relayInterval = time.Second
+	// defaultBatchSize is how many events a round claims when OUTBOX_BATCH_SIZE is zero
+	defaultBatchSize = 100
+	// defaultMaxAttempts is how often an event is tried when OUTBOX_MAX_ATTEMPTS is zero
+	defaultMaxAttempts = 10
+	// relayRounds bounds the rounds of a run, a round publishes one event per aggregate
+	relayRounds = 10
+	// claimLease is how long a claimed event is left to its replica, longer
+	// than the relay job timeout
+	claimLease = 2 * time.Minute
+	// minRetryBackoff and maxRetryBackoff bound the wait before a failed event
+	// is retried, it doubles with every attempt
+	minRetryBackoff = time.Second
+	maxRetryBackoff = 5 * time.Minute
+	// retention is how long published events are kept
+	retention = 7 * 24 * time.Hour
+)
+
+// Relay publishes the events of the outbox to a sink
+type Relay struct {
+	query       *sqlc.Queries
+	sink        Sink
+	batchSize   int32
+	maxAttempts int32
+	log         *zap.Logger
+}
+
+func NewRelay(q *sqlc.Queries, sink Sink, cfg *config.Config, log *zap.Logger) *Relay {
+	batchSize := int32(cfg.Outbox.BatchSize)
+	if batchSize == 0 {
+		batchSize = defaultBatchSize
+	}
+	maxAttempts := int32(cfg.Outbox.MaxAttempts)
+	if maxAttempts == 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	return &Relay{
+		query:       q,
+		sink:        sink,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		log:         log,
+	}
+}
+
+// relayJob publishes a batch of the outbox
+type relayJob struct {
+	job.BaseJob
+	relay *Relay
+}
+
+func (j *relayJob) Execute(ctx context.Context) error {
+	_, err := j.relay.Relay(ctx)
+	return err
+}
+
+// RegisterRelay has the poller dispatch a relay job every relayInterval
+func RegisterRelay(p *poller.Poller, r *Relay) {
+	p.Every(relayInterval, func() job.Job {
+		now := time.Now().UTC()
+		return &relayJob{
+			BaseJob: job.BaseJob{
+				JobID:       "outbox-relay-" + strconv.FormatInt(now.UnixNano(), 10),
+				ServiceName: RelayService,
+				CreatedAt:   now,
+			},
+			relay: r,
+		}
+	})
+}
+
+// Relay publishes the pending events in the order they were added and
+// returns how many were published. Each round claims the oldest pending event
+// of every aggregate for claimLease, publishes the claimed events with no
+// transaction open and then marks them, so replicas relay different
+// aggregates at once and an aggregate never has two events in flight. An
+// event the sink fails holds back the later events of its aggregate until it
+// is retried after a backoff, or parked once it has failed maxAttempts times.
+func (r *Relay) Relay(ctx context.Context) (int, error) {
+	published := 0
+	for round := 0; round < relayRounds; round++ {
+		now := time.Now().UTC()
+		events, err := r.query.ClaimOutboxEvents(ctx, sqlc.ClaimOutboxEventsParams{
+			LockedUntil: now.Add(claimLease),
+			Now:         now,
+			BatchSize:   r.batchSize,
+		})
+		if err != nil {
+			return published, err
+		}
+		if len(events) == 0 {
+			break
+		}
+		sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+
+		var ids []int64
+		for _, e := range events {
+			if err := r.sink.Publish(ctx, toEvent(e)); err != nil {
+				if err := r.fail(ctx, e, err); err != nil {
+					return published, err
+				}
+				continue
+			}
+			ids = append(ids, e.ID)
+		}
+		if len(ids) == 0 {
+			break
+		}
+		// a crash before this publishes the events again once the claim expires
+		err = r.query.MarkOutboxPublished(ctx, sqlc.MarkOutboxPublishedParams{Now: time.Now().UTC(), Ids: ids})
+		if err != nil {
+			return published, err
+		}
+		published += len(ids)
+	}
+
+	if _, err := r.query.DeletePublishedOutbox(ctx, time.Now().UTC().Add(-retention)); err != nil {
+		return published, err
+	}
+	if published > 0 {
+		r.log.Info("Events published", zap.Int("count", published))
+	}
+	return published, nil
+}
+
+// fail counts a failed publish of e and schedules its retry, or parks it
+func (r *Relay) fail(ctx context.Context, e sqlc.Outbox, cause error) error {
+	now := time.Now().UTC()
+	parked, err := r.query.MarkOutboxFailed(ctx, sqlc.MarkOutboxFailedParams{
+		LastError:   cause.Error(),
+		RetryAt:     now.Add(retryBackoff(e.Attempts + 1)),
+		MaxAttempts: r.maxAttempts,
+		Now:         now,
+		ID:          e.ID,
+	})
+	if err != nil {
+		return err
+	}
+	fields := []zap.Field{
+		zap.Int64("id", e.ID),
+		zap.String("aggregate", e.AggregateType+":"+e.AggregateID),
+		zap.Int32("attempts", e.Attempts+1),
+		zap.Error(cause),
+	}
+	if parked {
+		r.log.Error("Event parked", fields...)
+		return nil
+	}
+	r.log.Warn("Could not publish event", fields...)
+	return nil
+}
+
+// retryBackoff is how long an event waits after its attempts-th failure
+func retryBackoff(attempts int32) time.Duration {
+	backoff := minRetryBackoff
+	for i := int32(1); i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxRetryBackoff)
+}
@@ -0,0 +1,115 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-worker/internal/config"
+	"go-worker/internal/storage/cache"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// defaultWebhookTimeout is used when OUTBOX_WEBHOOK_TIMEOUT is zero
+const defaultWebhookTimeout = 10 * time.Second
+
+// Sink publishes the events the relay reads from the outbox. An event can be
+// published more than once, when the relay stops before it records the
+// event as published.
+type Sink interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// NewSink returns the sink OUTBOX_SINK names
+func NewSink(cfg *config.Config, store *cache.Store, log *zap.Logger) Sink {
+	switch cfg.Outbox.Sink {
+	case "redis":
+		return NewStreamSink(store, cfg.Outbox.StreamMaxLen)
+	case "webhook":
+		timeout := cfg.Outbox.WebhookTimeout
+		if timeout == 0 {
+			timeout = defaultWebhookTimeout
+		}
+		return NewWebhookSink(cfg.Outbox.WebhookURL, timeout)
+	default:
+		return NewLogSink(log)
+	}
+}
+
+// StreamSink appends every event to the redis stream of its aggregate type
+type StreamSink struct {
+	store  *cache.Store
+	maxLen int64
+}
+
+func NewStreamSink(store *cache.Store, maxLen int64) *StreamSink {
+	return &StreamSink{store: store, maxLen: maxLen}
+}
+
+func (s *StreamSink) Publish(ctx context.Context, event Event) error {
+	_, err := s.store.AddToStream(ctx, s.store.KeyEventStream(event.AggregateType), map[string]interface{}{
+		"id":           event.ID,
+		"aggregate_id": event.AggregateID,
+		"type":         event.Type,
+		"payload":      string(event.Payload),
+		"created_at":   event.CreatedAt.Format(time.RFC3339Nano),
+	}, s.maxLen)
+	return err
+}
+
+// WebhookSink posts every event as JSON to a URL, any answer but a 2xx fails it
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookSink(url string, timeout time.Duration) *WebhookSink {
+	return &WebhookSink{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (s *WebhookSink) Publish(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", strconv.FormatInt(event.ID, 10))
+	req.Header.Set("X-Event-Type", event.Type)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return nil
+}
+
+// LogSink only logs every event, for development and tests
+type LogSink struct {
+	log *zap.Logger
+}
+
+func NewLogSink(log *zap.Logger) *LogSink {
+	return &LogSink{log: log}
+}
+
+func (s *LogSink) Publish(ctx context.Context, event Event) error {
+	s.log.Info("Event published",
+		zap.Int64("id", event.ID),
+		zap.String("aggregate_type", event.AggregateType),
+		zap.String("aggregate_id", event.AggregateID),
+		zap.String("type", event.Type),
+		zap.ByteString("payload", event.Payload))
+	return nil
+}
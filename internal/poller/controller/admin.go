@@ -0,0 +1,224 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"go-worker/internal/config"
+	"go-worker/internal/http/response"
+	"go-worker/internal/middleware"
+	"go-worker/internal/poller/dispatcher"
+	"go-worker/internal/poller/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+var ErrTenantScoped = errors.New("tenant scoped tokens cannot change dispatcher services")
+
+type AdminDispatcher struct {
+	Dispatcher *dispatcher.Service
+}
+
+func NewAdmin(d *dispatcher.Service) *AdminDispatcher {
+	return &AdminDispatcher{Dispatcher: d}
+}
+
+func (c *AdminDispatcher) RegisterRoutes(rg *gin.RouterGroup, cfg *config.Config) {
+	auth := middleware.JWTAuth(cfg)
+
+	rg.GET("/", auth, c.ListServices)
+	rg.GET("/:name/tenants", auth, c.ListTenants)
+	rg.POST("/:name/pause", auth, unscoped, c.PauseService)
+	rg.POST("/:name/resume", auth, unscoped, c.ResumeService)
+	rg.POST("/:name/drain", auth, unscoped, c.DrainService)
+}
+
+// ListServices godoc
+// @Summary List dispatcher services
+// @Description Get the status of every registered dispatcher service, tenant scoped tokens only see the queued and running jobs of their own tenant
+// @Tags Admin Dispatcher
+// @Produce json
+// @Success 200 {array} dto.ServiceStatusResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/dispatcher/services [get]
+func (c *AdminDispatcher) ListServices(ctx *gin.Context) {
+	services := scopedServices(c.Dispatcher, middleware.TenantID(ctx))
+	resp := make(dto.ListServicesResponse, 0, len(services))
+	for _, s := range services {
+		resp = append(resp, toServiceStatusResponse(s))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ListTenants godoc
+// @Summary List the tenants of a dispatcher service
+// @Description Get the queued and running jobs and limits of every tenant of a service, tenant scoped tokens only see their own tenant
+// @Tags Admin Dispatcher
+// @Produce json
+// @Param name path string true "Service name"
+// @Success 200 {array} dto.TenantStatusResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/dispatcher/services/{name}/tenants [get]
+func (c *AdminDispatcher) ListTenants(ctx *gin.Context) {
+	name := ctx.Param("name")
+
+	var tenants []dispatcher.TenantStatus
+	if tenant := middleware.TenantID(ctx); tenant != "" {
+		t, err := c.Dispatcher.TenantStatus(name, tenant)
+		if err != nil {
+			serviceError(ctx, err)
+			return
+		}
+		tenants = append(tenants, t)
+	} else {
+		var err error
+		tenants, err = c.Dispatcher.Tenants(name)
+		if err != nil {
+			serviceError(ctx, err)
+			return
+		}
+	}
+
+	resp := make(dto.ListTenantsResponse, 0, len(tenants))
+	for _, t := range tenants {
+		resp = append(resp, toTenantStatusResponse(t))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// PauseService godoc
+// @Summary Pause a dispatcher service
+// @Description Stop the workers of a service from picking up jobs, the paused state survives restarts. A draining service cannot be paused
+// @Tags Admin Dispatcher
+// @Produce json
+// @Param name path string true "Service name"
+// @Success 200 {object} dto.ServiceStatusResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/dispatcher/services/{name}/pause [post]
+func (c *AdminDispatcher) PauseService(ctx *gin.Context) {
+	name := ctx.Param("name")
+	if err := c.Dispatcher.Pause(ctx, name); err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	c.respondStatus(ctx, name)
+}
+
+// ResumeService godoc
+// @Summary Resume a dispatcher service
+// @Description Let the workers of a paused or draining service pick up jobs again
+// @Tags Admin Dispatcher
+// @Produce json
+// @Param name path string true "Service name"
+// @Success 200 {object} dto.ServiceStatusResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/dispatcher/services/{name}/resume [post]
+func (c *AdminDispatcher) ResumeService(ctx *gin.Context) {
+	name := ctx.Param("name")
+	if err := c.Dispatcher.Resume(ctx, name); err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	c.respondStatus(ctx, name)
+}
+
+// DrainService godoc
+// @Summary Drain a dispatcher service
+// @Description Reject new jobs, wait for queued jobs to finish and leave the service paused
+// @Tags Admin Dispatcher
+// @Produce json
+// @Param name path string true "Service name"
+// @Success 200 {object} dto.ServiceStatusResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/dispatcher/services/{name}/drain [post]
+func (c *AdminDispatcher) DrainService(ctx *gin.Context) {
+	name := ctx.Param("name")
+	if err := c.Dispatcher.Drain(ctx, name); err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	c.respondStatus(ctx, name)
+}
+
+func (c *AdminDispatcher) respondStatus(ctx *gin.Context, name string) {
+	status, err := c.Dispatcher.Status(name)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, toServiceStatusResponse(status))
+}
+
+// scopedServices returns the status of every service, with the queued and
+// running jobs of tenant only when it is not empty
+func scopedServices(d *dispatcher.Service, tenant string) []dispatcher.ServiceStatus {
+	services := d.Services()
+	if tenant == "" {
+		return services
+	}
+	for i, s := range services {
+		t, err := d.TenantStatus(s.Name, tenant)
+		if err != nil {
+			// unregistered since Services, it has nothing of tenant
+			t = dispatcher.TenantStatus{}
+		}
+		services[i].QueueLength = t.Queued
+		services[i].Busy = t.Running
+	}
+	return services
+}
+
+// unscoped rejects tokens scoped to a tenant, a service is shared by every tenant
+func unscoped(ctx *gin.Context) {
+	if middleware.TenantID(ctx) != "" {
+		response.JSONError(ctx, http.StatusForbidden, ErrTenantScoped)
+		return
+	}
+	ctx.Next()
+}
+
+func serviceError(ctx *gin.Context, err error) {
+	if errors.Is(err, dispatcher.ErrServiceNotRegistered) {
+		response.JSONError(ctx, http.StatusNotFound, err)
+		return
+	}
+	if errors.Is(err, dispatcher.ErrServiceDraining) {
+		response.JSONError(ctx, http.StatusConflict, err)
+		return
+	}
+	response.JSONError(ctx, http.StatusInternalServerError, err)
+}
+
+func toServiceStatusResponse(s dispatcher.ServiceStatus) dto.ServiceStatusResponse {
+	return dto.ServiceStatusResponse{
+		Name:          s.Name,
+		Workers:       s.Workers,
+		QueueLength:   s.QueueLength,
+		QueueCapacity: s.QueueCapacity,
+		Busy:          s.Busy,
+		Paused:        s.Paused,
+		Draining:      s.Draining,
+	}
+}
+
+func toTenantStatusResponse(t dispatcher.TenantStatus) dto.TenantStatusResponse {
+	return dto.TenantStatusResponse{
+		Tenant:     t.Tenant,
+		Queued:     t.Queued,
+		Running:    t.Running,
+		MaxQueued:  t.Limits.MaxQueued,
+		MaxRunning: t.Limits.MaxRunning,
+	}
+}
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"context"
+	"errors"
+
+	pb "go-worker/api/proto/dispatcher/v1"
+	"go-worker/internal/middleware"
+	"go-worker/internal/poller/dispatcher"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type DispatcherGRPC struct {
+	pb.UnimplementedDispatcherServiceServer
+	dispatcher *dispatcher.Service
+}
+
+func NewGRPC(d *dispatcher.Service) pb.DispatcherServiceServer {
+	return &DispatcherGRPC{
+		dispatcher: d,
+	}
+}
+
+func (h *DispatcherGRPC) ListServices(ctx context.Context, _ *emptypb.Empty) (*pb.ListServicesResponse, error) {
+	var resp pb.ListServicesResponse
+	for _, s := range scopedServices(h.dispatcher, middleware.GRPCClaims(ctx).TenantID) {
+		resp.Services = append(resp.Services, toPBServiceStatus(s))
+	}
+	return &resp, nil
+}
+
+func (h *DispatcherGRPC) PauseService(ctx context.Context, req *pb.ServiceRequest) (*pb.ServiceStatus, error) {
+	if middleware.GRPCClaims(ctx).TenantID != "" {
+		return nil, status.Error(codes.PermissionDenied, ErrTenantScoped.Error())
+	}
+	if err := h.dispatcher.Pause(ctx, req.Name); err != nil {
+		return nil, serviceStatus(err)
+	}
+	return h.status(req.Name)
+}
+
+func (h *DispatcherGRPC) ResumeService(ctx context.Context, req *pb.ServiceRequest) (*pb.ServiceStatus, error) {
+	if middleware.GRPCClaims(ctx).TenantID != "" {
+		return nil, status.Error(codes.PermissionDenied, ErrTenantScoped.Error())
+	}
+	if err := h.dispatcher.Resume(ctx, req.Name); err != nil {
+		return nil, serviceStatus(err)
+	}
+	return h.status(req.Name)
+}
+
+func (h *DispatcherGRPC) DrainService(ctx context.Context, req *pb.ServiceRequest) (*pb.ServiceStatus, error) {
+	if middleware.GRPCClaims(ctx).TenantID != "" {
+		return nil, status.Error(codes.PermissionDenied, ErrTenantScoped.Error())
+	}
+	if err := h.dispatcher.Drain(ctx, req.Name); err != nil {
+		return nil, serviceStatus(err)
+	}
+	return h.status(req.Name)
+}
+
+func (h *DispatcherGRPC) ListTenants(ctx context.Context, req *pb.ListTenantsRequest) (*pb.ListTenantsResponse, error) {
+	tenant := req.Tenant
+	if scoped := middleware.GRPCClaims(ctx).TenantID; scoped != "" {
+		if tenant != "" && tenant != scoped {
+			return nil, status.Error(codes.PermissionDenied, "tenant scoped tokens only see their own tenant")
+		}
+		tenant = scoped
+	}
+
+	var tenants []dispatcher.TenantStatus
+	if tenant != "" {
+		t, err := h.dispatcher.TenantStatus(req.Name, tenant)
+		if err != nil {
+			return nil, serviceStatus(err)
+		}
+		tenants = append(tenants, t)
+	} else {
+		var err error
+		tenants, err = h.dispatcher.Tenants(req.Name)
+		if err != nil {
+			return nil, serviceStatus(err)
+		}
+	}
+
+	var resp pb.ListTenantsResponse
+	for _, t := range tenants {
+		resp.Tenants = append(resp.Tenants, toPBTenantStatus(t))
+	}
+	return &resp, nil
+}
+
+func (h *DispatcherGRPC) status(name string) (*pb.ServiceStatus, error) {
+	s, err := h.dispatcher.Status(name)
+	if err != nil {
+		return nil, serviceStatus(err)
+	}
+	return toPBServiceStatus(s), nil
+}
+
+// serviceStatus maps dispatcher errors to gRPC status codes
+func serviceStatus(err error) error {
+	switch {
+	case errors.Is(err, dispatcher.ErrServiceNotRegistered):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, dispatcher.ErrServiceDraining), errors.Is(err, dispatcher.ErrServicePaused):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return err
+}
+
+func toPBServiceStatus(s dispatcher.ServiceStatus) *pb.ServiceStatus {
+	return &pb.ServiceStatus{
+		Name:          s.Name,
+		Workers:       int32(s.Workers),
+		QueueLength:   int32(s.QueueLength),
+		QueueCapacity: int32(s.QueueCapacity),
+		Busy:          int32(s.Busy),
+		Paused:        s.Paused,
+		Draining:      s.Draining,
+	}
+}
+
+func toPBTenantStatus(t dispatcher.TenantStatus) *pb.TenantStatus {
+	return &pb.TenantStatus{
+		Tenant:     t.Tenant,
+		Queued:     int32(t.Queued),
+		Running:    int32(t.Running),
+		MaxQueued:  int32(t.Limits.MaxQueued),
+		MaxRunning: int32(t.Limits.MaxRunning),
+	}
+}
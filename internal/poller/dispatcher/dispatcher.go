@@ -2,15 +2,19 @@
 package dispatcher
 
 //Producer → Dispatcher → Service Queue → Worker Pool → Job.Execute()
-//One queue per service
+//One queue per service, jobs are handed to workers round robin across tenants
 //N workers per service
 import (
 	"context"
 	"errors"
 	"fmt"
+	"go-worker/internal/config"
 	"go-worker/internal/poller/job"
 	"go-worker/internal/poller/worker"
+	"log"
+	"sort"
 	"sync"
+	"time"
 
 	"go.uber.org/fx"
 )
@@ -21,26 +25,62 @@ type Dispatcher interface {
 	Dispatch(j job.Job) error
 }
 
-var ErrServiceNotRegistered = errors.New("service not registered")
+var (
+	ErrServiceNotRegistered = errors.New("service not registered")
+	ErrServicePaused        = errors.New("service paused")
+	ErrServiceDraining      = errors.New("service draining")
+)
+
+const (
+	// drainPollInterval is how often Drain checks whether a service is empty
+	drainPollInterval = 50 * time.Millisecond
+	// refillInterval is how often spilled jobs are moved back into their queue
+	refillInterval = 100 * time.Millisecond
+)
 
 type Service struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 
 	mu sync.RWMutex
+	// closeMu is held for reading while sending to a queue so Stop never closes a queue mid send
+	closeMu sync.RWMutex
+
+	// queues hand jobs taken from pending to the workers, they are unbuffered
+	queues   map[string]chan job.Job
+	pending  map[string]*fairQueue
+	workers  map[string][]worker.Worker
+	gates    map[string]*worker.Gate
+	options  map[string]options
+	draining map[string]bool
+	started  bool
 
-	queues  map[string]chan job.Job
-	workers map[string][]worker.Worker
+	state StateStore
+}
+
+// ServiceStatus is a point-in-time view of a registered service
+type ServiceStatus struct {
+	Name          string
+	Workers       int
+	QueueLength   int
+	QueueCapacity int
+	Busy          int
+	Paused        bool
+	Draining      bool
 }
 
 func New() *Service {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	return &Service{
-		ctx:     ctx,
-		cancel:  cancel,
-		queues:  make(map[string]chan job.Job),
-		workers: make(map[string][]worker.Worker),
+		ctx:      ctx,
+		cancel:   cancel,
+		queues:   make(map[string]chan job.Job),
+		pending:  make(map[string]*fairQueue),
+		workers:  make(map[string][]worker.Worker),
+		gates:    make(map[string]*worker.Gate),
+		options:  make(map[string]options),
+		draining: make(map[string]bool),
 	}
 }
 
@@ -48,6 +88,7 @@ func (d *Service) Register(
 	service string,
 	workerCount int,
 	queueSize int,
+	opts ...Option,
 ) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -57,27 +98,85 @@ func (d *Service) Register(
 		return
 	}
 
-	queue := make(chan job.Job, queueSize)
+	o := newOptions(opts)
+
+	queue := make(chan job.Job)
 	d.queues[service] = queue
 
+	pending := newFairQueue(queueSize, o.tenantLimits)
+	d.pending[service] = pending
+
+	gate := worker.NewGate()
+	d.gates[service] = gate
+
+	if o.overflow == OverflowSpill && o.overflowStore == nil {
+		log.Printf("⚠️ service %q has no overflow store, falling back to %s\n", service, OverflowBlock)
+		o.overflow = OverflowBlock
+	}
+	if o.policy.Limiter == nil {
+		// unlimited, but can be limited later by SetRateLimit
+		o.policy.Limiter = worker.NewLimiter(0, 0)
+	}
+	o.policy.OnDone = pending.Done
+	o.policy.Requeue = func(j job.Job) { d.requeue(pending, o, j) }
+	d.options[service] = o
+
 	var workers []worker.Worker
 	for i := 0; i < workerCount; i++ {
-		w := worker.NewSimpleWorker(i+1, queue)
+		w := worker.NewGatedWorker(i+1, queue, gate, o.policy)
 		workers = append(workers, w)
 	}
 
 	d.workers[service] = workers
 }
 
-func (d *Service) Start() {
+// SetStateStore sets where paused state is persisted, nil disables persistence
+func (d *Service) SetStateStore(state StateStore) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.state = state
+}
+
+// RestoreState pauses every registered service that was paused before the last restart
+func (d *Service) RestoreState(ctx context.Context) error {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 
+	if d.state == nil {
+		return nil
+	}
+
+	for service, gate := range d.gates {
+		paused, err := d.state.IsPaused(ctx, service)
+		if err != nil {
+			return fmt.Errorf("restore state of %q: %w", service, err)
+		}
+		if paused {
+			log.Printf("service %q restored as paused\n", service)
+			gate.Pause()
+		}
+	}
+	return nil
+}
+
+func (d *Service) Start() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.started = true
+
 	for _, ws := range d.workers {
 		for _, w := range ws {
 			w.Start(d.ctx)
 		}
 	}
+
+	for service, o := range d.options {
+		go d.feed(d.queues[service], d.pending[service], d.gates[service])
+		if o.overflow == OverflowSpill {
+			go d.refill(service, d.pending[service], o.overflowStore)
+		}
+	}
 }
 
 func (d *Service) Dispatch(j job.Job) error {
@@ -89,25 +188,408 @@ func (d *Service) Dispatch(j job.Job) error {
 	}
 
 	d.mu.RLock()
-	queue, ok := d.queues[j.Service()]
+	pending, ok := d.pending[j.Service()]
+	gate := d.gates[j.Service()]
+	opts := d.options[j.Service()]
+	draining := d.draining[j.Service()]
 	d.mu.RUnlock()
 
 	if !ok {
 		return ErrServiceNotRegistered
 	}
+	if draining {
+		return ErrServiceDraining
+	}
+	if opts.rejectWhenPaused && gate.Paused() {
+		return ErrServicePaused
+	}
+
+	switch opts.overflow {
+	case OverflowReject:
+		return pending.Push(j)
+
+	case OverflowDropOldest:
+		for {
+			err := pending.Push(j)
+			if !errors.Is(err, ErrQueueFull) {
+				return err
+			}
+			if dropped := pending.DropOldest(); dropped != nil {
+				log.Printf("queue full, dropped oldest job id=%s service=%s tenant=%s\n", dropped.ID(), dropped.Service(), job.TenantOf(dropped))
+			}
+		}
+
+	case OverflowSpill:
+		// keep FIFO order while older jobs are still spilled
+		spilled, err := opts.overflowStore.Len(d.ctx, j.Service())
+		if err != nil {
+			return err
+		}
+		if spilled == 0 {
+			err := pending.Push(j)
+			if !errors.Is(err, ErrQueueFull) {
+				return err
+			}
+		}
+		return opts.overflowStore.Push(d.ctx, j.Service(), j)
+
+	default:
+		var timeout <-chan time.Time
+		if opts.blockTimeout > 0 {
+			timer := time.NewTimer(opts.blockTimeout)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+
+		for {
+			changed := pending.Changed()
+			err := pending.Push(j)
+			if !errors.Is(err, ErrQueueFull) {
+				return err
+			}
+
+			select {
+			case <-changed:
+			case <-timeout:
+				return ErrQueueFull
+			case <-d.ctx.Done():
+				return context.Canceled
+			}
+		}
+	}
+}
+
+// feed hands the jobs of a service queue to its workers in fair order.
+// A job taken while the service is paused goes back to the queue.
+func (d *Service) feed(queue chan job.Job, pending *fairQueue, gate *worker.Gate) {
+	for {
+		if err := gate.Wait(d.ctx); err != nil {
+			return
+		}
+		j, err := pending.Next(d.ctx)
+		if err != nil {
+			return
+		}
+		if !d.send(queue, gate, pending, j) {
+			return
+		}
+	}
+}
+
+// send blocks until a worker takes j or the service is paused,
+// it returns false once the dispatcher is stopped
+func (d *Service) send(queue chan job.Job, gate *worker.Gate, pending *fairQueue, j job.Job) bool {
+	d.closeMu.RLock()
+	defer d.closeMu.RUnlock()
 
-	// Safe send
 	select {
 	case queue <- j:
-		return nil
+		return true
+	case <-gate.Closed():
+		pending.PutBack(j)
+		return true
 	case <-d.ctx.Done():
-		return context.Canceled
+		pending.PutBack(j)
+		return false
+	}
+}
+
+// requeue takes back a job a worker picked up but could not start. It goes
+// back to the front of its queue, or once the dispatcher is stopping to the
+// overflow store of a spilling service, other services lose it.
+func (d *Service) requeue(pending *fairQueue, o options, j job.Job) {
+	select {
+	case <-d.ctx.Done():
+	default:
+		pending.PutBack(j)
+		return
+	}
+
+	pending.Done(j)
+	if o.overflow != OverflowSpill {
+		log.Printf("dispatcher stopped, dropped job id=%s service=%s\n", j.ID(), j.Service())
+		return
+	}
+	if err := o.overflowStore.Push(context.Background(), j.Service(), j); err != nil {
+		log.Printf("dispatcher stopped, lost job id=%s service=%s error=%v\n", j.ID(), j.Service(), err)
+	}
+}
+
+// refill moves spilled jobs of a service back into its queue whenever there is room
+func (d *Service) refill(service string, pending *fairQueue, store OverflowStore) {
+	ticker := time.NewTicker(refillInterval)
+	defer ticker.Stop()
+
+	if n, err := store.Recover(d.ctx, service); err != nil {
+		log.Printf("refill service=%s recover error=%v\n", service, err)
+	} else if n > 0 {
+		log.Printf("refill service=%s recovered %d popped jobs\n", service, n)
+	}
+
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for pending.Len() < pending.Cap() {
+			j, err := store.Pop(d.ctx, service)
+			if err != nil {
+				log.Printf("refill service=%s error=%v\n", service, err)
+				break
+			}
+			if j == nil {
+				break
+			}
+			if err := pending.Push(j); err != nil {
+				// over its tenant quota or raced with Dispatch, retry on the next tick
+				if err := store.Requeue(context.Background(), service, j); err != nil {
+					log.Printf("refill service=%s requeue job id=%s error=%v\n", service, j.ID(), err)
+				}
+				break
+			}
+			if err := store.Ack(context.Background(), service, j); err != nil {
+				log.Printf("refill service=%s ack job id=%s error=%v\n", service, j.ID(), err)
+			}
+		}
 	}
 }
 
+// Resize changes the number of workers of a service.
+// Removed workers stop once their current job is finished.
+func (d *Service) Resize(service string, workerCount int) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	workers, ok := d.workers[service]
+	if !ok {
+		return ErrServiceNotRegistered
+	}
+
+	for len(workers) < workerCount {
+		w := worker.NewGatedWorker(len(workers)+1, d.queues[service], d.gates[service], d.options[service].policy)
+		if d.started {
+			w.Start(d.ctx)
+		}
+		workers = append(workers, w)
+	}
+	for len(workers) > workerCount {
+		workers[len(workers)-1].Retire()
+		workers = workers[:len(workers)-1]
+	}
+
+	d.workers[service] = workers
+	return nil
+}
+
+// SetRateLimit changes how many jobs per second a service runs, 0 means unlimited
+func (d *Service) SetRateLimit(service string, perSecond float64, burst int) error {
+	d.mu.RLock()
+	o, ok := d.options[service]
+	d.mu.RUnlock()
+
+	if !ok {
+		return ErrServiceNotRegistered
+	}
+	worker.SetLimit(o.policy.Limiter, perSecond, burst)
+	return nil
+}
+
+// Pause stops the workers of a service from picking up jobs.
+// Queued jobs stay in the queue until the service is resumed.
+// A draining service cannot be paused, it would never empty its queue.
+func (d *Service) Pause(ctx context.Context, service string) error {
+	d.mu.RLock()
+	_, ok := d.gates[service]
+	draining := d.draining[service]
+	d.mu.RUnlock()
+
+	if !ok {
+		return ErrServiceNotRegistered
+	}
+	if draining {
+		return ErrServiceDraining
+	}
+	return d.pause(ctx, service)
+}
+
+func (d *Service) pause(ctx context.Context, service string) error {
+	d.mu.RLock()
+	gate := d.gates[service]
+	state := d.state
+	d.mu.RUnlock()
+
+	gate.Pause()
+	if state != nil {
+		return state.SetPaused(ctx, service, true)
+	}
+	return nil
+}
+
+// Resume lets the workers of a service pick up jobs again and ends any drain
+func (d *Service) Resume(ctx context.Context, service string) error {
+	d.mu.Lock()
+	gate, ok := d.gates[service]
+	delete(d.draining, service)
+	state := d.state
+	d.mu.Unlock()
+
+	if !ok {
+		return ErrServiceNotRegistered
+	}
+
+	gate.Resume()
+	if state != nil {
+		return state.SetPaused(ctx, service, false)
+	}
+	return nil
+}
+
+// Drain rejects new jobs for a service, waits until its queued and running
+// jobs are finished and then leaves it paused. If ctx is done first the
+// service keeps draining until it is resumed.
+func (d *Service) Drain(ctx context.Context, service string) error {
+	d.mu.Lock()
+	pending, ok := d.pending[service]
+	gate := d.gates[service]
+	if ok {
+		d.draining[service] = true
+	}
+	d.mu.Unlock()
+
+	if !ok {
+		return ErrServiceNotRegistered
+	}
+
+	// a paused service would never empty its queue
+	gate.Resume()
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for pending.Len() > 0 || pending.Running() > 0 || d.spilled(ctx, service) > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-d.ctx.Done():
+			return context.Canceled
+		case <-ticker.C:
+		}
+	}
+
+	if err := d.pause(ctx, service); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	delete(d.draining, service)
+	d.mu.Unlock()
+	return nil
+}
+
+// spilled returns how many jobs of a service wait in its overflow store
+func (d *Service) spilled(ctx context.Context, service string) int64 {
+	d.mu.RLock()
+	o := d.options[service]
+	d.mu.RUnlock()
+
+	if o.overflow != OverflowSpill {
+		return 0
+	}
+	n, err := o.overflowStore.Len(ctx, service)
+	if err != nil {
+		log.Printf("overflow store service=%s error=%v\n", service, err)
+		return 0
+	}
+	return n
+}
+
+// Status returns the status of a single service
+func (d *Service) Status(service string) (ServiceStatus, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if _, ok := d.queues[service]; !ok {
+		return ServiceStatus{}, ErrServiceNotRegistered
+	}
+	return d.status(service), nil
+}
+
+// Services returns the status of every registered service ordered by name
+func (d *Service) Services() []ServiceStatus {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	statuses := make([]ServiceStatus, 0, len(d.queues))
+	for service := range d.queues {
+		statuses = append(statuses, d.status(service))
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// status must be called with d.mu held
+func (d *Service) status(service string) ServiceStatus {
+	pending := d.pending[service]
+	gate := d.gates[service]
+	return ServiceStatus{
+		Name:          service,
+		Workers:       len(d.workers[service]),
+		QueueLength:   pending.Len(),
+		QueueCapacity: pending.Cap(),
+		Busy:          gate.Busy(),
+		Paused:        gate.Paused(),
+		Draining:      d.draining[service],
+	}
+}
+
+// SetTenantLimits overrides the limits of a tenant within a service,
+// an empty tenant changes the limits of every tenant without an override
+func (d *Service) SetTenantLimits(service, tenant string, limits TenantLimits) error {
+	d.mu.RLock()
+	pending, ok := d.pending[service]
+	d.mu.RUnlock()
+
+	if !ok {
+		return ErrServiceNotRegistered
+	}
+	pending.SetLimits(tenant, limits)
+	return nil
+}
+
+// Tenants returns the status of every tenant of a service ordered by name
+func (d *Service) Tenants(service string) ([]TenantStatus, error) {
+	d.mu.RLock()
+	pending, ok := d.pending[service]
+	d.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrServiceNotRegistered
+	}
+	return pending.Tenants(), nil
+}
+
+// TenantStatus returns the status of a single tenant of a service
+func (d *Service) TenantStatus(service, tenant string) (TenantStatus, error) {
+	d.mu.RLock()
+	pending, ok := d.pending[service]
+	d.mu.RUnlock()
+
+	if !ok {
+		return TenantStatus{}, ErrServiceNotRegistered
+	}
+	return pending.Tenant(tenant), nil
+}
+
 func (d *Service) Stop() {
 	d.cancel()
 
+	d.closeMu.Lock()
+	defer d.closeMu.Unlock()
+
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 
@@ -116,10 +598,15 @@ func (d *Service) Stop() {
 	}
 }
 
-func RegisterLifecycle(lc fx.Lifecycle, d *Service) {
+func RegisterLifecycle(lc fx.Lifecycle, d *Service, state StateStore) {
+	d.SetStateStore(state)
+
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
 			fmt.Println("starting dispatcher...")
+			if err := d.RestoreState(ctx); err != nil {
+				log.Printf("⚠️ could not restore dispatcher state: %v\n", err)
+			}
 			d.Start()
 			return nil
 		},
@@ -131,7 +618,65 @@ func RegisterLifecycle(lc fx.Lifecycle, d *Service) {
 	})
 }
 
-func RegisterServices(d *Service) {
-	// Example services
-	d.Register("email", 5, 100)
+// RegisterReload applies worker count, rate limit and tenant limit changes from config reloads
+func RegisterReload(w *config.Watcher, d *Service) {
+	w.OnChange(func(old, new *config.Config) {
+		previous := map[string]config.ServiceCfg{}
+		for _, svc := range old.Dispatcher.Services {
+			previous[svc.Name] = svc
+		}
+
+		for _, svc := range new.Dispatcher.Services {
+			prev := previous[svc.Name]
+			if prev.Workers != svc.Workers {
+				if err := d.Resize(svc.Name, svc.Workers); err != nil {
+					log.Printf("⚠️ resize service %q: %v\n", svc.Name, err)
+					continue
+				}
+				log.Printf("✅ Service %q resized to %d workers\n", svc.Name, svc.Workers)
+			}
+			if prev.RateLimit != svc.RateLimit || prev.RateBurst != svc.RateBurst {
+				if err := d.SetRateLimit(svc.Name, svc.RateLimit, svc.RateBurst); err != nil {
+					log.Printf("⚠️ rate limit service %q: %v\n", svc.Name, err)
+					continue
+				}
+				log.Printf("✅ Service %q rate limit set to %v/s\n", svc.Name, svc.RateLimit)
+			}
+			if prev.TenantMaxQueued != svc.TenantMaxQueued || prev.TenantMaxRunning != svc.TenantMaxRunning {
+				limits := TenantLimits{MaxQueued: svc.TenantMaxQueued, MaxRunning: svc.TenantMaxRunning}
+				if err := d.SetTenantLimits(svc.Name, "", limits); err != nil {
+					log.Printf("⚠️ tenant limits service %q: %v\n", svc.Name, err)
+					continue
+				}
+				log.Printf("✅ Service %q tenant limits set to %+v\n", svc.Name, limits)
+			}
+		}
+	})
+}
+
+// RegisterServices registers the services declared in cfg.Dispatcher
+func RegisterServices(d *Service, cfg *config.Config, redisOverflow *RedisOverflowStore) {
+	for _, svc := range cfg.Dispatcher.Services {
+		opts := []Option{
+			WithOverflow(OverflowPolicy(svc.Overflow)),
+			WithBlockTimeout(svc.BlockTimeout),
+			WithTimeout(svc.Timeout),
+			WithRetry(svc.Retry.MaxAttempts, svc.Retry.Backoff),
+			WithRateLimit(svc.RateLimit, svc.RateBurst),
+			WithTenantLimits(TenantLimits{MaxQueued: svc.TenantMaxQueued, MaxRunning: svc.TenantMaxRunning}),
+		}
+		if svc.RejectWhenPaused {
+			opts = append(opts, WithRejectWhenPaused())
+		}
+		if svc.Overflow == string(OverflowSpill) {
+			var store OverflowStore = NewMemoryOverflowStore()
+			if svc.Backend == "redis" {
+				store = redisOverflow
+			}
+			opts = append(opts, WithSpill(store))
+		}
+
+		d.Register(svc.Name, svc.Workers, svc.QueueSize, opts...)
+		log.Printf("✅ Registered dispatcher service %q: %+v\n", svc.Name, svc)
+	}
 }
@@ -126,3 +126,478 @@ func TestDispatcher_Stop(t *testing.T) {
 		t.Fatal("expected error or panic prevention after Stop")
 	}
 }
+
+type quickJob struct {
+	service string
+	done    chan struct{}
+}
+
+func newQuickJob(service string) *quickJob {
+	return &quickJob{
+		service: service,
+		done:    make(chan struct{}),
+	}
+}
+
+func (q *quickJob) ID() string {
+	return "quick-job"
+}
+
+func (q *quickJob) Service() string {
+	return q.service
+}
+
+func (q *quickJob) Execute(ctx context.Context) error {
+	close(q.done)
+	return nil
+}
+
+type memoryStateStore struct {
+	mu     sync.Mutex
+	paused map[string]bool
+}
+
+func (m *memoryStateStore) IsPaused(ctx context.Context, service string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.paused[service], nil
+}
+
+func (m *memoryStateStore) SetPaused(ctx context.Context, service string, paused bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.paused[service] = paused
+	return nil
+}
+
+func TestDispatcher_PauseHoldsJobsUntilResume(t *testing.T) {
+	d := New()
+
+	d.Register("email", 1, 1)
+	d.Start()
+	defer d.Stop()
+
+	if err := d.Pause(context.Background(), "email"); err != nil {
+		t.Fatalf("pause failed: %v", err)
+	}
+
+	job := newQuickJob("email")
+	if err := d.Dispatch(job); err != nil {
+		t.Fatalf("dispatch failed: %v", err)
+	}
+
+	select {
+	case <-job.done:
+		t.Fatal("job should not be executed while paused")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if err := d.Resume(context.Background(), "email"); err != nil {
+		t.Fatalf("resume failed: %v", err)
+	}
+
+	select {
+	case <-job.done:
+	case <-time.After(time.Second):
+		t.Fatal("job was not executed after resume")
+	}
+}
+
+func TestDispatcher_PauseRejectsWhenConfigured(t *testing.T) {
+	d := New()
+
+	d.Register("email", 1, 1, WithRejectWhenPaused())
+	d.Start()
+	defer d.Stop()
+
+	_ = d.Pause(context.Background(), "email")
+
+	if err := d.Dispatch(newQuickJob("email")); err != ErrServicePaused {
+		t.Fatalf("expected ErrServicePaused, got %v", err)
+	}
+}
+
+func TestDispatcher_PauseUnknownService(t *testing.T) {
+	d := New()
+
+	if err := d.Pause(context.Background(), "sms"); err != ErrServiceNotRegistered {
+		t.Fatalf("expected ErrServiceNotRegistered, got %v", err)
+	}
+}
+
+func TestDispatcher_DrainRunsQueueAndPauses(t *testing.T) {
+	d := New()
+
+	d.Register("email", 1, 2)
+	d.Start()
+	defer d.Stop()
+
+	_ = d.Pause(context.Background(), "email")
+	job1 := newQuickJob("email")
+	job2 := newQuickJob("email")
+	_ = d.Dispatch(job1)
+	_ = d.Dispatch(job2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := d.Drain(ctx, "email"); err != nil {
+		t.Fatalf("drain failed: %v", err)
+	}
+
+	<-job1.done
+	<-job2.done
+
+	status, _ := d.Status("email")
+	if !status.Paused || status.Draining || status.QueueLength != 0 {
+		t.Fatalf("unexpected status after drain: %+v", status)
+	}
+}
+
+func TestDispatcher_DrainRejectsNewJobs(t *testing.T) {
+	d := New()
+
+	d.Register("email", 1, 1)
+	d.Start()
+	defer d.Stop()
+
+	job := newMockJob("email")
+	_ = d.Dispatch(job)
+
+	go func() {
+		_ = d.Drain(context.Background(), "email")
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	if err := d.Dispatch(newQuickJob("email")); err != ErrServiceDraining {
+		t.Fatalf("expected ErrServiceDraining, got %v", err)
+	}
+	if err := d.Pause(context.Background(), "email"); err != ErrServiceDraining {
+		t.Fatalf("expected pausing a draining service to fail, got %v", err)
+	}
+}
+
+func TestDispatcher_RestoreState(t *testing.T) {
+	state := &memoryStateStore{paused: map[string]bool{}}
+
+	d := New()
+	d.Register("email", 1, 1)
+	d.SetStateStore(state)
+	_ = d.Pause(context.Background(), "email")
+
+	restarted := New()
+	restarted.Register("email", 1, 1)
+	restarted.SetStateStore(state)
+	if err := restarted.RestoreState(context.Background()); err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+
+	status, _ := restarted.Status("email")
+	if !status.Paused {
+		t.Fatal("expected service to be paused after restore")
+	}
+}
+
+func TestDispatcher_Overflow_BlockTimeout(t *testing.T) {
+	d := New()
+
+	d.Register("email", 1, 1, WithBlockTimeout(100*time.Millisecond))
+	d.Start()
+	defer d.Stop()
+
+	_ = d.Pause(context.Background(), "email")
+	_ = d.Dispatch(newQuickJob("email"))
+
+	start := time.Now()
+	if err := d.Dispatch(newQuickJob("email")); err != ErrQueueFull {
+		t.Fatalf("expected ErrQueueFull, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("expected dispatch to block for the timeout, returned after %v", elapsed)
+	}
+}
+
+func TestDispatcher_Overflow_Reject(t *testing.T) {
+	d := New()
+
+	d.Register("email", 1, 1, WithOverflow(OverflowReject))
+	d.Start()
+	defer d.Stop()
+
+	_ = d.Pause(context.Background(), "email")
+	_ = d.Dispatch(newQuickJob("email"))
+
+	if err := d.Dispatch(newQuickJob("email")); err != ErrQueueFull {
+		t.Fatalf("expected ErrQueueFull, got %v", err)
+	}
+}
+
+func TestDispatcher_Overflow_DropOldest(t *testing.T) {
+	d := New()
+
+	d.Register("email", 1, 1, WithOverflow(OverflowDropOldest))
+	d.Start()
+	defer d.Stop()
+
+	_ = d.Pause(context.Background(), "email")
+	oldest := newQuickJob("email")
+	newest := newQuickJob("email")
+	_ = d.Dispatch(oldest)
+	if err := d.Dispatch(newest); err != nil {
+		t.Fatalf("dispatch failed: %v", err)
+	}
+	_ = d.Resume(context.Background(), "email")
+
+	select {
+	case <-newest.done:
+	case <-time.After(time.Second):
+		t.Fatal("newest job was not executed")
+	}
+
+	select {
+	case <-oldest.done:
+		t.Fatal("oldest job should have been dropped")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestDispatcher_Overflow_Spill(t *testing.T) {
+	store := NewMemoryOverflowStore()
+
+	d := New()
+	d.Register("email", 1, 1, WithSpill(store))
+	d.Start()
+	defer d.Stop()
+
+	_ = d.Pause(context.Background(), "email")
+	jobs := []*quickJob{newQuickJob("email"), newQuickJob("email"), newQuickJob("email")}
+	for _, j := range jobs {
+		if err := d.Dispatch(j); err != nil {
+			t.Fatalf("dispatch failed: %v", err)
+		}
+	}
+
+	if n, _ := store.Len(context.Background(), "email"); n != 2 {
+		t.Fatalf("expected 2 spilled jobs, got %d", n)
+	}
+
+	_ = d.Resume(context.Background(), "email")
+
+	for i, j := range jobs {
+		select {
+		case <-j.done:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("job %d was not executed after spill", i)
+		}
+	}
+}
+
+func TestDispatcher_Resize(t *testing.T) {
+	d := New()
+
+	d.Register("email", 1, 10)
+	d.Start()
+	defer d.Stop()
+
+	if err := d.Resize("email", 3); err != nil {
+		t.Fatalf("resize failed: %v", err)
+	}
+	if status, _ := d.Status("email"); status.Workers != 3 {
+		t.Fatalf("expected 3 workers, got %d", status.Workers)
+	}
+
+	_ = d.Resize("email", 1)
+	if status, _ := d.Status("email"); status.Workers != 1 {
+		t.Fatalf("expected 1 worker, got %d", status.Workers)
+	}
+
+	job := newQuickJob("email")
+	_ = d.Dispatch(job)
+
+	select {
+	case <-job.done:
+	case <-time.After(time.Second):
+		t.Fatal("job was not executed after resize")
+	}
+}
+
+func TestDispatcher_SetRateLimit(t *testing.T) {
+	d := New()
+
+	d.Register("email", 1, 10)
+	d.Start()
+	defer d.Stop()
+
+	if err := d.SetRateLimit("email", 5, 1); err != nil {
+		t.Fatalf("set rate limit failed: %v", err)
+	}
+
+	start := time.Now()
+	jobs := []*quickJob{newQuickJob("email"), newQuickJob("email"), newQuickJob("email")}
+	for _, j := range jobs {
+		_ = d.Dispatch(j)
+	}
+	for _, j := range jobs {
+		<-j.done
+	}
+
+	// 3 jobs at 5/s with a burst of 1 need at least 400ms
+	if elapsed := time.Since(start); elapsed < 350*time.Millisecond {
+		t.Fatalf("expected rate limited execution, took %v", elapsed)
+	}
+}
+
+type tenantJob struct {
+	tenant  string
+	service string
+	run     func(tenant string)
+	done    chan struct{}
+}
+
+func newTenantJob(service, tenant string, run func(tenant string)) *tenantJob {
+	return &tenantJob{
+		tenant:  tenant,
+		service: service,
+		run:     run,
+		done:    make(chan struct{}),
+	}
+}
+
+func (j *tenantJob) ID() string {
+	return "tenant-job"
+}
+
+func (j *tenantJob) Service() string {
+	return j.service
+}
+
+func (j *tenantJob) Tenant() string {
+	return j.tenant
+}
+
+func (j *tenantJob) Execute(ctx context.Context) error {
+	if j.run != nil {
+		j.run(j.tenant)
+	}
+	close(j.done)
+	return nil
+}
+
+func TestDispatcher_TenantsAreServedRoundRobin(t *testing.T) {
+	d := New()
+
+	d.Register("email", 1, 10)
+	d.Start()
+	defer d.Stop()
+
+	_ = d.Pause(context.Background(), "email")
+
+	var mu sync.Mutex
+	var order []string
+	record := func(tenant string) {
+		mu.Lock()
+		order = append(order, tenant)
+		mu.Unlock()
+	}
+
+	jobs := []*tenantJob{
+		newTenantJob("email", "a", record),
+		newTenantJob("email", "a", record),
+		newTenantJob("email", "a", record),
+		newTenantJob("email", "b", record),
+	}
+	for _, j := range jobs {
+		if err := d.Dispatch(j); err != nil {
+			t.Fatalf("dispatch failed: %v", err)
+		}
+	}
+	_ = d.Resume(context.Background(), "email")
+
+	for _, j := range jobs {
+		select {
+		case <-j.done:
+		case <-time.After(time.Second):
+			t.Fatal("job was not executed")
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if fmt.Sprint(order) != "[a b a a]" {
+		t.Fatalf("expected tenants to alternate, got %v", order)
+	}
+}
+
+func TestDispatcher_TenantQuota(t *testing.T) {
+	d := New()
+
+	d.Register("email", 1, 10, WithTenantLimits(TenantLimits{MaxQueued: 1}))
+	d.Start()
+	defer d.Stop()
+
+	_ = d.Pause(context.Background(), "email")
+
+	if err := d.Dispatch(newTenantJob("email", "a", nil)); err != nil {
+		t.Fatalf("dispatch failed: %v", err)
+	}
+	if err := d.Dispatch(newTenantJob("email", "a", nil)); err != ErrTenantQuotaExceeded {
+		t.Fatalf("expected ErrTenantQuotaExceeded, got %v", err)
+	}
+	if err := d.Dispatch(newTenantJob("email", "b", nil)); err != nil {
+		t.Fatalf("other tenant should not be limited, got %v", err)
+	}
+
+	tenants, err := d.Tenants("email")
+	if err != nil {
+		t.Fatalf("tenants failed: %v", err)
+	}
+	if len(tenants) != 2 || tenants[0].Tenant != "a" || tenants[0].Queued != 1 || tenants[0].Limits.MaxQueued != 1 {
+		t.Fatalf("unexpected tenants: %+v", tenants)
+	}
+}
+
+func TestDispatcher_TenantMaxRunning(t *testing.T) {
+	d := New()
+
+	d.Register("email", 3, 10, WithTenantLimits(TenantLimits{MaxRunning: 1}))
+	d.Start()
+	defer d.Stop()
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	slow := func(tenant string) {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+
+		time.Sleep(50 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+	}
+
+	jobs := []*tenantJob{
+		newTenantJob("email", "a", slow),
+		newTenantJob("email", "a", slow),
+		newTenantJob("email", "a", slow),
+	}
+	for _, j := range jobs {
+		_ = d.Dispatch(j)
+	}
+	for _, j := range jobs {
+		select {
+		case <-j.done:
+		case <-time.After(time.Second):
+			t.Fatal("job was not executed")
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if maxRunning != 1 {
+		t.Fatalf("expected at most 1 running job of the tenant, got %d", maxRunning)
+	}
+}
@@ -0,0 +1,90 @@
+package dispatcher
+
+import (
+	"go-worker/internal/poller/worker"
+	"time"
+)
+
+// Option configures a service at registration time
+type Option func(*options)
+
+type options struct {
+	rejectWhenPaused bool
+
+	overflow      OverflowPolicy
+	blockTimeout  time.Duration
+	overflowStore OverflowStore
+
+	policy worker.Policy
+
+	tenantLimits TenantLimits
+}
+
+// WithRejectWhenPaused makes Dispatch return ErrServicePaused while the
+// service is paused instead of queueing the job until it is resumed.
+func WithRejectWhenPaused() Option {
+	return func(o *options) {
+		o.rejectWhenPaused = true
+	}
+}
+
+// WithOverflow sets what Dispatch does when the service queue is full, default is OverflowBlock
+func WithOverflow(policy OverflowPolicy) Option {
+	return func(o *options) {
+		o.overflow = policy
+	}
+}
+
+// WithBlockTimeout limits how long OverflowBlock waits before returning ErrQueueFull,
+// zero waits until there is room or the dispatcher stops.
+func WithBlockTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.blockTimeout = timeout
+	}
+}
+
+// WithSpill uses OverflowSpill with store as the overflow store
+func WithSpill(store OverflowStore) Option {
+	return func(o *options) {
+		o.overflow = OverflowSpill
+		o.overflowStore = store
+	}
+}
+
+// WithTimeout bounds every attempt of a job, zero means no timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.policy.Timeout = timeout
+	}
+}
+
+// WithRetry tries a failing job up to maxAttempts times, waiting backoff
+// before the first retry and doubling it after every attempt
+func WithRetry(maxAttempts int, backoff time.Duration) Option {
+	return func(o *options) {
+		o.policy.MaxAttempts = maxAttempts
+		o.policy.Backoff = backoff
+	}
+}
+
+// WithRateLimit limits the service to perSecond jobs across all its workers
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(o *options) {
+		o.policy.Limiter = worker.NewLimiter(perSecond, burst)
+	}
+}
+
+// WithTenantLimits bounds the queued and running jobs of every tenant of the service
+func WithTenantLimits(limits TenantLimits) Option {
+	return func(o *options) {
+		o.tenantLimits = limits
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{overflow: OverflowBlock}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,210 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-worker/internal/poller/job"
+	"go-worker/internal/storage/cache"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// OverflowPolicy decides what Dispatch does when a service queue is full
+type OverflowPolicy string
+
+const (
+	// OverflowBlock waits for room in the queue, up to the block timeout if one is set
+	OverflowBlock OverflowPolicy = "block"
+	// OverflowReject returns ErrQueueFull right away
+	OverflowReject OverflowPolicy = "reject"
+	// OverflowDropOldest discards the oldest queued job to make room
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+	// OverflowSpill moves the job to an OverflowStore and feeds it back when there is room
+	OverflowSpill OverflowPolicy = "spill"
+)
+
+var ErrQueueFull = errors.New("queue full")
+
+// OverflowStore keeps jobs that did not fit into a service queue
+type OverflowStore interface {
+	Push(ctx context.Context, service string, j job.Job) error
+	// Pop takes the oldest job stored for service, it is kept until it is
+	// passed to Ack or Requeue. Pop returns nil without an error when there is
+	// nothing stored for service
+	Pop(ctx context.Context, service string) (job.Job, error)
+	// Ack forgets a popped job once it is back in the service queue
+	Ack(ctx context.Context, service string, j job.Job) error
+	// Requeue puts a popped job back in front of the stored jobs
+	Requeue(ctx context.Context, service string, j job.Job) error
+	// Recover puts back in front the jobs popped by a process that stopped
+	// before it acked them
+	Recover(ctx context.Context, service string) (int, error)
+	Len(ctx context.Context, service string) (int64, error)
+}
+
+// MemoryOverflowStore keeps spilled jobs in process, they are lost on restart
+type MemoryOverflowStore struct {
+	mu   sync.Mutex
+	jobs map[string][]job.Job
+}
+
+func NewMemoryOverflowStore() *MemoryOverflowStore {
+	return &MemoryOverflowStore{jobs: make(map[string][]job.Job)}
+}
+
+func (s *MemoryOverflowStore) Push(ctx context.Context, service string, j job.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[service] = append(s.jobs[service], j)
+	return nil
+}
+
+func (s *MemoryOverflowStore) Pop(ctx context.Context, service string) (job.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := s.jobs[service]
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	s.jobs[service] = jobs[1:]
+	return jobs[0], nil
+}
+
+func (s *MemoryOverflowStore) Ack(ctx context.Context, service string, j job.Job) error {
+	return nil
+}
+
+func (s *MemoryOverflowStore) Requeue(ctx context.Context, service string, j job.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[service] = append([]job.Job{j}, s.jobs[service]...)
+	return nil
+}
+
+// Recover has nothing to do, popped jobs do not outlive the process
+func (s *MemoryOverflowStore) Recover(ctx context.Context, service string) (int, error) {
+	return 0, nil
+}
+
+func (s *MemoryOverflowStore) Len(ctx context.Context, service string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return int64(len(s.jobs[service])), nil
+}
+
+// RedisOverflowStore keeps spilled jobs in a Redis list per service so they
+// survive restarts. Only job.Encodable jobs of services with a decoder can be stored.
+// A popped job is moved to a processing list until it is acked, so a job that
+// cannot be decoded, or that a crash interrupts, is not lost.
+type RedisOverflowStore struct {
+	store *cache.Store
+
+	mu       sync.RWMutex
+	decoders map[string]job.Decoder
+	popped   map[string]string // stored entries of popped jobs, by service and job id
+}
+
+func NewRedisOverflowStore(store *cache.Store) *RedisOverflowStore {
+	return &RedisOverflowStore{
+		store:    store,
+		decoders: make(map[string]job.Decoder),
+		popped:   make(map[string]string),
+	}
+}
+
+// Decode sets how stored jobs of a service are turned back into jobs
+func (s *RedisOverflowStore) Decode(service string, dec job.Decoder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.decoders[service] = dec
+}
+
+func (s *RedisOverflowStore) Push(ctx context.Context, service string, j job.Job) error {
+	s.mu.RLock()
+	_, ok := s.decoders[service]
+	s.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("no decoder for service %q", service)
+	}
+	encodable, isEncodable := j.(job.Encodable)
+	if !isEncodable {
+		return fmt.Errorf("spill job id=%s service=%s: %w", j.ID(), service, job.ErrNotEncodable)
+	}
+	data, err := encodable.Encode()
+	if err != nil {
+		return err
+	}
+	return s.store.Push(ctx, s.store.KeyOverflowQueue(service), data)
+}
+
+func (s *RedisOverflowStore) Pop(ctx context.Context, service string) (job.Job, error) {
+	s.mu.RLock()
+	dec, ok := s.decoders[service]
+	s.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no decoder for service %q", service)
+	}
+
+	processing := s.store.KeyOverflowProcessing(service)
+	var data []byte
+	entry, err := s.store.Move(ctx, s.store.KeyOverflowQueue(service), processing, &data)
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		if entry != "" {
+			return nil, fmt.Errorf("spilled job of service %s left in %s: %w", service, processing, err)
+		}
+		return nil, err
+	}
+	j, err := dec(data)
+	if err != nil {
+		return nil, fmt.Errorf("spilled job of service %s left in %s: %w", service, processing, err)
+	}
+
+	s.mu.Lock()
+	s.popped[service+"/"+j.ID()] = entry
+	s.mu.Unlock()
+	return j, nil
+}
+
+func (s *RedisOverflowStore) Ack(ctx context.Context, service string, j job.Job) error {
+	entry, ok := s.take(service, j)
+	if !ok {
+		return nil
+	}
+	return s.store.Remove(ctx, s.store.KeyOverflowProcessing(service), entry)
+}
+
+func (s *RedisOverflowStore) Requeue(ctx context.Context, service string, j job.Job) error {
+	entry, ok := s.take(service, j)
+	if !ok {
+		return fmt.Errorf("job id=%s service=%s was not popped", j.ID(), service)
+	}
+	return s.store.MoveBack(ctx, s.store.KeyOverflowProcessing(service), s.store.KeyOverflowQueue(service), entry)
+}
+
+// Recover also puts back the jobs another replica is moving at that moment,
+// they can then run twice
+func (s *RedisOverflowStore) Recover(ctx context.Context, service string) (int, error) {
+	return s.store.MoveAllBack(ctx, s.store.KeyOverflowProcessing(service), s.store.KeyOverflowQueue(service))
+}
+
+// take returns and forgets the stored entry of a popped job
+func (s *RedisOverflowStore) take(service string, j job.Job) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := service + "/" + j.ID()
+	entry, ok := s.popped[key]
+	delete(s.popped, key)
+	return entry, ok
+}
+
+func (s *RedisOverflowStore) Len(ctx context.Context, service string) (int64, error) {
+	return s.store.Len(ctx, s.store.KeyOverflowQueue(service))
+}
@@ -0,0 +1,32 @@
+package dispatcher
+
+import (
+	"context"
+	"go-worker/internal/storage/cache"
+)
+
+// StateStore persists the paused state of services so it survives restarts
+type StateStore interface {
+	IsPaused(ctx context.Context, service string) (bool, error)
+	SetPaused(ctx context.Context, service string, paused bool) error
+}
+
+type redisStateStore struct {
+	store *cache.Store
+}
+
+func NewRedisStateStore(store *cache.Store) StateStore {
+	return &redisStateStore{store: store}
+}
+
+func (s *redisStateStore) IsPaused(ctx context.Context, service string) (bool, error) {
+	return s.store.Exists(ctx, s.store.KeyServicePaused(service))
+}
+
+func (s *redisStateStore) SetPaused(ctx context.Context, service string, paused bool) error {
+	if !paused {
+		return s.store.Delete(ctx, s.store.KeyServicePaused(service))
+	}
+	// ttl 0 keeps the key until the service is resumed
+	return s.store.Set(ctx, s.store.KeyServicePaused(service), true, 0)
+}
@@ -0,0 +1,301 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"go-worker/internal/poller/job"
+	"sort"
+	"sync"
+)
+
+var ErrTenantQuotaExceeded = errors.New("tenant quota exceeded")
+
+// TenantLimits bounds what a single tenant can use of a service, zero means unlimited
+type TenantLimits struct {
+	// MaxQueued is how many jobs of the tenant can wait in the service queue
+	MaxQueued int
+	// MaxRunning is how many jobs of the tenant the service runs at the same time
+	MaxRunning int
+}
+
+// TenantStatus is a point-in-time view of a tenant within a service
+type TenantStatus struct {
+	Tenant  string
+	Queued  int
+	Running int
+	Limits  TenantLimits
+}
+
+// fairQueue is the queue of a service. It keeps one FIFO per tenant and
+// hands jobs out round robin across tenants, skipping tenants that reached
+// their MaxRunning, so a busy tenant cannot starve the others.
+type fairQueue struct {
+	mu       sync.Mutex
+	capacity int
+	size     int
+
+	queued  map[string][]job.Job
+	running map[string]int
+	// ring holds the tenants with queued jobs in round robin order, next is served first
+	ring []string
+	next int
+
+	defaults  TenantLimits
+	overrides map[string]TenantLimits
+
+	// changed is closed and replaced whenever a job is queued, taken or finished
+	changed chan struct{}
+}
+
+func newFairQueue(capacity int, defaults TenantLimits) *fairQueue {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &fairQueue{
+		capacity:  capacity,
+		queued:    make(map[string][]job.Job),
+		running:   make(map[string]int),
+		defaults:  defaults,
+		overrides: make(map[string]TenantLimits),
+		changed:   make(chan struct{}),
+	}
+}
+
+// Changed returns a channel that is closed on the next change of the queue
+func (q *fairQueue) Changed() <-chan struct{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.changed
+}
+
+// Push queues j, it returns ErrQueueFull when the queue is full and
+// ErrTenantQuotaExceeded when the tenant of j reached its MaxQueued
+func (q *fairQueue) Push(j job.Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	tenant := job.TenantOf(j)
+	if max := q.limits(tenant).MaxQueued; max > 0 && len(q.queued[tenant]) >= max {
+		return ErrTenantQuotaExceeded
+	}
+	if q.size >= q.capacity {
+		return ErrQueueFull
+	}
+
+	if len(q.queued[tenant]) == 0 {
+		q.ring = append(q.ring, tenant)
+	}
+	q.queued[tenant] = append(q.queued[tenant], j)
+	q.size++
+	q.signal()
+	return nil
+}
+
+// Next blocks until a job can run and marks it as running
+func (q *fairQueue) Next(ctx context.Context) (job.Job, error) {
+	for {
+		q.mu.Lock()
+		j := q.take()
+		changed := q.changed
+		q.mu.Unlock()
+
+		if j != nil {
+			return j, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// take must be called with q.mu held, it returns nil when no tenant can run a job
+func (q *fairQueue) take() job.Job {
+	for i := 0; i < len(q.ring); i++ {
+		idx := (q.next + i) % len(q.ring)
+		tenant := q.ring[idx]
+		if max := q.limits(tenant).MaxRunning; max > 0 && q.running[tenant] >= max {
+			continue
+		}
+
+		jobs := q.queued[tenant]
+		j := jobs[0]
+		if len(jobs) == 1 {
+			delete(q.queued, tenant)
+			q.ring = append(q.ring[:idx], q.ring[idx+1:]...)
+			q.next = idx
+		} else {
+			q.queued[tenant] = jobs[1:]
+			q.next = idx + 1
+		}
+		if len(q.ring) > 0 {
+			q.next %= len(q.ring)
+		} else {
+			q.next = 0
+		}
+
+		q.size--
+		q.running[tenant]++
+		q.signal()
+		return j
+	}
+	return nil
+}
+
+// PutBack returns a job taken by Next that was never run to the front of its tenant queue
+func (q *fairQueue) PutBack(j job.Job) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	tenant := job.TenantOf(j)
+	if len(q.queued[tenant]) == 0 {
+		q.ring = append(q.ring, tenant)
+	}
+	q.queued[tenant] = append([]job.Job{j}, q.queued[tenant]...)
+	q.size++
+	q.finish(tenant)
+}
+
+// Done marks a job taken by Next as finished
+func (q *fairQueue) Done(j job.Job) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.finish(job.TenantOf(j))
+}
+
+func (q *fairQueue) finish(tenant string) {
+	if q.running[tenant] <= 1 {
+		delete(q.running, tenant)
+	} else {
+		q.running[tenant]--
+	}
+	q.signal()
+}
+
+// DropOldest removes the oldest job of the tenant with the most queued jobs,
+// it returns nil when the queue is empty
+func (q *fairQueue) DropOldest() job.Job {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	idx := -1
+	for i, tenant := range q.ring {
+		if idx < 0 || len(q.queued[tenant]) > len(q.queued[q.ring[idx]]) {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return nil
+	}
+
+	tenant := q.ring[idx]
+	jobs := q.queued[tenant]
+	if len(jobs) == 1 {
+		delete(q.queued, tenant)
+		q.ring = append(q.ring[:idx], q.ring[idx+1:]...)
+		if idx < q.next {
+			q.next--
+		}
+		if q.next >= len(q.ring) {
+			q.next = 0
+		}
+	} else {
+		q.queued[tenant] = jobs[1:]
+	}
+	q.size--
+	q.signal()
+	return jobs[0]
+}
+
+// Len returns how many jobs are queued
+func (q *fairQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+func (q *fairQueue) Cap() int {
+	return q.capacity
+}
+
+// Running returns how many jobs taken by Next are not done yet
+func (q *fairQueue) Running() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	n := 0
+	for _, running := range q.running {
+		n += running
+	}
+	return n
+}
+
+// SetLimits overrides the limits of a single tenant, an empty tenant changes the defaults
+func (q *fairQueue) SetLimits(tenant string, limits TenantLimits) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if tenant == "" {
+		q.defaults = limits
+	} else {
+		q.overrides[tenant] = limits
+	}
+	q.signal()
+}
+
+// limits must be called with q.mu held
+func (q *fairQueue) limits(tenant string) TenantLimits {
+	if limits, ok := q.overrides[tenant]; ok {
+		return limits
+	}
+	return q.defaults
+}
+
+// Tenants returns every tenant with queued or running jobs or its own limits, ordered by name
+func (q *fairQueue) Tenants() []TenantStatus {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	seen := map[string]bool{}
+	for tenant := range q.queued {
+		seen[tenant] = true
+	}
+	for tenant := range q.running {
+		seen[tenant] = true
+	}
+	for tenant := range q.overrides {
+		seen[tenant] = true
+	}
+
+	statuses := make([]TenantStatus, 0, len(seen))
+	for tenant := range seen {
+		statuses = append(statuses, q.tenant(tenant))
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Tenant < statuses[j].Tenant
+	})
+	return statuses
+}
+
+// Tenant returns the status of a single tenant, idle tenants have zero counts
+func (q *fairQueue) Tenant(tenant string) TenantStatus {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.tenant(tenant)
+}
+
+func (q *fairQueue) tenant(tenant string) TenantStatus {
+	return TenantStatus{
+		Tenant:  tenant,
+		Queued:  len(q.queued[tenant]),
+		Running: q.running[tenant],
+		Limits:  q.limits(tenant),
+	}
+}
+
+// signal must be called with q.mu held
+func (q *fairQueue) signal() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
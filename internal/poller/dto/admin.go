@@ -0,0 +1,23 @@
+package dto
+
+type ServiceStatusResponse struct {
+	Name          string `json:"name"`
+	Workers       int    `json:"workers"`
+	QueueLength   int    `json:"queue_length"`
+	QueueCapacity int    `json:"queue_capacity"`
+	Busy          int    `json:"busy"`
+	Paused        bool   `json:"paused"`
+	Draining      bool   `json:"draining"`
+}
+
+type ListServicesResponse []ServiceStatusResponse
+
+type TenantStatusResponse struct {
+	Tenant     string `json:"tenant"`
+	Queued     int    `json:"queued"`
+	Running    int    `json:"running"`
+	MaxQueued  int    `json:"max_queued"`
+	MaxRunning int    `json:"max_running"`
+}
+
+type ListTenantsResponse []TenantStatusResponse
@@ -0,0 +1,14 @@
+package job
+
+import "errors"
+
+var ErrNotEncodable = errors.New("job is not encodable")
+
+// Encodable jobs can be stored outside the process, e.g. in an overflow store
+type Encodable interface {
+	Job
+	Encode() ([]byte, error)
+}
+
+// Decoder rebuilds a job from the bytes returned by Encodable.Encode
+type Decoder func(data []byte) (Job, error)
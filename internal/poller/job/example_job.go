@@ -5,6 +5,7 @@ import "time"
 type BaseJob struct {
 	JobID       string
 	ServiceName string
+	TenantID    string
 	CreatedAt   time.Time
 	Payload     any
 }
@@ -16,3 +17,7 @@ func (j *BaseJob) ID() string {
 func (j *BaseJob) Service() string {
 	return j.ServiceName
 }
+
+func (j *BaseJob) Tenant() string {
+	return j.TenantID
+}
@@ -0,0 +1,17 @@
+package job
+
+// DefaultTenant owns every job that does not name a tenant
+const DefaultTenant = "default"
+
+// Tenanted jobs belong to a tenant, jobs of different tenants are scheduled fairly
+type Tenanted interface {
+	Tenant() string
+}
+
+// TenantOf returns the tenant of j, DefaultTenant when it has none
+func TenantOf(j Job) string {
+	if t, ok := j.(Tenanted); ok && t.Tenant() != "" {
+		return t.Tenant()
+	}
+	return DefaultTenant
+}
@@ -3,9 +3,12 @@ package poller
 import (
 	"context"
 	"fmt"
+	"go-worker/internal/config"
 	"go-worker/internal/example"
 	"go-worker/internal/poller/dispatcher"
+	"go-worker/internal/poller/job"
 	"log"
+	"sync"
 	"time"
 
 	"go.uber.org/fx"
@@ -28,6 +31,17 @@ type Poller struct {
 	ctx        context.Context
 	cancel     context.CancelFunc
 	OnTick     func(context.Context)
+
+	mu        sync.Mutex
+	interval  chan time.Duration // interval changes while Run is active
+	schedules []*schedule
+}
+
+// schedule dispatches a new job every interval
+type schedule struct {
+	every  time.Duration
+	next   time.Time
+	newJob func() job.Job
 }
 
 func New(
@@ -36,13 +50,16 @@ func New(
 	p := &Poller{
 		Dispatcher: dispatcher,
 		Interval:   500 * time.Microsecond,
+		interval:   make(chan time.Duration, 1),
 	}
 	p.OnTick = p.DefaultTick // default behavior
 	return p
 }
 
 func (p *Poller) Run(ctx context.Context) {
+	p.mu.Lock()
 	ticker := time.NewTicker(p.Interval)
+	p.mu.Unlock()
 	defer ticker.Stop()
 
 	for {
@@ -51,8 +68,56 @@ func (p *Poller) Run(ctx context.Context) {
 		case <-ctx.Done():
 			return
 
+		case d := <-p.interval:
+			ticker.Reset(d)
+
 		case <-ticker.C:
 			p.OnTick(ctx)
+			p.dispatchDue(time.Now())
+		}
+	}
+}
+
+// SetInterval changes the tick interval, a running poller picks it up on its next loop
+func (p *Poller) SetInterval(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.Interval = d
+	// keep only the latest change
+	select {
+	case <-p.interval:
+	default:
+	}
+	p.interval <- d
+}
+
+// Every dispatches a job made by newJob on the first tick and then once every d,
+// ticks keep their own interval so d is rounded up to the next tick
+func (p *Poller) Every(d time.Duration, newJob func() job.Job) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.schedules = append(p.schedules, &schedule{every: d, newJob: newJob})
+}
+
+// dispatchDue dispatches the jobs of the schedules that are due at now,
+// outside the lock since Dispatch blocks on a full queue
+func (p *Poller) dispatchDue(now time.Time) {
+	var due []*schedule
+	p.mu.Lock()
+	for _, s := range p.schedules {
+		if now.Before(s.next) {
+			continue
+		}
+		s.next = now.Add(s.every)
+		due = append(due, s)
+	}
+	p.mu.Unlock()
+
+	for _, s := range due {
+		j := s.newJob()
+		if err := p.Dispatcher.Dispatch(j); err != nil {
+			log.Printf("⚠️ scheduled job %s of service %q: %v\n", j.ID(), j.Service(), err)
 		}
 	}
 }
@@ -74,6 +139,24 @@ func (p *Poller) conditionMet(ctx context.Context) bool {
 	return true
 }
 
+// RegisterDecoders tells the redis overflow store how to rebuild the jobs the poller dispatches
+func RegisterDecoders(store *dispatcher.RedisOverflowStore) {
+	store.Decode("email", example.DecodeExampleJob)
+}
+
+// RegisterInterval sets the interval from POLLER_INTERVAL and keeps it in sync with config reloads
+func RegisterInterval(w *config.Watcher, p *Poller) {
+	if d := w.Current().PollerInterval; d > 0 {
+		p.SetInterval(d)
+	}
+	w.OnChange(func(old, new *config.Config) {
+		if new.PollerInterval > 0 && old.PollerInterval != new.PollerInterval {
+			p.SetInterval(new.PollerInterval)
+			log.Printf("✅ Poller interval set to %v\n", new.PollerInterval)
+		}
+	})
+}
+
 func RegisterLifecycle(lc fx.Lifecycle, p *Poller) {
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
@@ -5,6 +5,7 @@ import (
 	"go-worker/internal/example"
 	"go-worker/internal/poller"
 	"go-worker/internal/poller/dispatcher"
+	"go-worker/internal/poller/job"
 	"log"
 	"sync/atomic"
 	"testing"
@@ -81,3 +82,66 @@ func TestPollerIntegration_WithConditionalDispatching(t *testing.T) {
 		t.Fatalf("expected at least 3 ticks, got %d", count)
 	}
 }
+
+func TestPoller_EveryDispatchesOnSchedule(t *testing.T) {
+	disp := dispatcher.New()
+	disp.Register("sweep", 1, 50)
+	disp.Start()
+	defer disp.Stop()
+
+	var scheduled atomic.Int32
+	p := poller.New(disp)
+	p.Interval = 10 * time.Millisecond
+	p.OnTick = func(ctx context.Context) {}
+	p.Every(100*time.Millisecond, func() job.Job {
+		scheduled.Add(1)
+		return example.NewExampleJob("sweep-job", "sweep")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go p.Run(ctx)
+	time.Sleep(350 * time.Millisecond)
+	cancel()
+
+	// the first tick plus one every 100ms
+	if count := scheduled.Load(); count < 3 || count > 5 {
+		t.Fatalf("expected 3 to 5 scheduled jobs, got %d", count)
+	}
+}
+
+func TestPoller_SetIntervalWhileDispatchBlocks(t *testing.T) {
+	disp := dispatcher.New()
+	disp.Register("sweep", 1, 1,
+		dispatcher.WithOverflow(dispatcher.OverflowBlock),
+		dispatcher.WithBlockTimeout(2*time.Second),
+	)
+	disp.Start()
+	defer disp.Stop()
+	// nothing leaves the queue, so the second scheduled job blocks
+	if err := disp.Pause(context.Background(), "sweep"); err != nil {
+		t.Fatal(err)
+	}
+
+	p := poller.New(disp)
+	p.Interval = 10 * time.Millisecond
+	p.OnTick = func(ctx context.Context) {}
+	p.Every(time.Millisecond, func() job.Job {
+		return example.NewExampleJob("sweep-job", "sweep")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go p.Run(ctx)
+	time.Sleep(100 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		p.SetInterval(20 * time.Millisecond)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("SetInterval waited for a blocked dispatch")
+	}
+}
@@ -0,0 +1,97 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Gate holds workers between jobs without stopping them.
+// While the gate is paused no worker sharing it takes a job from its queue.
+type Gate struct {
+	mu     sync.Mutex
+	paused bool
+	opened chan struct{} // closed while the gate is open
+	closed chan struct{} // closed while the gate is paused
+
+	busy atomic.Int64
+}
+
+func NewGate() *Gate {
+	opened := make(chan struct{})
+	close(opened)
+
+	return &Gate{
+		opened: opened,
+		closed: make(chan struct{}),
+	}
+}
+
+// Pause stops workers from picking up new jobs, jobs already running finish normally
+func (g *Gate) Pause() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.paused {
+		return
+	}
+	g.paused = true
+	g.opened = make(chan struct{})
+	close(g.closed)
+}
+
+// Resume lets workers pick up jobs again
+func (g *Gate) Resume() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.paused {
+		return
+	}
+	g.paused = false
+	g.closed = make(chan struct{})
+	close(g.opened)
+}
+
+func (g *Gate) Paused() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.paused
+}
+
+// Busy returns the number of jobs currently executing behind the gate
+func (g *Gate) Busy() int {
+	return int(g.busy.Load())
+}
+
+// Wait blocks until the gate is open or ctx is done
+func (g *Gate) Wait(ctx context.Context) error {
+	select {
+	case <-g.Opened():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Opened returns a channel that is closed while the gate is open
+func (g *Gate) Opened() <-chan struct{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.opened
+}
+
+// Closed returns a channel that is closed while the gate is paused
+func (g *Gate) Closed() <-chan struct{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.closed
+}
+
+func (g *Gate) begin() {
+	g.busy.Add(1)
+}
+
+func (g *Gate) end() {
+	g.busy.Add(-1)
+}
@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"time"
+
+	"go-worker/internal/poller/job"
+
+	"golang.org/x/time/rate"
+)
+
+// Policy controls how a worker runs each job it picks up.
+// The zero value runs every job once with no timeout and no rate limit.
+type Policy struct {
+	// Timeout bounds a single attempt, zero means no timeout
+	Timeout time.Duration
+	// MaxAttempts is how many times a failing job is tried, values below 1 mean once
+	MaxAttempts int
+	// Backoff is the wait before the first retry, it doubles after every attempt
+	Backoff time.Duration
+	// Limiter is shared by all workers of a service, nil means no rate limit
+	Limiter *rate.Limiter
+	// OnDone is called after the last attempt of every job the worker picked up
+	OnDone func(j job.Job)
+	// Requeue is called instead of OnDone for a job the worker picked up but
+	// could not start, nil drops it
+	Requeue func(j job.Job)
+}
+
+// NewLimiter returns a limiter allowing perSecond jobs with the given burst,
+// a perSecond of 0 or less means unlimited
+func NewLimiter(perSecond float64, burst int) *rate.Limiter {
+	limiter := rate.NewLimiter(rate.Inf, 1)
+	SetLimit(limiter, perSecond, burst)
+	return limiter
+}
+
+// SetLimit changes a limiter made by NewLimiter while workers are using it
+func SetLimit(limiter *rate.Limiter, perSecond float64, burst int) {
+	if burst < 1 {
+		burst = 1
+	}
+	limit := rate.Inf
+	if perSecond > 0 {
+		limit = rate.Limit(perSecond)
+	}
+	limiter.SetLimit(limit)
+	limiter.SetBurst(burst)
+}
+
+func (p Policy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
@@ -3,6 +3,8 @@ package worker
 import (
 	"context"
 	"log"
+	"sync"
+	"time"
 
 	"go-worker/internal/poller/job"
 )
@@ -10,7 +12,11 @@ import (
 type SimpleWorker struct {
 	id       int
 	jobQueue <-chan job.Job
+	gate     *Gate
+	policy   Policy
 	cancel   context.CancelFunc
+	retire   chan struct{}
+	retired  sync.Once
 }
 
 func NewSimpleWorker(
@@ -20,6 +26,25 @@ func NewSimpleWorker(
 	return &SimpleWorker{
 		id:       id,
 		jobQueue: jobQueue,
+		gate:     NewGate(),
+		retire:   make(chan struct{}),
+	}
+}
+
+// NewGatedWorker creates a worker that only picks up jobs while gate is open
+// and runs them according to policy
+func NewGatedWorker(
+	id int,
+	jobQueue <-chan job.Job,
+	gate *Gate,
+	policy Policy,
+) *SimpleWorker {
+	return &SimpleWorker{
+		id:       id,
+		jobQueue: jobQueue,
+		gate:     gate,
+		policy:   policy,
+		retire:   make(chan struct{}),
 	}
 }
 
@@ -31,14 +56,39 @@ func (w *SimpleWorker) Start(ctx context.Context) {
 		log.Printf("[worker-%d] started\n", w.id)
 
 		for {
+			if err := w.gate.Wait(ctx); err != nil {
+				log.Printf("[worker-%d] stopped\n", w.id)
+				return
+			}
+
 			//blocker
 			select {
 			case <-ctx.Done():
 				log.Printf("[worker-%d] stopped\n", w.id)
 				return
 
-			case j := <-w.jobQueue:
-				w.handleJob(ctx, j)
+			case <-w.retire:
+				log.Printf("[worker-%d] retired\n", w.id)
+				return
+
+			case <-w.gate.Closed():
+				// paused while idle, wait for resume
+				continue
+
+			case j, ok := <-w.jobQueue:
+				if !ok {
+					log.Printf("[worker-%d] queue closed\n", w.id)
+					return
+				}
+				w.gate.begin()
+				if w.handleJob(ctx, j) {
+					if w.policy.OnDone != nil {
+						w.policy.OnDone(j)
+					}
+				} else if w.policy.Requeue != nil {
+					w.policy.Requeue(j)
+				}
+				w.gate.end()
 			}
 		}
 	}()
@@ -50,7 +100,27 @@ func (w *SimpleWorker) Stop() {
 	}
 }
 
-func (w *SimpleWorker) handleJob(ctx context.Context, j job.Job) {
+func (w *SimpleWorker) Retire() {
+	w.retired.Do(func() {
+		close(w.retire)
+	})
+}
+
+// handleJob runs j, it returns false when j was not started
+func (w *SimpleWorker) handleJob(ctx context.Context, j job.Job) bool {
+	if w.policy.Limiter != nil {
+		if err := w.policy.Limiter.Wait(ctx); err != nil {
+			log.Printf(
+				"[worker-%d] job not started id=%s service=%s error=%v\n",
+				w.id,
+				j.ID(),
+				j.Service(),
+				err,
+			)
+			return false
+		}
+	}
+
 	log.Printf(
 		"[worker-%d] executing job id=%s service=%s\n",
 		w.id,
@@ -58,12 +128,39 @@ func (w *SimpleWorker) handleJob(ctx context.Context, j job.Job) {
 		j.Service(),
 	)
 
-	if err := j.Execute(ctx); err != nil {
+	backoff := w.policy.Backoff
+	for attempt := 1; ; attempt++ {
+		err := w.execute(ctx, j)
+		if err == nil {
+			return true
+		}
+
 		log.Printf(
-			"[worker-%d] job failed id=%s error=%v\n",
+			"[worker-%d] job failed id=%s attempt=%d error=%v\n",
 			w.id,
 			j.ID(),
+			attempt,
 			err,
 		)
+		if attempt >= w.policy.attempts() {
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return true
+		case <-time.After(backoff):
+		}
+		backoff *= 2
 	}
 }
+
+func (w *SimpleWorker) execute(ctx context.Context, j job.Job) error {
+	if w.policy.Timeout <= 0 {
+		return j.Execute(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.policy.Timeout)
+	defer cancel()
+	return j.Execute(ctx)
+}
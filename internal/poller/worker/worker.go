@@ -5,4 +5,6 @@ import "context"
 type Worker interface {
 	Start(ctx context.Context)
 	Stop()
+	// Retire stops the worker once its current job is finished
+	Retire()
 }
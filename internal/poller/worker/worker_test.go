@@ -169,3 +169,87 @@ func TestSimpleWorker_JobErrorDoesNotStopWorker(t *testing.T) {
 	<-j1.done
 	<-j2.done // worker still alive
 }
+
+type flakyJob struct {
+	failures int
+
+	mu       sync.Mutex
+	attempts int
+	done     chan struct{}
+}
+
+func (f *flakyJob) ID() string {
+	return "flaky-job"
+}
+
+func (f *flakyJob) Service() string {
+	return "test-service"
+}
+
+func (f *flakyJob) Execute(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.attempts++
+	if f.attempts <= f.failures {
+		return errors.New("fail")
+	}
+	close(f.done)
+	return nil
+}
+
+func TestGatedWorker_RetriesFailingJob(t *testing.T) {
+	jobQueue := make(chan job.Job, 1)
+	policy := Policy{MaxAttempts: 3, Backoff: 10 * time.Millisecond}
+	worker := NewGatedWorker(1, jobQueue, NewGate(), policy)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	worker.Start(ctx)
+
+	j := &flakyJob{failures: 2, done: make(chan struct{})}
+	jobQueue <- j
+
+	select {
+	case <-j.done:
+	case <-time.After(time.Second):
+		t.Fatal("job did not succeed after retries")
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", j.attempts)
+	}
+}
+
+func TestGatedWorker_RequeuesJobNotStarted(t *testing.T) {
+	jobQueue := make(chan job.Job, 1)
+	limiter := NewLimiter(0.001, 1)
+	limiter.Allow() // the next job waits for a token
+
+	requeued := make(chan job.Job, 1)
+	policy := Policy{
+		Limiter: limiter,
+		OnDone:  func(j job.Job) { t.Errorf("job %s should not be done", j.ID()) },
+		Requeue: func(j job.Job) { requeued <- j },
+	}
+	worker := NewGatedWorker(1, jobQueue, NewGate(), policy)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	worker.Start(ctx)
+
+	j := newMockJob(1, 0)
+	jobQueue <- j
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case got := <-requeued:
+		if got != j {
+			t.Fatalf("expected job %s to be requeued, got %s", j.ID(), got.ID())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("job was not requeued")
+	}
+}
@@ -1,12 +1,19 @@
 package controller
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"go-worker/internal/config"
 	"go-worker/internal/http/response"
 	"go-worker/internal/middleware"
+	"go-worker/internal/poller/dispatcher"
 	"go-worker/internal/product/dto"
 	"go-worker/internal/product/service"
 
@@ -25,8 +32,22 @@ func (c *AdminProduct) RegisterRoutes(rg *gin.RouterGroup, cfg *config.Config) {
 	auth := middleware.JWTAuth(cfg)
 
 	rg.POST("/", auth, c.CreateProduct)
+	rg.POST("/import", auth, c.ImportProducts)
+	rg.GET("/import/:importID", auth, c.ImportStatus)
+	rg.GET("/export", auth, c.ExportProducts)
 	rg.PUT("/:id", auth, c.UpdateProduct)
+	rg.PATCH("/:id", auth, c.PatchProduct)
 	rg.DELETE("/:id", auth, c.DeleteProduct)
+	rg.POST("/:id/restore", auth, c.RestoreProduct)
+	rg.GET("/:id/history", auth, c.ProductHistory)
+	rg.GET("/:id/categories", auth, c.ProductCategories)
+	rg.PUT("/:id/categories", auth, c.SetProductCategories)
+	rg.GET("/:id/tags", auth, c.ProductTags)
+	rg.PUT("/:id/tags", auth, c.SetProductTags)
+	rg.GET("/:id/prices", auth, c.ProductPrices)
+	rg.POST("/:id/prices", auth, c.AddProductPrice)
+	rg.GET("/:id/prices/history", auth, c.PriceHistory)
+	rg.DELETE("/:id/prices/:priceID", auth, c.DeleteProductPrice)
 	rg.GET("/:id", auth, c.GetProductByID)
 	rg.GET("/", auth, c.ListProducts)
 }
@@ -38,7 +59,7 @@ func (c *AdminProduct) RegisterRoutes(rg *gin.RouterGroup, cfg *config.Config) {
 // @Accept json
 // @Produce json
 // @Param product body dto.AdminCreateProductRequest true "Product to create"
-// @Success 201 {object} dto.ProductResponse
+// @Success 201 {object} dto.AdminProductResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Security BearerAuth
@@ -49,9 +70,9 @@ func (c *AdminProduct) CreateProduct(ctx *gin.Context) {
 		response.JSONError(ctx, http.StatusBadRequest, err)
 		return
 	}
-	product, err := c.Service.Create(ctx, req)
+	product, err := c.Service.Create(ctx, middleware.AdminID(ctx), req)
 	if err != nil {
-		response.JSONError(ctx, http.StatusInternalServerError, err)
+		serviceError(ctx, err)
 		return
 	}
 	ctx.JSON(http.StatusCreated, product)
@@ -59,14 +80,19 @@ func (c *AdminProduct) CreateProduct(ctx *gin.Context) {
 
 // UpdateProduct godoc
 // @Summary Update an existing product
-// @Description Update product details by ID
+// @Description Update product details by ID, If-Match must carry the ETag of the product the change was made against
 // @Tags Admin Products
 // @Accept json
 // @Produce json
 // @Param id path int true "Product ID"
+// @Param If-Match header string true "ETag from GET, * updates unconditionally"
 // @Param product body dto.AdminUpdateProductRequest true "Updated product details"
-// @Success 200 {object} dto.ProductResponse
+// @Success 200 {object} dto.AdminProductResponse
+// @Header 200 {string} ETag "Version of the updated product"
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 412 {object} response.ErrorResponse
+// @Failure 428 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Security BearerAuth
 // @Router /api/v1/admin/products/{id} [put]
@@ -82,21 +108,93 @@ func (c *AdminProduct) UpdateProduct(ctx *gin.Context) {
 		return
 	}
 	req.ID = int32(id)
-	product, err := c.Service.Update(ctx, req)
+	req.Version, err = parseIfMatch(ctx.GetHeader("If-Match"))
+	if errors.Is(err, ErrIfMatchRequired) {
+		response.JSONError(ctx, http.StatusPreconditionRequired, err)
+		return
+	}
 	if err != nil {
-		response.JSONError(ctx, http.StatusInternalServerError, err)
+		response.JSONError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	product, err := c.Service.Update(ctx, middleware.AdminID(ctx), req)
+	if errors.Is(err, service.ErrVersionConflict) {
+		response.JSONError(ctx, http.StatusPreconditionFailed, err)
+		return
+	}
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.Header("ETag", etag(product.Version))
+	ctx.JSON(http.StatusOK, product)
+}
+
+// PatchProduct godoc
+// @Summary Partially update a product
+// @Description Change only the fields present in a JSON Merge Patch, If-Match must carry the ETag of the product the change was made against
+// @Tags Admin Products
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param If-Match header string true "ETag from GET, * updates unconditionally"
+// @Param product body dto.AdminPatchProductRequest true "Fields to change"
+// @Success 200 {object} dto.AdminProductResponse
+// @Header 200 {string} ETag "Version of the updated product"
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 412 {object} response.ErrorResponse
+// @Failure 428 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/products/{id} [patch]
+func (c *AdminProduct) PatchProduct(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, response.ErrInvalidID)
+		return
+	}
+	body, err := ctx.GetRawData()
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	var req dto.AdminPatchProductRequest
+	if err := decodeMergePatch(body, &req); err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.ID = int32(id)
+	req.Version, err = parseIfMatch(ctx.GetHeader("If-Match"))
+	if errors.Is(err, ErrIfMatchRequired) {
+		response.JSONError(ctx, http.StatusPreconditionRequired, err)
+		return
+	}
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	product, err := c.Service.Patch(ctx, middleware.AdminID(ctx), req)
+	if errors.Is(err, service.ErrVersionConflict) {
+		response.JSONError(ctx, http.StatusPreconditionFailed, err)
 		return
 	}
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.Header("ETag", etag(product.Version))
 	ctx.JSON(http.StatusOK, product)
 }
 
 // DeleteProduct godoc
 // @Summary Delete a product by ID
-// @Description Delete a product by its ID
+// @Description Soft delete a product by its ID, it can be brought back with restore
 // @Tags Admin Products
 // @Param id path int true "Product ID"
 // @Success 204 "No Content"
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Security BearerAuth
 // @Router /api/v1/admin/products/{id} [delete]
@@ -106,20 +204,438 @@ func (c *AdminProduct) DeleteProduct(ctx *gin.Context) {
 		response.JSONError(ctx, http.StatusBadRequest, response.ErrInvalidID)
 		return
 	}
-	if err := c.Service.Delete(ctx, int32(id)); err != nil {
-		response.JSONError(ctx, http.StatusInternalServerError, err)
+	if err := c.Service.Delete(ctx, middleware.AdminID(ctx), int32(id)); err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RestoreProduct godoc
+// @Summary Restore a deleted product
+// @Description Bring back a soft deleted product by its ID
+// @Tags Admin Products
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {object} dto.AdminProductResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/products/{id}/restore [post]
+func (c *AdminProduct) RestoreProduct(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, response.ErrInvalidID)
+		return
+	}
+	product, err := c.Service.Restore(ctx, middleware.AdminID(ctx), int32(id))
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, product)
+}
+
+// ProductHistory godoc
+// @Summary Get the history of a product
+// @Description Get every create, update, delete and restore of a product with the acting admin, newest first
+// @Tags Admin Products
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {array} dto.ProductHistoryResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/products/{id}/history [get]
+func (c *AdminProduct) ProductHistory(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, response.ErrInvalidID)
+		return
+	}
+	history, err := c.Service.History(ctx, int32(id))
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, history)
+}
+
+// ProductCategories godoc
+// @Summary List the categories of a product
+// @Tags Admin Products
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {array} dto.CategoryResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/products/{id}/categories [get]
+func (c *AdminProduct) ProductCategories(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, response.ErrInvalidID)
+		return
+	}
+	categories, err := c.Service.ProductCategories(ctx, int32(id))
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, categories)
+}
+
+// SetProductCategories godoc
+// @Summary Set the categories of a product
+// @Description Replace the categories of a product, an empty list removes them all
+// @Tags Admin Products
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param categories body dto.SetProductCategoriesRequest true "Category IDs"
+// @Success 200 {array} dto.CategoryResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/products/{id}/categories [put]
+func (c *AdminProduct) SetProductCategories(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, response.ErrInvalidID)
+		return
+	}
+	var req dto.SetProductCategoriesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	categories, err := c.Service.SetProductCategories(ctx, int32(id), req.CategoryIDs)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, categories)
+}
+
+// ProductTags godoc
+// @Summary List the tags of a product
+// @Tags Admin Products
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {object} dto.ProductTagsResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/products/{id}/tags [get]
+func (c *AdminProduct) ProductTags(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, response.ErrInvalidID)
+		return
+	}
+	tags, err := c.Service.ProductTags(ctx, int32(id))
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, tags)
+}
+
+// SetProductTags godoc
+// @Summary Set the tags of a product
+// @Description Replace the tags of a product, tags are trimmed and lower cased
+// @Tags Admin Products
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param tags body dto.SetProductTagsRequest true "Tags"
+// @Success 200 {object} dto.ProductTagsResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/products/{id}/tags [put]
+func (c *AdminProduct) SetProductTags(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, response.ErrInvalidID)
+		return
+	}
+	var req dto.SetProductTagsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	tags, err := c.Service.SetProductTags(ctx, int32(id), req.Tags)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, tags)
+}
+
+// ProductPrices godoc
+// @Summary List the prices of a product
+// @Description List the scheduled prices of a product in every currency, amounts are in minor units
+// @Tags Admin Products
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param current query bool false "Only the prices in effect now"
+// @Success 200 {array} dto.PriceResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/products/{id}/prices [get]
+func (c *AdminProduct) ProductPrices(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, response.ErrInvalidID)
+		return
+	}
+	current, _ := strconv.ParseBool(ctx.Query("current"))
+	prices, err := c.Service.ProductPrices(ctx, int32(id), current)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, prices)
+}
+
+// AddProductPrice godoc
+// @Summary Add a price to a product
+// @Description Schedule a price in one currency from valid_from (default now) until valid_to (default open ended), it cannot overlap another price of the same currency
+// @Tags Admin Products
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param price body dto.AdminCreatePriceRequest true "Price to add"
+// @Success 201 {object} dto.PriceResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/products/{id}/prices [post]
+func (c *AdminProduct) AddProductPrice(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, response.ErrInvalidID)
+		return
+	}
+	var req dto.AdminCreatePriceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.ProductID = int32(id)
+	price, err := c.Service.AddProductPrice(ctx, middleware.AdminID(ctx), req)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, price)
+}
+
+// DeleteProductPrice godoc
+// @Summary Remove a price from a product
+// @Description Remove a scheduled price, the removal is kept in the price history
+// @Tags Admin Products
+// @Param id path int true "Product ID"
+// @Param priceID path int true "Price ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/products/{id}/prices/{priceID} [delete]
+func (c *AdminProduct) DeleteProductPrice(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, response.ErrInvalidID)
+		return
+	}
+	priceID, err := strconv.ParseInt(ctx.Param("priceID"), 10, 64)
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, response.ErrInvalidID)
+		return
+	}
+	if err := c.Service.DeleteProductPrice(ctx, middleware.AdminID(ctx), int32(id), priceID); err != nil {
+		serviceError(ctx, err)
 		return
 	}
 	ctx.Status(http.StatusNoContent)
 }
 
+// PriceHistory godoc
+// @Summary Get the price history of a product
+// @Description Get every price added to or removed from a product with the acting admin, newest first
+// @Tags Admin Products
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {array} dto.PriceHistoryResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/products/{id}/prices/history [get]
+func (c *AdminProduct) PriceHistory(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, response.ErrInvalidID)
+		return
+	}
+	history, err := c.Service.PriceHistory(ctx, int32(id))
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, history)
+}
+
+// ImportProducts godoc
+// @Summary Import products
+// @Description Upload a CSV or JSONL file of products and import it in the background. Rows with an id update that product, rows without one create a product. The CSV header names the columns: id, name, description, price, is_active.
+// @Tags Admin Products
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or JSONL file"
+// @Param format formData string false "csv or jsonl, taken from the file extension when empty"
+// @Success 202 {object} dto.ProductImportResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 413 {object} response.ErrorResponse
+// @Failure 429 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Failure 503 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/products/import [post]
+func (c *AdminProduct) ImportProducts(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, MaxImportSize)
+	header, err := ctx.FormFile("file")
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		response.JSONError(ctx, http.StatusRequestEntityTooLarge, err)
+		return
+	}
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, ErrImportFileRequired)
+		return
+	}
+
+	format := strings.ToLower(ctx.PostForm("format"))
+	if format == "" {
+		format = formatOf(header.Filename)
+	}
+	file, err := header.Open()
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	status, err := c.Service.Import(ctx, middleware.AdminID(ctx), middleware.TenantID(ctx), format, data)
+	switch {
+	case errors.Is(err, service.ErrUnknownFormat):
+		response.JSONError(ctx, http.StatusBadRequest, err)
+	case errors.Is(err, dispatcher.ErrQueueFull), errors.Is(err, dispatcher.ErrTenantQuotaExceeded):
+		response.JSONError(ctx, http.StatusTooManyRequests, err)
+	case errors.Is(err, dispatcher.ErrServiceNotRegistered),
+		errors.Is(err, dispatcher.ErrServicePaused),
+		errors.Is(err, dispatcher.ErrServiceDraining):
+		response.JSONError(ctx, http.StatusServiceUnavailable, err)
+	case err != nil:
+		response.JSONError(ctx, http.StatusInternalServerError, err)
+	default:
+		ctx.JSON(http.StatusAccepted, status)
+	}
+}
+
+// ImportStatus godoc
+// @Summary Get a product import
+// @Description Get the progress of an import and the rows it rejected
+// @Tags Admin Products
+// @Produce json
+// @Param importID path string true "Import ID"
+// @Success 200 {object} dto.ProductImportResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/products/import/{importID} [get]
+func (c *AdminProduct) ImportStatus(ctx *gin.Context) {
+	status, err := c.Service.ImportStatus(ctx, middleware.TenantID(ctx), ctx.Param("importID"))
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, status)
+}
+
+// ExportProducts godoc
+// @Summary Export products
+// @Description Stream every product as CSV or JSONL, the output can be imported again
+// @Tags Admin Products
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string false "csv (default) or jsonl"
+// @Success 200 {file} file
+// @Failure 400 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/products/export [get]
+func (c *AdminProduct) ExportProducts(ctx *gin.Context) {
+	format := strings.ToLower(ctx.DefaultQuery("format", service.FormatCSV))
+	contentType := "text/csv"
+	switch format {
+	case service.FormatCSV:
+	case service.FormatJSONL:
+		contentType = "application/x-ndjson"
+	default:
+		response.JSONError(ctx, http.StatusBadRequest, service.ErrUnknownFormat)
+		return
+	}
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="products.%s"`, format))
+	ctx.Status(http.StatusOK)
+	if err := c.Service.Export(ctx, flushWriter{ctx.Writer}, format); err != nil {
+		// the status is already sent, the client sees a truncated file
+		log.Printf("⚠️ product export failed: %v\n", err)
+		ctx.Abort()
+	}
+}
+
+// serviceError responds with the status code of the kind of a service error,
+// a missing row that the service did not name is still a 404
+func serviceError(ctx *gin.Context, err error) {
+	switch service.KindOf(err) {
+	case service.KindNotFound:
+		response.JSONError(ctx, http.StatusNotFound, err)
+	case service.KindInvalid:
+		response.JSONError(ctx, http.StatusBadRequest, err)
+	case service.KindConflict:
+		response.JSONError(ctx, http.StatusConflict, err)
+	default:
+		if errors.Is(err, sql.ErrNoRows) {
+			response.JSONError(ctx, http.StatusNotFound, response.ErrNotFound)
+			return
+		}
+		response.JSONError(ctx, http.StatusInternalServerError, err)
+	}
+}
+
 // GetProductByID godoc
 // @Summary Get a product by ID
-// @Description Get a product by its ID
+// @Description Get a product by its ID, the ETag header is needed to update it
 // @Tags Admin Products
 // @Param id path int true "Product ID"
-// @Success 200 {object} dto.ProductResponse
+// @Success 200 {object} dto.AdminProductResponse
+// @Header 200 {string} ETag "Version of the product"
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Security BearerAuth
 // @Router /api/v1/admin/products/{id} [get]
@@ -130,27 +646,43 @@ func (c *AdminProduct) GetProductByID(ctx *gin.Context) {
 		return
 	}
 
-	product, err := c.Service.GetProductByID(ctx, int32(id))
+	product, err := c.Service.AdminGetProductByID(ctx, int32(id))
 	if err != nil {
-		response.JSONError(ctx, http.StatusInternalServerError, err)
+		serviceError(ctx, err)
 		return
 	}
+	ctx.Header("ETag", etag(product.Version))
 	ctx.JSON(http.StatusOK, product)
 }
 
 // ListProducts godoc
-// @Summary List all products
-// @Description Get a list of all products
+// @Summary List products
+// @Description Get a page of products matching the filters, pass next_cursor as cursor to get the next page
 // @Tags Admin Products
 // @Produce json
-// @Success 200 {array} dto.ClientListProductsResponse
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Page size, default 20, max 100"
+// @Param active query bool false "Only active (true) or inactive (false) products"
+// @Param min_price query int false "Minimum price"
+// @Param max_price query int false "Maximum price"
+// @Param name query string false "Name contains, case insensitive"
+// @Param category_id query int false "Category, its subcategories included"
+// @Param tag query string false "Tag"
+// @Param sort query string false "Sort order" Enums(created_at_desc, created_at_asc, price_asc, price_desc, name_asc, name_desc)
+// @Success 200 {object} dto.AdminListProductsResponse
+// @Failure 400 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Security BearerAuth
 // @Router /api/v1/admin/products [get]
 func (c *AdminProduct) ListProducts(ctx *gin.Context) {
-	products, err := c.Service.ListProducts(ctx)
+	var req dto.ListProductsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	products, err := c.Service.AdminListProducts(ctx, req)
 	if err != nil {
-		response.JSONError(ctx, http.StatusInternalServerError, err)
+		serviceError(ctx, err)
 		return
 	}
 	ctx.JSON(http.StatusOK, products)
@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-worker/internal/config"
+	"go-worker/internal/http/response"
+	"go-worker/internal/middleware"
+	"go-worker/internal/product/dto"
+	"go-worker/internal/product/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminCategory struct {
+	Service *service.Product
+}
+
+func NewAdminCategory(s *service.Product) *AdminCategory {
+	return &AdminCategory{Service: s}
+}
+
+func (c *AdminCategory) RegisterRoutes(rg *gin.RouterGroup, cfg *config.Config) {
+	auth := middleware.JWTAuth(cfg)
+
+	rg.POST("/", auth, c.CreateCategory)
+	rg.PUT("/:id", auth, c.UpdateCategory)
+	rg.DELETE("/:id", auth, c.DeleteCategory)
+	rg.GET("/:id", auth, c.GetCategory)
+	rg.GET("/", auth, c.ListCategories)
+}
+
+type ClientCategory struct {
+	Service *service.Product
+}
+
+func NewClientCategory(s *service.Product) *ClientCategory {
+	return &ClientCategory{Service: s}
+}
+
+func (c *ClientCategory) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/:id", c.GetCategory)
+	rg.GET("/", c.ListCategories)
+}
+
+// CreateCategory godoc
+// @Summary Create a category
+// @Description Create a category, under parent_id when it is set
+// @Tags Admin Categories
+// @Accept json
+// @Produce json
+// @Param category body dto.AdminCreateCategoryRequest true "Category to create"
+// @Success 201 {object} dto.CategoryResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/categories [post]
+func (c *AdminCategory) CreateCategory(ctx *gin.Context) {
+	var req dto.AdminCreateCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	category, err := c.Service.CreateCategory(ctx, req)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, category)
+}
+
+// UpdateCategory godoc
+// @Summary Update a category
+// @Description Rename a category or move it under another parent
+// @Tags Admin Categories
+// @Accept json
+// @Produce json
+// @Param id path int true "Category ID"
+// @Param category body dto.AdminUpdateCategoryRequest true "Category"
+// @Success 200 {object} dto.CategoryResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/categories/{id} [put]
+func (c *AdminCategory) UpdateCategory(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, response.ErrInvalidID)
+		return
+	}
+	var req dto.AdminUpdateCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	req.ID = int32(id)
+	category, err := c.Service.UpdateCategory(ctx, req)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, category)
+}
+
+// DeleteCategory godoc
+// @Summary Delete a category
+// @Description Delete a category without subcategories, its products are unlinked from it
+// @Tags Admin Categories
+// @Param id path int true "Category ID"
+// @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/categories/{id} [delete]
+func (c *AdminCategory) DeleteCategory(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, response.ErrInvalidID)
+		return
+	}
+	if err := c.Service.DeleteCategory(ctx, int32(id)); err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetCategory godoc
+// @Summary Get a category
+// @Tags Admin Categories
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {object} dto.CategoryResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/categories/{id} [get]
+func (c *AdminCategory) GetCategory(ctx *gin.Context) {
+	getCategory(ctx, c.Service)
+}
+
+// ListCategories godoc
+// @Summary List categories
+// @Description Get every top level category with its subcategories
+// @Tags Admin Categories
+// @Produce json
+// @Success 200 {array} dto.CategoryNode
+// @Failure 500 {object} response.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/admin/categories [get]
+func (c *AdminCategory) ListCategories(ctx *gin.Context) {
+	listCategories(ctx, c.Service)
+}
+
+// GetCategory godoc
+// @Summary Get a category
+// @Tags Categories
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {object} dto.CategoryResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Router /api/v1/categories/{id} [get]
+func (c *ClientCategory) GetCategory(ctx *gin.Context) {
+	getCategory(ctx, c.Service)
+}
+
+// ListCategories godoc
+// @Summary List categories
+// @Description Get every top level category with its subcategories, use an id as category_id to list its products
+// @Tags Categories
+// @Produce json
+// @Success 200 {array} dto.CategoryNode
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/categories [get]
+func (c *ClientCategory) ListCategories(ctx *gin.Context) {
+	listCategories(ctx, c.Service)
+}
+
+func getCategory(ctx *gin.Context, s *service.Product) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, response.ErrInvalidID)
+		return
+	}
+	category, err := s.GetCategory(ctx, int32(id))
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, category)
+}
+
+func listCategories(ctx *gin.Context, s *service.Product) {
+	tree, err := s.ListCategories(ctx)
+	if err != nil {
+		response.JSONError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, tree)
+}
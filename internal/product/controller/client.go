@@ -19,17 +19,19 @@ func NewClient(s *service.Product) *ClientProduct {
 }
 
 func (c *ClientProduct) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/search", c.SearchProducts)
 	rg.GET("/:id", c.GetProductByID)
 	rg.GET("/", c.ListProducts)
 }
 
 // GetProductByID godoc
 // @Summary Get a product by ID
-// @Description Get a product by its ID
+// @Description Get an active product by its ID
 // @Tags Products
 // @Param id path int true "Product ID"
 // @Success 200 {object} dto.ProductResponse
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /api/v1/products/{id} [get]
 func (c *ClientProduct) GetProductByID(ctx *gin.Context) {
@@ -41,24 +43,64 @@ func (c *ClientProduct) GetProductByID(ctx *gin.Context) {
 	}
 	product, err = c.Service.GetProductByID(ctx, int32(id))
 	if err != nil {
-		response.JSONError(ctx, http.StatusInternalServerError, err)
+		serviceError(ctx, err)
 		return
 	}
 	ctx.JSON(http.StatusOK, product)
 }
 
 // ListProducts godoc
-// @Summary List all products
-// @Description Get a list of all products
+// @Summary List products
+// @Description Get a page of active products matching the filters, pass next_cursor as cursor to get the next page
 // @Tags Products
 // @Produce json
-// @Success 200 {array} dto.ClientListProductsResponse
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Page size, default 20, max 100"
+// @Param min_price query int false "Minimum price"
+// @Param max_price query int false "Maximum price"
+// @Param name query string false "Name contains, case insensitive"
+// @Param category_id query int false "Category, its subcategories included"
+// @Param tag query string false "Tag"
+// @Param sort query string false "Sort order" Enums(created_at_desc, created_at_asc, price_asc, price_desc, name_asc, name_desc)
+// @Success 200 {object} dto.ClientListProductsResponse
+// @Failure 400 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /api/v1/products [get]
 func (c *ClientProduct) ListProducts(ctx *gin.Context) {
-	products, err := c.Service.ListProducts(ctx)
+	var req dto.ListProductsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	products, err := c.Service.ListProducts(ctx, req)
+	if err != nil {
+		serviceError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, products)
+}
+
+// SearchProducts godoc
+// @Summary Search products
+// @Description Full-text search in product names and descriptions, every word must match and may be a prefix. Best matches come first.
+// @Tags Products
+// @Produce json
+// @Param q query string true "Search text"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Page size, default 20, max 100"
+// @Success 200 {object} dto.SearchProductsResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/products/search [get]
+func (c *ClientProduct) SearchProducts(ctx *gin.Context) {
+	var req dto.SearchProductsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.JSONError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	products, err := c.Service.SearchProducts(ctx, req)
 	if err != nil {
-		response.JSONError(ctx, http.StatusInternalServerError, err)
+		serviceError(ctx, err)
 		return
 	}
 	ctx.JSON(http.StatusOK, products)
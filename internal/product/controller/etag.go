@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrIfMatchRequired = errors.New("If-Match header with the product ETag is required")
+	ErrInvalidIfMatch  = errors.New("invalid If-Match header")
+)
+
+// etag is the strong ETag of a product version
+func etag(version int32) string {
+	return `"` + strconv.Itoa(int(version)) + `"`
+}
+
+// parseIfMatch returns the product version of an If-Match header,
+// 0 for "*" which matches any version
+func parseIfMatch(header string) (int32, error) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, ErrIfMatchRequired
+	}
+	if header == "*" {
+		return 0, nil
+	}
+	header = strings.TrimPrefix(header, "W/")
+	version, err := strconv.ParseInt(strings.Trim(header, `"`), 10, 32)
+	if err != nil || version < 1 {
+		return 0, ErrInvalidIfMatch
+	}
+	return int32(version), nil
+}
@@ -2,12 +2,17 @@ package controller
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 
 	pb "go-worker/api/proto/product/v1"
+	"go-worker/internal/middleware"
 	"go-worker/internal/product/dto"
 	"go-worker/internal/product/service"
 
-	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type ProductGRPC struct {
@@ -23,71 +28,412 @@ func NewGRPC(svc *service.Product) pb.ProductServiceServer {
 
 func (h *ProductGRPC) GetProductByID(ctx context.Context, req *pb.ProductRequest) (*pb.ProductResponse, error) {
 	p, err := h.svc.GetProductByID(ctx, req.Id)
+	if err != nil {
+		return nil, serviceStatus(err)
+	}
 	return &pb.ProductResponse{
 		Id:          p.ID,
 		Name:        p.Name,
 		Description: p.Description,
 		Price:       p.Price,
-	}, err
+		Version:     p.Version,
+		BasePrice:   toPBMoney(p.Currency, p.Price),
+	}, nil
 }
 
 func (h *ProductGRPC) CreateProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.ProductResponse, error) {
-	p, err := h.svc.Create(ctx, dto.AdminCreateProductRequest{
+	p, err := h.svc.Create(ctx, middleware.GRPCClaims(ctx).AdminID, dto.AdminCreateProductRequest{
 		Name:        req.Name,
 		Description: req.Description,
 		Price:       req.Price,
+		Currency:    req.Currency,
 	})
 	if err != nil {
-		return nil, err
+		return nil, serviceStatus(err)
 	}
-	return &pb.ProductResponse{
-		Id:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-	}, nil
+	return toPBAdminProduct(p), nil
 }
 
 func (h *ProductGRPC) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.ProductResponse, error) {
-	p, err := h.svc.Update(ctx, dto.AdminUpdateProductRequest{
+	p, err := h.svc.Update(ctx, middleware.GRPCClaims(ctx).AdminID, dto.AdminUpdateProductRequest{
 		ID:          req.Id,
 		Name:        req.Name,
 		Description: req.Description,
 		Price:       req.Price,
 		IsActive:    req.IsActive,
+		Currency:    req.Currency,
+		Version:     req.ExpectedVersion,
 	})
+	if errors.Is(err, service.ErrVersionConflict) {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+	if err != nil {
+		return nil, serviceStatus(err)
+	}
+	return toPBAdminProduct(p), nil
+}
+
+func (h *ProductGRPC) PatchProduct(ctx context.Context, req *pb.PatchProductRequest) (*pb.ProductResponse, error) {
+	patch := dto.AdminPatchProductRequest{
+		ID:      req.Id,
+		Version: req.ExpectedVersion,
+	}
+	if len(req.GetUpdateMask().GetPaths()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "update_mask is required")
+	}
+	for _, path := range req.GetUpdateMask().GetPaths() {
+		switch path {
+		case "name":
+			patch.Name = &req.Name
+		case "description":
+			patch.Description = &req.Description
+		case "price":
+			patch.Price = &req.Price
+		case "is_active":
+			patch.IsActive = &req.IsActive
+		case "currency":
+			patch.Currency = &req.Currency
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "unknown update_mask path %q", path)
+		}
+	}
+
+	p, err := h.svc.Patch(ctx, middleware.GRPCClaims(ctx).AdminID, patch)
+	if errors.Is(err, service.ErrVersionConflict) {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+	if err != nil {
+		return nil, serviceStatus(err)
+	}
+	return toPBAdminProduct(p), nil
+}
+
+func (h *ProductGRPC) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*pb.ProductResponse, error) {
+	err := h.svc.Delete(ctx, middleware.GRPCClaims(ctx).AdminID, req.Id)
+	if err != nil {
+		return nil, serviceStatus(err)
+	}
+	return &pb.ProductResponse{Id: req.Id}, nil
+}
+
+func (h *ProductGRPC) RestoreProduct(ctx context.Context, req *pb.ProductRequest) (*pb.ProductResponse, error) {
+	p, err := h.svc.Restore(ctx, middleware.GRPCClaims(ctx).AdminID, req.Id)
+	if err != nil {
+		return nil, serviceStatus(err)
+	}
+	return toPBAdminProduct(p), nil
+}
+
+func (h *ProductGRPC) GetProductHistory(ctx context.Context, req *pb.ProductRequest) (*pb.ProductHistoryResponse, error) {
+	history, err := h.svc.History(ctx, req.Id)
 	if err != nil {
-		return nil, err
+		return nil, serviceStatus(err)
 	}
+	var resp pb.ProductHistoryResponse
+	for _, e := range history {
+		resp.Entries = append(resp.Entries, &pb.ProductHistoryEntry{
+			Id:        e.ID,
+			ProductId: e.ProductID,
+			Action:    e.Action,
+			AdminId:   e.AdminID,
+			Before:    toPBSnapshot(e.Before),
+			After:     toPBSnapshot(e.After),
+			CreatedAt: timestamppb.New(e.CreatedAt),
+		})
+	}
+	return &resp, nil
+}
+
+func toPBAdminProduct(p dto.AdminProductResponse) *pb.ProductResponse {
 	return &pb.ProductResponse{
 		Id:          p.ID,
 		Name:        p.Name,
 		Description: p.Description,
 		Price:       p.Price,
-	}, nil
+		Version:     p.Version,
+		IsActive:    p.IsActive,
+		CreatedAt:   timestamppb.New(p.CreatedAt),
+		BasePrice:   toPBMoney(p.Currency, p.Price),
+	}
 }
 
-func (h *ProductGRPC) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*pb.ProductResponse, error) {
-	err := h.svc.Delete(ctx, req.Id)
-	if err != nil {
-		return nil, err
+func toPBSnapshot(s *dto.ProductSnapshot) *pb.ProductSnapshot {
+	if s == nil {
+		return nil
 	}
-	return &pb.ProductResponse{Id: req.Id}, nil
+	snapshot := &pb.ProductSnapshot{
+		Id:          s.ID,
+		Name:        s.Name,
+		Description: s.Description,
+		Price:       s.Price,
+		IsActive:    s.IsActive,
+		CreatedAt:   timestamppb.New(s.CreatedAt),
+		Currency:    s.Currency,
+	}
+	if s.DeletedAt != nil {
+		snapshot.DeletedAt = timestamppb.New(*s.DeletedAt)
+	}
+	return snapshot
 }
 
-func (h *ProductGRPC) ListProducts(ctx context.Context, _ *emptypb.Empty) (*pb.ListProductsResponse, error) {
-	products, err := h.svc.ListProducts(ctx)
+func (h *ProductGRPC) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	products, err := h.svc.ListProducts(ctx, dto.ListProductsRequest{
+		Cursor:     req.GetCursor(),
+		Limit:      int(req.GetLimit()),
+		Active:     req.Active,
+		MinPrice:   req.MinPrice,
+		MaxPrice:   req.MaxPrice,
+		Name:       req.GetName(),
+		CategoryID: req.CategoryId,
+		Tag:        req.GetTag(),
+		Sort:       req.GetSort(),
+	})
 	if err != nil {
-		return nil, err
+		return nil, serviceStatus(err)
+	}
+	resp := pb.ListProductsResponse{
+		NextCursor: products.NextCursor,
+		Total:      products.Total,
 	}
-	var resp pb.ListProductsResponse
-	for _, p := range products {
+	for _, p := range products.Products {
 		resp.Products = append(resp.Products, &pb.ProductResponse{
 			Id:          p.ID,
 			Name:        p.Name,
 			Description: p.Description,
 			Price:       p.Price,
+			BasePrice:   toPBMoney(p.Currency, p.Price),
+		})
+	}
+	return &resp, nil
+}
+
+func (h *ProductGRPC) SearchProducts(ctx context.Context, req *pb.SearchProductsRequest) (*pb.SearchProductsResponse, error) {
+	products, err := h.svc.SearchProducts(ctx, dto.SearchProductsRequest{
+		Query:  req.GetQuery(),
+		Cursor: req.GetCursor(),
+		Limit:  int(req.GetLimit()),
+	})
+	if err != nil {
+		return nil, serviceStatus(err)
+	}
+	resp := pb.SearchProductsResponse{
+		NextCursor: products.NextCursor,
+		Total:      products.Total,
+	}
+	for _, p := range products.Products {
+		resp.Results = append(resp.Results, &pb.SearchResult{
+			Product: &pb.ProductResponse{
+				Id:          p.ID,
+				Name:        p.Name,
+				Description: p.Description,
+				Price:       p.Price,
+				BasePrice:   toPBMoney(p.Currency, p.Price),
+			},
+			Rank:                 p.Rank,
+			NameHighlight:        p.NameHighlight,
+			DescriptionHighlight: p.DescriptionHighlight,
 		})
 	}
 	return &resp, nil
 }
+
+func (h *ProductGRPC) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.Category, error) {
+	c, err := h.svc.CreateCategory(ctx, dto.AdminCreateCategoryRequest{
+		Name:     req.GetName(),
+		Slug:     req.GetSlug(),
+		ParentID: req.ParentId,
+	})
+	if err != nil {
+		return nil, categoryStatus(err)
+	}
+	return toPBCategory(dto.CategoryNode{CategoryResponse: c}), nil
+}
+
+func (h *ProductGRPC) GetCategory(ctx context.Context, req *pb.CategoryRequest) (*pb.Category, error) {
+	c, err := h.svc.GetCategory(ctx, req.GetId())
+	if err != nil {
+		return nil, categoryStatus(err)
+	}
+	return toPBCategory(dto.CategoryNode{CategoryResponse: c}), nil
+}
+
+func (h *ProductGRPC) ListCategories(ctx context.Context, req *pb.ListCategoriesRequest) (*pb.ListCategoriesResponse, error) {
+	tree, err := h.svc.ListCategories(ctx)
+	if err != nil {
+		return nil, serviceStatus(err)
+	}
+	var resp pb.ListCategoriesResponse
+	for _, node := range tree {
+		resp.Categories = append(resp.Categories, toPBCategory(node))
+	}
+	return &resp, nil
+}
+
+func (h *ProductGRPC) UpdateCategory(ctx context.Context, req *pb.UpdateCategoryRequest) (*pb.Category, error) {
+	c, err := h.svc.UpdateCategory(ctx, dto.AdminUpdateCategoryRequest{
+		ID:       req.GetId(),
+		Name:     req.GetName(),
+		Slug:     req.GetSlug(),
+		ParentID: req.ParentId,
+	})
+	if err != nil {
+		return nil, categoryStatus(err)
+	}
+	return toPBCategory(dto.CategoryNode{CategoryResponse: c}), nil
+}
+
+func (h *ProductGRPC) DeleteCategory(ctx context.Context, req *pb.CategoryRequest) (*pb.DeleteCategoryResponse, error) {
+	if err := h.svc.DeleteCategory(ctx, req.GetId()); err != nil {
+		return nil, categoryStatus(err)
+	}
+	return &pb.DeleteCategoryResponse{}, nil
+}
+
+func (h *ProductGRPC) SetProductCategories(ctx context.Context, req *pb.SetProductCategoriesRequest) (*pb.ProductCategoriesResponse, error) {
+	categories, err := h.svc.SetProductCategories(ctx, req.GetProductId(), req.GetCategoryIds())
+	if err != nil {
+		return nil, categoryStatus(err)
+	}
+	var resp pb.ProductCategoriesResponse
+	for _, c := range categories {
+		resp.Categories = append(resp.Categories, toPBCategory(dto.CategoryNode{CategoryResponse: c}))
+	}
+	return &resp, nil
+}
+
+func (h *ProductGRPC) SetProductTags(ctx context.Context, req *pb.SetProductTagsRequest) (*pb.ProductTagsResponse, error) {
+	tags, err := h.svc.SetProductTags(ctx, req.GetProductId(), req.GetTags())
+	if err != nil {
+		return nil, categoryStatus(err)
+	}
+	return &pb.ProductTagsResponse{Tags: tags.Tags}, nil
+}
+
+func toPBCategory(c dto.CategoryNode) *pb.Category {
+	category := &pb.Category{
+		Id:        c.ID,
+		Name:      c.Name,
+		Slug:      c.Slug,
+		CreatedAt: timestamppb.New(c.CreatedAt),
+	}
+	if c.ParentID != nil {
+		category.ParentId = *c.ParentID
+	}
+	for _, child := range c.Children {
+		category.Children = append(category.Children, toPBCategory(child))
+	}
+	return category
+}
+
+// categoryStatus maps category errors to gRPC status codes, moves that would
+// break the tree are a failed precondition rather than a conflict
+func categoryStatus(err error) error {
+	if errors.Is(err, service.ErrCategoryCycle) || errors.Is(err, service.ErrCategoryHasChildren) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return serviceStatus(err)
+}
+func (h *ProductGRPC) ListProductPrices(ctx context.Context, req *pb.ListProductPricesRequest) (*pb.ListProductPricesResponse, error) {
+	prices, err := h.svc.ProductPrices(ctx, req.GetProductId(), req.GetCurrent())
+	if err != nil {
+		return nil, serviceStatus(err)
+	}
+	var resp pb.ListProductPricesResponse
+	for _, p := range prices {
+		resp.Prices = append(resp.Prices, toPBPrice(p))
+	}
+	return &resp, nil
+}
+
+func (h *ProductGRPC) AddProductPrice(ctx context.Context, req *pb.AddProductPriceRequest) (*pb.ProductPrice, error) {
+	if req.GetPrice() == nil {
+		return nil, status.Error(codes.InvalidArgument, "price is required")
+	}
+	price := dto.AdminCreatePriceRequest{
+		ProductID: req.GetProductId(),
+		Price: dto.Money{
+			Currency: req.GetPrice().GetCurrencyCode(),
+			Amount:   req.GetPrice().GetAmount(),
+		},
+	}
+	if req.ValidFrom != nil {
+		validFrom := req.ValidFrom.AsTime()
+		price.ValidFrom = &validFrom
+	}
+	if req.ValidTo != nil {
+		validTo := req.ValidTo.AsTime()
+		price.ValidTo = &validTo
+	}
+	p, err := h.svc.AddProductPrice(ctx, middleware.GRPCClaims(ctx).AdminID, price)
+	if err != nil {
+		return nil, serviceStatus(err)
+	}
+	return toPBPrice(p), nil
+}
+
+func (h *ProductGRPC) DeleteProductPrice(ctx context.Context, req *pb.DeleteProductPriceRequest) (*pb.DeleteProductPriceResponse, error) {
+	if err := h.svc.DeleteProductPrice(ctx, middleware.GRPCClaims(ctx).AdminID, req.GetProductId(), req.GetPriceId()); err != nil {
+		return nil, serviceStatus(err)
+	}
+	return &pb.DeleteProductPriceResponse{}, nil
+}
+
+func (h *ProductGRPC) GetProductPriceHistory(ctx context.Context, req *pb.ProductRequest) (*pb.PriceHistoryResponse, error) {
+	history, err := h.svc.PriceHistory(ctx, req.GetId())
+	if err != nil {
+		return nil, serviceStatus(err)
+	}
+	var resp pb.PriceHistoryResponse
+	for _, e := range history {
+		entry := &pb.PriceHistoryEntry{
+			Id:        e.ID,
+			ProductId: e.ProductID,
+			PriceId:   e.PriceID,
+			Action:    e.Action,
+			AdminId:   e.AdminID,
+			Price:     toPBMoney(e.Price.Currency, e.Price.Amount),
+			ValidFrom: timestamppb.New(e.ValidFrom),
+			CreatedAt: timestamppb.New(e.CreatedAt),
+		}
+		if e.ValidTo != nil {
+			entry.ValidTo = timestamppb.New(*e.ValidTo)
+		}
+		resp.Entries = append(resp.Entries, entry)
+	}
+	return &resp, nil
+}
+
+func toPBMoney(currency string, amount int64) *pb.Money {
+	return &pb.Money{CurrencyCode: currency, Amount: amount}
+}
+
+func toPBPrice(p dto.PriceResponse) *pb.ProductPrice {
+	price := &pb.ProductPrice{
+		Id:        p.ID,
+		ProductId: p.ProductID,
+		Price:     toPBMoney(p.Price.Currency, p.Price.Amount),
+		ValidFrom: timestamppb.New(p.ValidFrom),
+		CreatedAt: timestamppb.New(p.CreatedAt),
+	}
+	if p.ValidTo != nil {
+		price.ValidTo = timestamppb.New(*p.ValidTo)
+	}
+	return price
+}
+
+// serviceStatus maps the kind of a service error to a gRPC status code,
+// a missing row that the service did not name is still NotFound
+func serviceStatus(err error) error {
+	switch service.KindOf(err) {
+	case service.KindNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case service.KindInvalid:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case service.KindConflict:
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
+}
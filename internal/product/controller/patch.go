@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-worker/internal/product/dto"
+)
+
+var ErrInvalidPatch = errors.New("invalid merge patch")
+
+// decodeMergePatch reads a JSON Merge Patch (RFC 7396) of a product.
+// Every product field is required, so a null that would remove one is rejected.
+func decodeMergePatch(body []byte, req *dto.AdminPatchProductRequest) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return fmt.Errorf("%w: body must be a JSON object", ErrInvalidPatch)
+	}
+	for name, value := range fields {
+		switch name {
+		case "name", "description", "price", "is_active", "currency":
+		default:
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidPatch, name)
+		}
+		if bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+			return fmt.Errorf("%w: field %q cannot be removed", ErrInvalidPatch, name)
+		}
+	}
+	if err := json.Unmarshal(body, req); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"go-worker/internal/product/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxImportSize bounds the size of an uploaded import file
+const MaxImportSize = 32 << 20
+
+var ErrImportFileRequired = errors.New("file is required")
+
+// flushWriter sends every write to the client right away so large exports stream
+type flushWriter struct {
+	w gin.ResponseWriter
+}
+
+func (f flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	f.w.Flush()
+	return n, err
+}
+
+func formatOf(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return service.FormatCSV
+	case ".jsonl", ".ndjson":
+		return service.FormatJSONL
+	}
+	return ""
+}
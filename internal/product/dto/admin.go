@@ -1,10 +1,15 @@
 package dto
 
+import "time"
+
 type AdminCreateProductRequest struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
-	Price       int64  `json:"price"`
-	IsActive    bool   `json:"is_active"`
+	// Price is in the minor units of Currency
+	Price    int64 `json:"price"`
+	IsActive bool  `json:"is_active"`
+	// Currency is an ISO 4217 code, USD when empty
+	Currency string `json:"currency"`
 }
 
 type AdminUpdateProductRequest struct {
@@ -13,4 +18,103 @@ type AdminUpdateProductRequest struct {
 	Description string `json:"description"`
 	Price       int64  `json:"price"`
 	IsActive    bool   `json:"is_active"`
+	// Currency is an ISO 4217 code, the current one is kept when empty
+	Currency string `json:"currency"`
+	// Version is the version the change was made against, 0 updates unconditionally
+	Version int32 `json:"-"`
+}
+
+// AdminProductResponse is the admin view of a product, it includes inactive products
+type AdminProductResponse struct {
+	ID          int32     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Price       int64     `json:"price"`
+	IsActive    bool      `json:"is_active"`
+	Currency    string    `json:"currency"`
+	CreatedAt   time.Time `json:"created_at"`
+	// Version changes on every update, it is also sent as the ETag
+	Version int32 `json:"version"`
+}
+
+type AdminListProductsResponse struct {
+	Products []AdminProductResponse `json:"products"`
+	// NextCursor is empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+	// Total is the number of products matching the filters across all pages
+	Total int64 `json:"total"`
+}
+
+// AdminPatchProductRequest changes only the fields that are set
+type AdminPatchProductRequest struct {
+	ID          int32   `json:"-"`
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Price       *int64  `json:"price,omitempty"`
+	IsActive    *bool   `json:"is_active,omitempty"`
+	Currency    *string `json:"currency,omitempty"`
+	// Version is the version the change was made against, 0 updates unconditionally
+	Version int32 `json:"-"`
+}
+
+// ProductSnapshot is the state of a product before or after a change
+type ProductSnapshot struct {
+	ID          int32      `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Price       int64      `json:"price"`
+	IsActive    bool       `json:"is_active"`
+	Currency    string     `json:"currency,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	Version     int32      `json:"version"`
+}
+
+type ProductHistoryResponse struct {
+	ID        int64  `json:"id"`
+	ProductID int32  `json:"product_id"`
+	Action    string `json:"action"`
+	AdminID   string `json:"admin_id"`
+	// Before is empty for create, After is the state once the change was made
+	Before    *ProductSnapshot `json:"before,omitempty"`
+	After     *ProductSnapshot `json:"after,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+type AdminProductHistoryResponse []ProductHistoryResponse
+
+// ProductRow is a product in an import or export file. Rows with an id update
+// that product, rows without one create a new product.
+type ProductRow struct {
+	ID          int32  `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Price       int64  `json:"price"`
+	// IsActive defaults to true on import
+	IsActive *bool `json:"is_active,omitempty"`
+	// Currency defaults to USD for new products and is kept for updated ones
+	Currency string `json:"currency,omitempty"`
+}
+
+type ImportRowError struct {
+	// Row is the line of the file, the CSV header is line 1
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+type ProductImportResponse struct {
+	ID     string `json:"id"`
+	Tenant string `json:"tenant"`
+	Format string `json:"format"`
+	// Status is queued, running, done or failed
+	Status  string `json:"status"`
+	Created int    `json:"created"`
+	Updated int    `json:"updated"`
+	Failed  int    `json:"failed"`
+	// Errors lists the rejected rows, it is capped while Failed counts them all
+	Errors []ImportRowError `json:"errors,omitempty"`
+	// Error is set when the whole import failed
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
 }
@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+type AdminCreateCategoryRequest struct {
+	Name string `json:"name" binding:"required"`
+	// Slug is made from the name when empty
+	Slug string `json:"slug"`
+	// ParentID is empty for a top level category
+	ParentID *int32 `json:"parent_id"`
+}
+
+type AdminUpdateCategoryRequest struct {
+	ID   int32  `json:"-"`
+	Name string `json:"name" binding:"required"`
+	// Slug is made from the name when empty
+	Slug string `json:"slug"`
+	// ParentID is empty for a top level category
+	ParentID *int32 `json:"parent_id"`
+}
+
+type CategoryResponse struct {
+	ID        int32     `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	ParentID  *int32    `json:"parent_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CategoryNode is a category and its subcategories
+type CategoryNode struct {
+	CategoryResponse
+	Children []CategoryNode `json:"children,omitempty"`
+}
+
+type SetProductCategoriesRequest struct {
+	CategoryIDs []int32 `json:"category_ids"`
+}
+
+type SetProductTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+type ProductTagsResponse struct {
+	Tags []string `json:"tags"`
+}
@@ -5,6 +5,57 @@ type ProductResponse struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Price       int64  `json:"price"`
+	Currency    string `json:"currency"`
+	// Version changes on every update, it is only sent over gRPC where updates are made
+	Version int32 `json:"-"`
 }
 
-type ClientListProductsResponse []ProductResponse
+// ListProductsRequest filters, sorts and pages a product listing
+type ListProductsRequest struct {
+	// Cursor is the next_cursor of the previous page, empty for the first page
+	Cursor string `form:"cursor" json:"cursor"`
+	Limit  int    `form:"limit" json:"limit"`
+	// Active only returns active (true) or inactive (false) products when set,
+	// client listings always return active products
+	Active   *bool  `form:"active" json:"active"`
+	MinPrice *int64 `form:"min_price" json:"min_price"`
+	MaxPrice *int64 `form:"max_price" json:"max_price"`
+	// Name matches products whose name contains it, case insensitive
+	Name string `form:"name" json:"name"`
+	// CategoryID matches products in the category or any of its subcategories
+	CategoryID *int32 `form:"category_id" json:"category_id"`
+	// Tag matches products with the tag
+	Tag string `form:"tag" json:"tag"`
+	// Sort is one of created_at_desc (default), created_at_asc, price_asc, price_desc, name_asc, name_desc
+	Sort string `form:"sort" json:"sort"`
+}
+
+type ClientListProductsResponse struct {
+	Products []ProductResponse `json:"products"`
+	// NextCursor is empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+	// Total is the number of products matching the filters across all pages
+	Total int64 `json:"total"`
+}
+
+// SearchProductsRequest searches product names and descriptions, every word
+// of Query must match and the last letters of a word may be missing
+type SearchProductsRequest struct {
+	Query  string `form:"q" json:"q" binding:"required"`
+	Cursor string `form:"cursor" json:"cursor"`
+	Limit  int    `form:"limit" json:"limit"`
+}
+
+type SearchProductResponse struct {
+	ProductResponse
+	Rank float32 `json:"rank"`
+	// NameHighlight and DescriptionHighlight wrap the matched words in <mark></mark>
+	NameHighlight        string `json:"name_highlight"`
+	DescriptionHighlight string `json:"description_highlight"`
+}
+
+type SearchProductsResponse struct {
+	Products   []SearchProductResponse `json:"products"`
+	NextCursor string                  `json:"next_cursor,omitempty"`
+	Total      int64                   `json:"total"`
+}
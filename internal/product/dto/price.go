@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+// Money is an amount in the minor units of an ISO 4217 currency, 1050 USD is $10.50
+type Money struct {
+	Currency string `json:"currency"`
+	Amount   int64  `json:"amount"`
+}
+
+// AdminCreatePriceRequest schedules a price of a product in one currency,
+// prices of the same currency cannot overlap
+type AdminCreatePriceRequest struct {
+	ProductID int32 `json:"-"`
+	Price     Money `json:"price"`
+	// ValidFrom defaults to now
+	ValidFrom *time.Time `json:"valid_from,omitempty"`
+	// ValidTo is empty for a price that does not end
+	ValidTo *time.Time `json:"valid_to,omitempty"`
+}
+
+type PriceResponse struct {
+	ID        int64      `json:"id"`
+	ProductID int32      `json:"product_id"`
+	Price     Money      `json:"price"`
+	ValidFrom time.Time  `json:"valid_from"`
+	ValidTo   *time.Time `json:"valid_to,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type PriceHistoryResponse struct {
+	ID        int64 `json:"id"`
+	ProductID int32 `json:"product_id"`
+	PriceID   int64 `json:"price_id"`
+	// Action is add or remove
+	Action    string     `json:"action"`
+	AdminID   string     `json:"admin_id"`
+	Price     Money      `json:"price"`
+	ValidFrom time.Time  `json:"valid_from"`
+	ValidTo   *time.Time `json:"valid_to,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
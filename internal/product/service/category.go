@@ -0,0 +1,333 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-worker/internal/product/dto"
+	"go-worker/internal/storage/sql/sqlc"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// maxTagLength bounds a single tag
+const maxTagLength = 64
+
+var (
+	ErrCategoryNotFound    = NotFound("category not found")
+	ErrCategorySlugTaken   = Conflict("category slug already used")
+	ErrCategoryCycle       = Conflict("category cannot be moved under itself or its subcategories")
+	ErrCategoryHasChildren = Conflict("category has subcategories")
+	ErrInvalidSlug         = Invalid("slug must contain a letter or digit")
+	ErrInvalidTag          = Invalid("invalid tag")
+)
+
+// postgres error codes
+const (
+	pgForeignKeyViolation = "23503"
+	pgUniqueViolation     = "23505"
+	pgExclusionViolation  = "23P01"
+)
+
+func (s *Product) CreateCategory(ctx context.Context, req dto.AdminCreateCategoryRequest) (dto.CategoryResponse, error) {
+	slug, err := categorySlug(req.Slug, req.Name)
+	if err != nil {
+		return dto.CategoryResponse{}, err
+	}
+	category, err := s.query.CreateCategory(ctx, sqlc.CreateCategoryParams{
+		CategoryName: strings.TrimSpace(req.Name),
+		Slug:         slug,
+		ParentID:     nullInt32(req.ParentID),
+	})
+	if err != nil {
+		return dto.CategoryResponse{}, categoryError(err)
+	}
+	s.log.Info("Category created", zap.Int32("id", category.ID))
+	return toCategoryResponse(category), nil
+}
+
+func (s *Product) GetCategory(ctx context.Context, id int32) (dto.CategoryResponse, error) {
+	category, err := s.query.GetCategory(ctx, id)
+	if err != nil {
+		return dto.CategoryResponse{}, categoryError(err)
+	}
+	return toCategoryResponse(category), nil
+}
+
+// ListCategories returns every top level category with its subcategories
+func (s *Product) ListCategories(ctx context.Context) ([]dto.CategoryNode, error) {
+	categories, err := s.query.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	children := map[int32][]sqlc.Category{}
+	var roots []sqlc.Category
+	for _, c := range categories {
+		if c.ParentID.Valid {
+			children[c.ParentID.Int32] = append(children[c.ParentID.Int32], c)
+		} else {
+			roots = append(roots, c)
+		}
+	}
+
+	var build func(c sqlc.Category) dto.CategoryNode
+	build = func(c sqlc.Category) dto.CategoryNode {
+		node := dto.CategoryNode{CategoryResponse: toCategoryResponse(c)}
+		for _, child := range children[c.ID] {
+			node.Children = append(node.Children, build(child))
+		}
+		return node
+	}
+	tree := make([]dto.CategoryNode, 0, len(roots))
+	for _, c := range roots {
+		tree = append(tree, build(c))
+	}
+	return tree, nil
+}
+
+// UpdateCategory renames or moves a category, it cannot be moved under itself
+func (s *Product) UpdateCategory(ctx context.Context, req dto.AdminUpdateCategoryRequest) (dto.CategoryResponse, error) {
+	slug, err := categorySlug(req.Slug, req.Name)
+	if err != nil {
+		return dto.CategoryResponse{}, err
+	}
+
+	var category sqlc.Category
+	err = s.tx.WithTx(ctx, func(ctx context.Context, q *sqlc.Queries) error {
+		if _, err := q.LockCategory(ctx, req.ID); err != nil {
+			return categoryError(err)
+		}
+		if req.ParentID != nil {
+			if err := lockAncestors(ctx, q, req.ID, *req.ParentID); err != nil {
+				return err
+			}
+		}
+		var err error
+		category, err = q.UpdateCategory(ctx, sqlc.UpdateCategoryParams{
+			ID:           req.ID,
+			CategoryName: strings.TrimSpace(req.Name),
+			Slug:         slug,
+			ParentID:     nullInt32(req.ParentID),
+		})
+		return categoryError(err)
+	})
+	if err != nil {
+		return dto.CategoryResponse{}, err
+	}
+	// moving a category changes which products its ancestors match
+	s.invalidateLists(ctx)
+	return toCategoryResponse(category), nil
+}
+
+// lockAncestors locks parentID and its ancestors, so none of them can be moved
+// under id before id is moved under parentID, and fails when id is one of them
+func lockAncestors(ctx context.Context, q *sqlc.Queries, id, parentID int32) error {
+	locked := map[int32]bool{}
+	for {
+		ancestors, err := q.ListCategoryAncestors(ctx, parentID)
+		if err != nil {
+			return err
+		}
+		if len(ancestors) == 0 {
+			return ErrCategoryNotFound
+		}
+		var unlocked []int32
+		for _, ancestor := range ancestors {
+			if ancestor == id {
+				return ErrCategoryCycle
+			}
+			if !locked[ancestor] {
+				unlocked = append(unlocked, ancestor)
+			}
+		}
+		if len(unlocked) == 0 {
+			return nil
+		}
+		// a category moved before it was locked changes the ancestors, read them again
+		if _, err := q.LockCategories(ctx, unlocked); err != nil {
+			return err
+		}
+		for _, ancestor := range unlocked {
+			locked[ancestor] = true
+		}
+	}
+}
+
+// DeleteCategory removes a category without subcategories, its products are unlinked
+func (s *Product) DeleteCategory(ctx context.Context, id int32) error {
+	children, err := s.query.CountChildCategories(ctx, sql.NullInt32{Int32: id, Valid: true})
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return ErrCategoryHasChildren
+	}
+	deleted, err := s.query.DeleteCategory(ctx, id)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrCategoryNotFound
+	}
+	s.invalidateLists(ctx)
+	return nil
+}
+
+func (s *Product) ProductCategories(ctx context.Context, productID int32) ([]dto.CategoryResponse, error) {
+	categories, err := s.query.ListProductCategories(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]dto.CategoryResponse, 0, len(categories))
+	for _, c := range categories {
+		resp = append(resp, toCategoryResponse(c))
+	}
+	return resp, nil
+}
+
+// SetProductCategories replaces the categories of a product, an unknown
+// category leaves them as they were
+func (s *Product) SetProductCategories(ctx context.Context, productID int32, categoryIDs []int32) ([]dto.CategoryResponse, error) {
+	err := s.tx.WithTx(ctx, func(ctx context.Context, q *sqlc.Queries) error {
+		if _, err := q.LockProduct(ctx, productID); err != nil {
+			return productNotFound(err)
+		}
+		if err := q.DeleteProductCategories(ctx, productID); err != nil {
+			return err
+		}
+		if len(categoryIDs) == 0 {
+			return nil
+		}
+		err := q.AddProductCategories(ctx, sqlc.AddProductCategoriesParams{
+			ProductID:   productID,
+			CategoryIds: categoryIDs,
+		})
+		return categoryError(err)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateLists(ctx)
+	return s.ProductCategories(ctx, productID)
+}
+
+func (s *Product) ProductTags(ctx context.Context, productID int32) (dto.ProductTagsResponse, error) {
+	tags, err := s.query.ListProductTags(ctx, productID)
+	if err != nil {
+		return dto.ProductTagsResponse{}, err
+	}
+	if tags == nil {
+		tags = []string{}
+	}
+	return dto.ProductTagsResponse{Tags: tags}, nil
+}
+
+// SetProductTags replaces the tags of a product, tags are trimmed and lower cased
+func (s *Product) SetProductTags(ctx context.Context, productID int32, tags []string) (dto.ProductTagsResponse, error) {
+	normalized, err := normalizeTags(tags)
+	if err != nil {
+		return dto.ProductTagsResponse{}, err
+	}
+	err = s.tx.WithTx(ctx, func(ctx context.Context, q *sqlc.Queries) error {
+		if _, err := q.LockProduct(ctx, productID); err != nil {
+			return productNotFound(err)
+		}
+		if err := q.DeleteProductTags(ctx, productID); err != nil {
+			return err
+		}
+		if len(normalized) == 0 {
+			return nil
+		}
+		return q.AddProductTags(ctx, sqlc.AddProductTagsParams{
+			ProductID: productID,
+			Tags:      normalized,
+		})
+	})
+	if err != nil {
+		return dto.ProductTagsResponse{}, err
+	}
+	s.invalidateLists(ctx)
+	return dto.ProductTagsResponse{Tags: normalized}, nil
+}
+
+func toCategoryResponse(c sqlc.Category) dto.CategoryResponse {
+	resp := dto.CategoryResponse{
+		ID:        c.ID,
+		Name:      c.CategoryName,
+		Slug:      c.Slug,
+		CreatedAt: c.CreatedAt,
+	}
+	if c.ParentID.Valid {
+		parentID := c.ParentID.Int32
+		resp.ParentID = &parentID
+	}
+	return resp
+}
+
+// categoryError maps constraint violations to category errors
+func categoryError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrCategoryNotFound
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgUniqueViolation:
+			return ErrCategorySlugTaken
+		case pgForeignKeyViolation:
+			return ErrCategoryNotFound
+		}
+	}
+	return err
+}
+
+// categorySlug returns slug, or one made from name when slug is empty
+func categorySlug(slug, name string) (string, error) {
+	if strings.TrimSpace(slug) == "" {
+		slug = name
+	}
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(slug) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+	result := strings.TrimSuffix(b.String(), "-")
+	if result == "" {
+		return "", ErrInvalidSlug
+	}
+	return result, nil
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// normalizeTags normalizes, dedupes and sorts tags, empty tags are dropped
+func normalizeTags(tags []string) ([]string, error) {
+	seen := map[string]bool{}
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if len(tag) > maxTagLength {
+			return nil, ErrInvalidTag
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// Kind tells controllers which status code a service error maps to
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindNotFound
+	KindInvalid
+	KindConflict
+)
+
+// Error is an error the caller can act on, its Kind says how
+type Error struct {
+	Kind Kind
+	msg  string
+}
+
+func (e *Error) Error() string {
+	return e.msg
+}
+
+// NotFound is for a product, category or other resource that does not exist
+func NotFound(msg string) *Error {
+	return &Error{Kind: KindNotFound, msg: msg}
+}
+
+// Invalid is for a request that can never succeed as it is
+func Invalid(msg string) *Error {
+	return &Error{Kind: KindInvalid, msg: msg}
+}
+
+// Conflict is for a request that clashes with the current state
+func Conflict(msg string) *Error {
+	return &Error{Kind: KindConflict, msg: msg}
+}
+
+// KindOf returns the kind of the service error in err's chain, KindInternal when there is none
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return KindInternal
+}
+
+// productNotFound turns a missing row into ErrProductNotFound
+func productNotFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrProductNotFound
+	}
+	return err
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"go-worker/internal/outbox"
+	"go-worker/internal/storage/sql/sqlc"
+	"strconv"
+)
+
+// AggregateProduct is the aggregate type of product events
+const AggregateProduct = "product"
+
+// product events published through the outbox, their payload is the product
+// after the change. Restoring a product publishes ProductUpdated.
+const (
+	EventProductCreated = "ProductCreated"
+	EventProductUpdated = "ProductUpdated"
+	EventProductDeleted = "ProductDeleted"
+)
+
+// addEvent stores an event of a product change with q, the queries of the
+// transaction making the change
+func addEvent(ctx context.Context, q *sqlc.Queries, eventType string, p sqlc.Product) error {
+	return outbox.Add(ctx, q, AggregateProduct, strconv.Itoa(int(p.ID)), eventType, toSnapshot(p))
+}
+
+func addEvents(ctx context.Context, q *sqlc.Queries, eventType string, products []sqlc.Product) error {
+	for _, p := range products {
+		if err := addEvent(ctx, q, eventType, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"go-worker/internal/product/dto"
+	"go-worker/internal/storage/sql/sqlc"
+)
+
+// actions recorded in product_history
+const (
+	HistoryCreate  = "create"
+	HistoryUpdate  = "update"
+	HistoryDelete  = "delete"
+	HistoryRestore = "restore"
+)
+
+// recordHistory stores a change made by adminID with q, the queries of the
+// transaction making the change, before is nil for creates
+func recordHistory(ctx context.Context, q *sqlc.Queries, action, adminID string, before, after *sqlc.Product) error {
+	_, err := q.CreateProductHistory(ctx, sqlc.CreateProductHistoryParams{
+		ProductID: after.ID,
+		Action:    action,
+		AdminID:   adminID,
+		Before:    snapshotJSON(before),
+		After:     snapshotJSON(after),
+	})
+	return err
+}
+
+// History returns every recorded change of a product, newest first
+func (s *Product) History(ctx context.Context, id int32) (dto.AdminProductHistoryResponse, error) {
+	entries, err := s.query.ListProductHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make(dto.AdminProductHistoryResponse, 0, len(entries))
+	for _, e := range entries {
+		resp = append(resp, dto.ProductHistoryResponse{
+			ID:        e.ID,
+			ProductID: e.ProductID,
+			Action:    e.Action,
+			AdminID:   e.AdminID,
+			Before:    parseSnapshot(e.Before),
+			After:     parseSnapshot(e.After),
+			CreatedAt: e.CreatedAt,
+		})
+	}
+	return resp, nil
+}
+
+func toSnapshot(p sqlc.Product) dto.ProductSnapshot {
+	snapshot := dto.ProductSnapshot{
+		ID:          p.ID,
+		Name:        p.ProductName,
+		Description: p.ProductDescription,
+		Price:       p.Price,
+		IsActive:    p.IsActive,
+		Currency:    p.Currency,
+		CreatedAt:   p.CreatedAt,
+		Version:     p.Version,
+	}
+	if p.DeletedAt.Valid {
+		snapshot.DeletedAt = &p.DeletedAt.Time
+	}
+	return snapshot
+}
+
+// snapshotJSON encodes a missing product as JSON null
+func snapshotJSON(p *sqlc.Product) json.RawMessage {
+	if p == nil {
+		return json.RawMessage("null")
+	}
+	data, _ := json.Marshal(toSnapshot(*p))
+	return data
+}
+
+func parseSnapshot(data json.RawMessage) *dto.ProductSnapshot {
+	var snapshot *dto.ProductSnapshot
+	_ = json.Unmarshal(data, &snapshot)
+	return snapshot
+}
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go-worker/internal/product/dto"
+	"go-worker/internal/storage/cache"
+	"go-worker/internal/storage/sql/sqlc"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+	DefaultSort     = "created_at_desc"
+)
+
+var (
+	ErrInvalidCursor = Invalid("invalid cursor")
+	ErrInvalidSort   = Invalid("invalid sort")
+)
+
+// Sorts are the sort options accepted by ListProducts
+var Sorts = []string{"created_at_desc", "created_at_asc", "price_asc", "price_desc", "name_asc", "name_desc"}
+
+// cursor is the position after the last product of a page, in the sort it was made for
+type cursor struct {
+	Sort      string    `json:"s"`
+	ID        int32     `json:"i"`
+	CreatedAt time.Time `json:"c"`
+	Price     int64     `json:"p"`
+	Name      string    `json:"n"`
+}
+
+func encodeCursor(sort string, p sqlc.Product) string {
+	data, _ := json.Marshal(cursor{
+		Sort:      sort,
+		ID:        p.ID,
+		CreatedAt: p.CreatedAt,
+		Price:     p.Price,
+		Name:      p.ProductName,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(sort, value string) (*cursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Sort != sort {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// productPage is one page of a listing, it is cached per view before it is
+// turned into the response of that view
+type productPage struct {
+	Products   []sqlc.Product `json:"products"`
+	NextCursor string         `json:"next_cursor"`
+	Total      int64          `json:"total"`
+}
+
+// ListProducts returns one page of active products matching req and the
+// total number of matching products, req.Active is ignored
+func (s *Product) ListProducts(ctx context.Context, req dto.ListProductsRequest) (dto.ClientListProductsResponse, error) {
+	active := true
+	req.Active = &active
+	page, err := s.listPage(ctx, cache.ViewClient, req)
+	if err != nil {
+		return dto.ClientListProductsResponse{}, err
+	}
+	resp := dto.ClientListProductsResponse{
+		Products:   make([]dto.ProductResponse, 0, len(page.Products)),
+		NextCursor: page.NextCursor,
+		Total:      page.Total,
+	}
+	for _, product := range page.Products {
+		resp.Products = append(resp.Products, toClientResponse(product))
+	}
+	return resp, nil
+}
+
+// AdminListProducts returns one page of products matching req, active or not
+func (s *Product) AdminListProducts(ctx context.Context, req dto.ListProductsRequest) (dto.AdminListProductsResponse, error) {
+	page, err := s.listPage(ctx, cache.ViewAdmin, req)
+	if err != nil {
+		return dto.AdminListProductsResponse{}, err
+	}
+	resp := dto.AdminListProductsResponse{
+		Products:   make([]dto.AdminProductResponse, 0, len(page.Products)),
+		NextCursor: page.NextCursor,
+		Total:      page.Total,
+	}
+	for _, product := range page.Products {
+		resp.Products = append(resp.Products, toAdminResponse(product))
+	}
+	return resp, nil
+}
+
+// listPage reads one page of a listing for view. Pages are cached per view
+// and query shape until a product is created, updated or deleted.
+func (s *Product) listPage(ctx context.Context, view string, req dto.ListProductsRequest) (productPage, error) {
+	pageKey, load, err := s.pageLoader(view, req)
+	if err != nil {
+		return productPage{}, err
+	}
+	var page productPage
+	err = s.memory.GetOrLoad(ctx, pageKey, &page, s.memory.DefaultTTL(), load,
+		cache.WithStale(cacheStale), cache.WithTags(cache.TagProducts))
+	return page, err
+}
+
+// pageLoader returns the key of a page of a listing and how to read it
+func (s *Product) pageLoader(view string, req dto.ListProductsRequest) (string, cache.Loader, error) {
+	req, err := normalizeListRequest(req)
+	if err != nil {
+		return "", nil, err
+	}
+	after, err := decodeCursor(req.Sort, req.Cursor)
+	if err != nil {
+		return "", nil, err
+	}
+	return s.memory.KeyProductsPage(view, shape(req)), func(ctx context.Context) (interface{}, error) {
+		return s.loadPage(ctx, view, req, after)
+	}, nil
+}
+
+// loadPage reads one page of a listing and its total from the database
+func (s *Product) loadPage(ctx context.Context, view string, req dto.ListProductsRequest, after *cursor) (productPage, error) {
+	filters := sqlc.CountProductsParams{
+		IsActive:   nullBool(req.Active),
+		MinPrice:   nullInt64(req.MinPrice),
+		MaxPrice:   nullInt64(req.MaxPrice),
+		Name:       nullString(nonEmpty(escapeLike(req.Name))),
+		CategoryID: nullInt32(req.CategoryID),
+		Tag:        nullString(nonEmpty(req.Tag)),
+	}
+	var page productPage
+	var err error
+	// one extra row tells whether there is a next page
+	page.Products, err = s.listSorted(ctx, req.Sort, filters, after, int32(req.Limit+1))
+	if err != nil {
+		return productPage{}, err
+	}
+	if len(page.Products) > req.Limit {
+		page.Products = page.Products[:req.Limit]
+		page.NextCursor = encodeCursor(req.Sort, page.Products[len(page.Products)-1])
+	}
+
+	page.Total, err = s.countProducts(ctx, view, req, filters)
+	if err != nil {
+		return productPage{}, err
+	}
+	return page, nil
+}
+
+// listSorted reads up to pageSize products matching filters after the cursor,
+// with the query of sort so the database walks the index of its column
+func (s *Product) listSorted(ctx context.Context, sort string, filters sqlc.CountProductsParams, after *cursor, pageSize int32) ([]sqlc.Product, error) {
+	var id sql.NullInt32
+	var createdAt sql.NullTime
+	var price sql.NullInt64
+	var name sql.NullString
+	if after != nil {
+		id = sql.NullInt32{Int32: after.ID, Valid: true}
+		createdAt = sql.NullTime{Time: after.CreatedAt, Valid: true}
+		price = sql.NullInt64{Int64: after.Price, Valid: true}
+		name = sql.NullString{String: after.Name, Valid: true}
+	}
+
+	switch sort {
+	case "created_at_asc":
+		return s.query.ListProductsByCreatedAtAsc(ctx, sqlc.ListProductsByCreatedAtAscParams{
+			IsActive: filters.IsActive, MinPrice: filters.MinPrice, MaxPrice: filters.MaxPrice,
+			Name: filters.Name, CategoryID: filters.CategoryID, Tag: filters.Tag,
+			CursorID: id, CursorCreatedAt: createdAt, PageSize: pageSize,
+		})
+	case "price_asc":
+		return s.query.ListProductsByPriceAsc(ctx, sqlc.ListProductsByPriceAscParams{
+			IsActive: filters.IsActive, MinPrice: filters.MinPrice, MaxPrice: filters.MaxPrice,
+			Name: filters.Name, CategoryID: filters.CategoryID, Tag: filters.Tag,
+			CursorID: id, CursorPrice: price, PageSize: pageSize,
+		})
+	case "price_desc":
+		return s.query.ListProductsByPriceDesc(ctx, sqlc.ListProductsByPriceDescParams{
+			IsActive: filters.IsActive, MinPrice: filters.MinPrice, MaxPrice: filters.MaxPrice,
+			Name: filters.Name, CategoryID: filters.CategoryID, Tag: filters.Tag,
+			CursorID: id, CursorPrice: price, PageSize: pageSize,
+		})
+	case "name_asc":
+		return s.query.ListProductsByNameAsc(ctx, sqlc.ListProductsByNameAscParams{
+			IsActive: filters.IsActive, MinPrice: filters.MinPrice, MaxPrice: filters.MaxPrice,
+			Name: filters.Name, CategoryID: filters.CategoryID, Tag: filters.Tag,
+			CursorID: id, CursorName: name, PageSize: pageSize,
+		})
+	case "name_desc":
+		return s.query.ListProductsByNameDesc(ctx, sqlc.ListProductsByNameDescParams{
+			IsActive: filters.IsActive, MinPrice: filters.MinPrice, MaxPrice: filters.MaxPrice,
+			Name: filters.Name, CategoryID: filters.CategoryID, Tag: filters.Tag,
+			CursorID: id, CursorName: name, PageSize: pageSize,
+		})
+	default:
+		return s.query.ListProductsByCreatedAtDesc(ctx, sqlc.ListProductsByCreatedAtDescParams{
+			IsActive: filters.IsActive, MinPrice: filters.MinPrice, MaxPrice: filters.MaxPrice,
+			Name: filters.Name, CategoryID: filters.CategoryID, Tag: filters.Tag,
+			CursorID: id, CursorCreatedAt: createdAt, PageSize: pageSize,
+		})
+	}
+}
+
+// countProducts caches the total per filter so paging through a listing counts once
+func (s *Product) countProducts(ctx context.Context, view string, req dto.ListProductsRequest, filters sqlc.CountProductsParams) (int64, error) {
+	req.Cursor, req.Limit, req.Sort = "", 0, ""
+	key := s.memory.KeyProductsCount(view, shape(req))
+
+	var total int64
+	err := s.memory.GetOrLoad(ctx, key, &total, s.memory.DefaultTTL(), func(ctx context.Context) (interface{}, error) {
+		return s.query.CountProducts(ctx, filters)
+	}, cache.WithStale(cacheStale), cache.WithTags(cache.TagProducts))
+	return total, err
+}
+
+// invalidateLists drops every cached listing, search and count
+func (s *Product) invalidateLists(ctx context.Context) {
+	if err := s.memory.InvalidateTag(ctx, cache.TagProducts); err != nil {
+		s.log.Warn("Could not invalidate product listings", zap.Error(err))
+	}
+}
+
+func normalizeListRequest(req dto.ListProductsRequest) (dto.ListProductsRequest, error) {
+	if req.Limit <= 0 {
+		req.Limit = DefaultPageSize
+	}
+	if req.Limit > MaxPageSize {
+		req.Limit = MaxPageSize
+	}
+	if req.Sort == "" {
+		req.Sort = DefaultSort
+	}
+	valid := false
+	for _, sort := range Sorts {
+		valid = valid || sort == req.Sort
+	}
+	if !valid {
+		return req, fmt.Errorf("%w %q, expected one of: %s", ErrInvalidSort, req.Sort, strings.Join(Sorts, ", "))
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	// tags are stored normalized
+	req.Tag = normalizeTag(req.Tag)
+	return req, nil
+}
+
+// shape identifies a normalized request in cache keys
+func shape(req any) string {
+	data, _ := json.Marshal(req)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16])
+}
+
+// escapeLike stops % and _ in a name filter from acting as wildcards
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// nonEmpty returns nil for an empty filter
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func nullBool(b *bool) sql.NullBool {
+	if b == nil {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: *b, Valid: true}
+}
+
+func nullInt32(i *int32) sql.NullInt32 {
+	if i == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *i, Valid: true}
+}
+
+func nullInt64(i *int64) sql.NullInt64 {
+	if i == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *i, Valid: true}
+}
+
+func nullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go-worker/internal/product/dto"
+	"go-worker/internal/storage/sql/sqlc"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// DefaultCurrency is the currency of products created without one
+const DefaultCurrency = "USD"
+
+// actions recorded in product_price_history
+const (
+	PriceAdd    = "add"
+	PriceRemove = "remove"
+)
+
+var (
+	ErrInvalidCurrency = Invalid("currency must be an ISO 4217 code such as USD")
+	ErrInvalidAmount   = Invalid("price amount cannot be negative")
+	ErrInvalidValidity = Invalid("valid_to must be after valid_from")
+	ErrPriceOverlap    = Conflict("product already has a price in this currency for that period")
+	ErrPriceNotFound   = NotFound("price not found")
+)
+
+// ProductPrices returns the prices of a product, only the ones in effect now when current is set
+func (s *Product) ProductPrices(ctx context.Context, productID int32, current bool) ([]dto.PriceResponse, error) {
+	if _, err := s.query.GetProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+	var prices []sqlc.ProductPrice
+	var err error
+	if current {
+		prices, err = s.query.ListCurrentProductPrices(ctx, sqlc.ListCurrentProductPricesParams{
+			ProductID: productID,
+			At:        time.Now().UTC(),
+		})
+	} else {
+		prices, err = s.query.ListProductPrices(ctx, productID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]dto.PriceResponse, 0, len(prices))
+	for _, p := range prices {
+		resp = append(resp, toPriceResponse(p))
+	}
+	return resp, nil
+}
+
+// AddProductPrice schedules a price, it cannot overlap another price of the same currency
+func (s *Product) AddProductPrice(ctx context.Context, adminID string, req dto.AdminCreatePriceRequest) (dto.PriceResponse, error) {
+	currency, err := normalizeCurrency(req.Price.Currency)
+	if err != nil {
+		return dto.PriceResponse{}, err
+	}
+	if req.Price.Amount < 0 {
+		return dto.PriceResponse{}, ErrInvalidAmount
+	}
+	validFrom := time.Now().UTC()
+	if req.ValidFrom != nil {
+		validFrom = req.ValidFrom.UTC()
+	}
+	validTo := sql.NullTime{}
+	if req.ValidTo != nil {
+		if !req.ValidTo.After(validFrom) {
+			return dto.PriceResponse{}, ErrInvalidValidity
+		}
+		validTo = sql.NullTime{Time: req.ValidTo.UTC(), Valid: true}
+	}
+
+	var price sqlc.ProductPrice
+	err = s.tx.WithTx(ctx, func(ctx context.Context, q *sqlc.Queries) error {
+		if _, err := q.GetProduct(ctx, req.ProductID); err != nil {
+			return productNotFound(err)
+		}
+		var err error
+		price, err = q.CreateProductPrice(ctx, sqlc.CreateProductPriceParams{
+			ProductID: req.ProductID,
+			Currency:  currency,
+			Amount:    req.Price.Amount,
+			ValidFrom: validFrom,
+			ValidTo:   validTo,
+		})
+		if err != nil {
+			return priceError(err)
+		}
+		return recordPriceHistory(ctx, q, PriceAdd, adminID, price)
+	})
+	if err != nil {
+		return dto.PriceResponse{}, err
+	}
+	s.log.Info("Product price added", zap.Int32("product_id", price.ProductID), zap.Int64("id", price.ID))
+	return toPriceResponse(price), nil
+}
+
+func (s *Product) DeleteProductPrice(ctx context.Context, adminID string, productID int32, priceID int64) error {
+	return s.tx.WithTx(ctx, func(ctx context.Context, q *sqlc.Queries) error {
+		price, err := q.DeleteProductPrice(ctx, sqlc.DeleteProductPriceParams{
+			ID:        priceID,
+			ProductID: productID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPriceNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return recordPriceHistory(ctx, q, PriceRemove, adminID, price)
+	})
+}
+
+// PriceHistory returns every price added to or removed from a product, newest first
+func (s *Product) PriceHistory(ctx context.Context, productID int32) ([]dto.PriceHistoryResponse, error) {
+	entries, err := s.query.ListPriceHistory(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]dto.PriceHistoryResponse, 0, len(entries))
+	for _, e := range entries {
+		resp = append(resp, dto.PriceHistoryResponse{
+			ID:        e.ID,
+			ProductID: e.ProductID,
+			PriceID:   e.PriceID,
+			Action:    e.Action,
+			AdminID:   e.AdminID,
+			Price:     dto.Money{Currency: e.Currency, Amount: e.Amount},
+			ValidFrom: e.ValidFrom,
+			ValidTo:   timePtr(e.ValidTo),
+			CreatedAt: e.CreatedAt,
+		})
+	}
+	return resp, nil
+}
+
+// recordPriceHistory stores a price change made by adminID with q, the
+// queries of the transaction making the change
+func recordPriceHistory(ctx context.Context, q *sqlc.Queries, action, adminID string, price sqlc.ProductPrice) error {
+	return q.CreatePriceHistory(ctx, sqlc.CreatePriceHistoryParams{
+		ProductID: price.ProductID,
+		PriceID:   price.ID,
+		Action:    action,
+		AdminID:   adminID,
+		Currency:  price.Currency,
+		Amount:    price.Amount,
+		ValidFrom: price.ValidFrom,
+		ValidTo:   price.ValidTo,
+	})
+}
+
+// priceError maps the overlap constraint to ErrPriceOverlap
+func priceError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgExclusionViolation {
+		return ErrPriceOverlap
+	}
+	return err
+}
+
+func toPriceResponse(p sqlc.ProductPrice) dto.PriceResponse {
+	return dto.PriceResponse{
+		ID:        p.ID,
+		ProductID: p.ProductID,
+		Price:     dto.Money{Currency: p.Currency, Amount: p.Amount},
+		ValidFrom: p.ValidFrom,
+		ValidTo:   timePtr(p.ValidTo),
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+// timePtr returns nil for a NULL time
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// normalizeCurrency upper cases code and checks it looks like an ISO 4217 code
+func normalizeCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return "", ErrInvalidCurrency
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", ErrInvalidCurrency
+		}
+	}
+	return code, nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"go-worker/internal/product/dto"
+	"go-worker/internal/storage/cache"
+	"go-worker/internal/storage/sql/sqlc"
+	"strings"
+	"unicode"
+)
+
+var ErrEmptySearchQuery = Invalid("search query has no words")
+
+// searchCursor is the offset of the next page of a search
+type searchCursor struct {
+	Query  string `json:"q"`
+	Offset int    `json:"o"`
+}
+
+func decodeSearchCursor(query, value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	var c searchCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Query != query || c.Offset < 0 {
+		return 0, ErrInvalidCursor
+	}
+	return c.Offset, nil
+}
+
+func encodeSearchCursor(query string, offset int) string {
+	data, _ := json.Marshal(searchCursor{Query: query, Offset: offset})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// tsQuery turns free text into a to_tsquery expression where every word
+// has to match, as a whole word or as a prefix
+func tsQuery(text string) (string, error) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return "", ErrEmptySearchQuery
+	}
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & "), nil
+}
+
+// SearchProducts returns one page of active products whose name or
+// description match req.Query, best matches first
+func (s *Product) SearchProducts(ctx context.Context, req dto.SearchProductsRequest) (dto.SearchProductsResponse, error) {
+	query, err := tsQuery(req.Query)
+	if err != nil {
+		return dto.SearchProductsResponse{}, err
+	}
+	offset, err := decodeSearchCursor(query, req.Cursor)
+	if err != nil {
+		return dto.SearchProductsResponse{}, err
+	}
+	if req.Limit <= 0 {
+		req.Limit = DefaultPageSize
+	}
+	if req.Limit > MaxPageSize {
+		req.Limit = MaxPageSize
+	}
+	req.Query = query
+
+	key := s.memory.KeyProductsSearch(shape(req))
+
+	var resp dto.SearchProductsResponse
+	if err := s.memory.Get(ctx, key, &resp); err == nil {
+		return resp, nil
+	}
+
+	rows, err := s.query.SearchProducts(ctx, sqlc.SearchProductsParams{
+		Query: query,
+		// one extra row tells whether there is a next page
+		PageSize:   int32(req.Limit + 1),
+		PageOffset: int32(offset),
+	})
+	if err != nil {
+		return dto.SearchProductsResponse{}, err
+	}
+	if len(rows) > req.Limit {
+		rows = rows[:req.Limit]
+		resp.NextCursor = encodeSearchCursor(query, offset+req.Limit)
+	}
+
+	resp.Products = make([]dto.SearchProductResponse, 0, len(rows))
+	for _, row := range rows {
+		resp.Products = append(resp.Products, dto.SearchProductResponse{
+			ProductResponse: dto.ProductResponse{
+				ID:          row.ID,
+				Name:        row.ProductName,
+				Description: row.ProductDescription,
+				Price:       row.Price,
+				Currency:    row.Currency,
+			},
+			Rank:                 row.Rank,
+			NameHighlight:        row.NameHighlight,
+			DescriptionHighlight: row.DescriptionHighlight,
+		})
+	}
+
+	countKey := s.memory.KeyProductsCount(cache.ViewClient, shape(searchCursor{Query: query}))
+	if err := s.memory.Get(ctx, countKey, &resp.Total); err != nil {
+		resp.Total, err = s.query.CountSearchProducts(ctx, query)
+		if err != nil {
+			return dto.SearchProductsResponse{}, err
+		}
+		s.memory.Set(ctx, countKey, resp.Total, s.memory.DefaultTTL(), cache.TagProducts)
+	}
+
+	s.memory.Set(ctx, key, resp, s.memory.DefaultTTL(), cache.TagProducts)
+	return resp, nil
+}
@@ -3,119 +3,320 @@ package service
 import (
 	"context"
 	"go-worker/internal/config"
+	"go-worker/internal/poller/dispatcher"
 	"go-worker/internal/product/dto"
 	"go-worker/internal/storage/cache"
 	"go-worker/internal/storage/sql/sqlc"
+	"go-worker/internal/storage/sql/tx"
+	"time"
 
 	"go.uber.org/zap"
 )
 
+var (
+	ErrProductNotFound = NotFound("product not found")
+	ErrVersionConflict = Conflict("product was changed since the given version")
+)
+
+const (
+	// cacheStale is how long an expired product read is still served while it is refreshed
+	cacheStale = time.Minute
+	// notFoundTTL is how long a missing product is remembered
+	notFoundTTL = 30 * time.Second
+)
+
 type Product struct {
-	query  *sqlc.Queries
-	log    *zap.Logger
-	memory *cache.Store
-	cfg    *config.Config
+	query      *sqlc.Queries
+	tx         *tx.Manager // writes a change, its history and its event at once
+	log        *zap.Logger
+	memory     *cache.Store
+	cfg        *config.Config
+	dispatcher *dispatcher.Service
 }
 
 func New(q *sqlc.Queries,
+	tx *tx.Manager,
 	log *zap.Logger,
 	memory *cache.Store,
-	cfg *config.Config) *Product {
+	cfg *config.Config,
+	dispatcher *dispatcher.Service) *Product {
 	return &Product{
-		query:  q,
-		log:    log,
-		memory: memory,
-		cfg:    cfg,
+		query:      q,
+		tx:         tx,
+		log:        log,
+		memory:     memory,
+		cfg:        cfg,
+		dispatcher: dispatcher,
 	}
 }
 
-func (s *Product) Create(ctx context.Context, req dto.AdminCreateProductRequest) (dto.ProductResponse, error) {
+func (s *Product) Create(ctx context.Context, adminID string, req dto.AdminCreateProductRequest) (dto.AdminProductResponse, error) {
+	currency := DefaultCurrency
+	if req.Currency != "" {
+		var err error
+		if currency, err = normalizeCurrency(req.Currency); err != nil {
+			return dto.AdminProductResponse{}, err
+		}
+	}
 	arg := sqlc.CreateProductParams{
 		ProductName:        req.Name,
 		ProductDescription: req.Description,
 		Price:              req.Price,
 		IsActive:           true,
+		Currency:           currency,
 	}
-	product, err := s.query.CreateProduct(ctx, arg)
+	var product sqlc.Product
+	err := s.tx.WithTx(ctx, func(ctx context.Context, q *sqlc.Queries) error {
+		var err error
+		if product, err = q.CreateProduct(ctx, arg); err != nil {
+			return err
+		}
+		if err := recordHistory(ctx, q, HistoryCreate, adminID, nil, &product); err != nil {
+			return err
+		}
+		return addEvent(ctx, q, EventProductCreated, product)
+	})
 	if err != nil {
-		return dto.ProductResponse{}, err
+		return dto.AdminProductResponse{}, err
 	}
 	s.log.Info("Product created", zap.Int32("id", product.ID))
-	s.memory.Set(ctx, s.memory.KeyProduct(product.ID), product, s.cfg.Redis.DefaultTTL)
-	s.memory.Delete(ctx, s.memory.KeyAllProducts())
-	return dto.ProductResponse{
-		ID:          product.ID,
-		Name:        product.ProductName,
-		Description: product.ProductDescription,
-		Price:       product.Price,
-	}, nil
+	s.cacheProduct(ctx, product)
+	s.invalidateLists(ctx)
+	return toAdminResponse(product), nil
 }
 
-func (s *Product) Update(ctx context.Context, req dto.AdminUpdateProductRequest) (dto.ProductResponse, error) {
-	arg := sqlc.UpdateProductParams{
-		ID:                 int32(req.ID),
-		ProductName:        req.Name,
-		ProductDescription: req.Description,
-		Price:              req.Price,
-		IsActive:           req.IsActive,
+func (s *Product) Update(ctx context.Context, adminID string, req dto.AdminUpdateProductRequest) (dto.AdminProductResponse, error) {
+	var currency string
+	if req.Currency != "" {
+		var err error
+		if currency, err = normalizeCurrency(req.Currency); err != nil {
+			return dto.AdminProductResponse{}, err
+		}
 	}
-	product, err := s.query.UpdateProduct(ctx, arg)
+	var product sqlc.Product
+	err := s.tx.WithTx(ctx, func(ctx context.Context, q *sqlc.Queries) error {
+		// locked so history records the state this change replaces
+		before, err := q.LockProduct(ctx, int32(req.ID))
+		if err != nil {
+			return productNotFound(err)
+		}
+		if req.Version != 0 && req.Version != before.Version {
+			return ErrVersionConflict
+		}
+		if currency == "" {
+			currency = before.Currency
+		}
+		product, err = q.UpdateProduct(ctx, sqlc.UpdateProductParams{
+			ID:                 int32(req.ID),
+			ProductName:        req.Name,
+			ProductDescription: req.Description,
+			Price:              req.Price,
+			IsActive:           req.IsActive,
+			Currency:           currency,
+			ExpectedVersion:    req.Version,
+		})
+		if err != nil {
+			return err
+		}
+		if err := recordHistory(ctx, q, HistoryUpdate, adminID, &before, &product); err != nil {
+			return err
+		}
+		return addEvent(ctx, q, EventProductUpdated, product)
+	})
 	if err != nil {
-		return dto.ProductResponse{}, err
+		return dto.AdminProductResponse{}, err
 	}
-	s.memory.Set(ctx, s.memory.KeyProduct(product.ID), product, s.cfg.Redis.DefaultTTL)
-	s.memory.Delete(ctx, s.memory.KeyAllProducts())
-	return dto.ProductResponse{
-		ID:          product.ID,
-		Name:        product.ProductName,
-		Description: product.ProductDescription,
-		Price:       product.Price,
-	}, nil
+	s.cacheProduct(ctx, product)
+	s.invalidateLists(ctx)
+	return toAdminResponse(product), nil
 }
 
-func (s *Product) Delete(ctx context.Context, id int32) error {
-	s.memory.Delete(ctx, s.memory.KeyProduct(id))
-	s.memory.Delete(ctx, s.memory.KeyAllProducts())
-	return s.query.DeleteProduct(ctx, id)
+// Patch changes the fields set in req and leaves the others as they are
+func (s *Product) Patch(ctx context.Context, adminID string, req dto.AdminPatchProductRequest) (dto.AdminProductResponse, error) {
+	if req.Currency != nil {
+		currency, err := normalizeCurrency(*req.Currency)
+		if err != nil {
+			return dto.AdminProductResponse{}, err
+		}
+		req.Currency = &currency
+	}
+	if req.Name == nil && req.Description == nil && req.Price == nil && req.IsActive == nil && req.Currency == nil {
+		product, err := s.query.GetProduct(ctx, req.ID)
+		if err != nil {
+			return dto.AdminProductResponse{}, productNotFound(err)
+		}
+		if req.Version != 0 && req.Version != product.Version {
+			return dto.AdminProductResponse{}, ErrVersionConflict
+		}
+		return toAdminResponse(product), nil
+	}
+
+	var product sqlc.Product
+	err := s.tx.WithTx(ctx, func(ctx context.Context, q *sqlc.Queries) error {
+		// locked so history records the state this change replaces
+		before, err := q.LockProduct(ctx, req.ID)
+		if err != nil {
+			return productNotFound(err)
+		}
+		if req.Version != 0 && req.Version != before.Version {
+			return ErrVersionConflict
+		}
+		product, err = q.PatchProduct(ctx, sqlc.PatchProductParams{
+			ProductName:        nullString(req.Name),
+			ProductDescription: nullString(req.Description),
+			Price:              nullInt64(req.Price),
+			IsActive:           nullBool(req.IsActive),
+			Currency:           nullString(req.Currency),
+			ID:                 req.ID,
+			ExpectedVersion:    req.Version,
+		})
+		if err != nil {
+			return err
+		}
+		if err := recordHistory(ctx, q, HistoryUpdate, adminID, &before, &product); err != nil {
+			return err
+		}
+		return addEvent(ctx, q, EventProductUpdated, product)
+	})
+	if err != nil {
+		return dto.AdminProductResponse{}, err
+	}
+	s.cacheProduct(ctx, product)
+	s.invalidateLists(ctx)
+	return toAdminResponse(product), nil
 }
 
+// Delete hides a product until it is restored
+func (s *Product) Delete(ctx context.Context, adminID string, id int32) error {
+	err := s.tx.WithTx(ctx, func(ctx context.Context, q *sqlc.Queries) error {
+		before, err := q.LockProduct(ctx, id)
+		if err != nil {
+			return productNotFound(err)
+		}
+		product, err := q.DeleteProduct(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := recordHistory(ctx, q, HistoryDelete, adminID, &before, &product); err != nil {
+			return err
+		}
+		return addEvent(ctx, q, EventProductDeleted, product)
+	})
+	if err != nil {
+		return err
+	}
+	// after the commit, so a concurrent read cannot cache the product again
+	s.forgetProduct(ctx, id)
+	s.invalidateLists(ctx)
+	return nil
+}
+
+// Restore brings back a deleted product
+func (s *Product) Restore(ctx context.Context, adminID string, id int32) (dto.AdminProductResponse, error) {
+	var product sqlc.Product
+	err := s.tx.WithTx(ctx, func(ctx context.Context, q *sqlc.Queries) error {
+		before, err := q.LockDeletedProduct(ctx, id)
+		if err != nil {
+			return productNotFound(err)
+		}
+		if product, err = q.RestoreProduct(ctx, id); err != nil {
+			return err
+		}
+		if err := recordHistory(ctx, q, HistoryRestore, adminID, &before, &product); err != nil {
+			return err
+		}
+		return addEvent(ctx, q, EventProductUpdated, product)
+	})
+	if err != nil {
+		return dto.AdminProductResponse{}, err
+	}
+	s.cacheProduct(ctx, product)
+	s.invalidateLists(ctx)
+	return toAdminResponse(product), nil
+}
+
+// GetProductByID returns an active product, inactive products are not found
 func (s *Product) GetProductByID(ctx context.Context, id int32) (dto.ProductResponse, error) {
 	var product sqlc.Product
-	err := s.memory.Get(ctx, s.memory.KeyProduct(id), &product)
+	err := s.memory.GetOrLoad(ctx, s.memory.KeyProduct(cache.ViewClient, id), &product, s.memory.DefaultTTL(),
+		s.productLoader(cache.ViewClient, id), productLoadOptions(id)...)
+	if err != nil {
+		return dto.ProductResponse{}, err
+	}
+	return toClientResponse(product), nil
+}
+
+// AdminGetProductByID returns a product whether it is active or not
+func (s *Product) AdminGetProductByID(ctx context.Context, id int32) (dto.AdminProductResponse, error) {
+	var product sqlc.Product
+	err := s.memory.GetOrLoad(ctx, s.memory.KeyProduct(cache.ViewAdmin, id), &product, s.memory.DefaultTTL(),
+		s.productLoader(cache.ViewAdmin, id), productLoadOptions(id)...)
 	if err != nil {
-		product, err = s.query.GetProduct(ctx, id)
+		return dto.AdminProductResponse{}, err
+	}
+	return toAdminResponse(product), nil
+}
+
+// productLoader reads a product as view sees it, clients do not see inactive products
+func (s *Product) productLoader(view string, id int32) cache.Loader {
+	return func(ctx context.Context) (interface{}, error) {
+		product, err := s.query.GetProduct(ctx, id)
 		if err != nil {
-			return dto.ProductResponse{}, err
+			return nil, productNotFound(err)
+		}
+		if view == cache.ViewClient && !product.IsActive {
+			return nil, ErrProductNotFound
 		}
-		s.memory.Set(ctx, s.memory.KeyProduct(product.ID), product, s.cfg.Redis.DefaultTTL)
+		return product, nil
 	}
-	result := dto.ProductResponse{
-		ID:          product.ID,
-		Name:        product.ProductName,
-		Description: product.ProductDescription,
-		Price:       product.Price,
+}
+
+func productLoadOptions(id int32) []cache.LoadOption {
+	return []cache.LoadOption{
+		cache.WithStale(cacheStale),
+		cache.WithTags(cache.TagProduct(id)),
+		cache.WithNegative(notFoundTTL, ErrProductNotFound),
 	}
-	return result, nil
 }
 
-func (s *Product) ListProducts(ctx context.Context) (dto.ClientListProductsResponse, error) {
-	var resp []dto.ProductResponse
-	if err := s.memory.Get(ctx, s.memory.KeyAllProducts(), &resp); err == nil {
-		return resp, nil
+// cacheProduct stores a changed product in the views that can see it
+func (s *Product) cacheProduct(ctx context.Context, product sqlc.Product) {
+	tag := cache.TagProduct(product.ID)
+	s.memory.Set(ctx, s.memory.KeyProduct(cache.ViewAdmin, product.ID), product, s.memory.DefaultTTL(), tag)
+	if product.IsActive {
+		s.memory.Set(ctx, s.memory.KeyProduct(cache.ViewClient, product.ID), product, s.memory.DefaultTTL(), tag)
+	} else {
+		s.memory.Delete(ctx, s.memory.KeyProduct(cache.ViewClient, product.ID))
 	}
-	products, err := s.query.ListProducts(ctx)
-	if err != nil {
-		return nil, err
-	}
-	resp = make([]dto.ProductResponse, 0, len(products))
-	for _, product := range products {
-		resp = append(resp, dto.ProductResponse{
-			ID:          product.ID,
-			Name:        product.ProductName,
-			Description: product.ProductDescription,
-			Price:       product.Price,
-		})
+}
+
+// forgetProduct drops a product from every view
+func (s *Product) forgetProduct(ctx context.Context, id int32) {
+	if err := s.memory.InvalidateTag(ctx, cache.TagProduct(id)); err != nil {
+		s.log.Warn("Could not invalidate product", zap.Int32("id", id), zap.Error(err))
+	}
+}
+
+func toAdminResponse(p sqlc.Product) dto.AdminProductResponse {
+	return dto.AdminProductResponse{
+		ID:          p.ID,
+		Name:        p.ProductName,
+		Description: p.ProductDescription,
+		Price:       p.Price,
+		IsActive:    p.IsActive,
+		Currency:    p.Currency,
+		CreatedAt:   p.CreatedAt,
+		Version:     p.Version,
+	}
+}
+
+func toClientResponse(p sqlc.Product) dto.ProductResponse {
+	return dto.ProductResponse{
+		ID:          p.ID,
+		Name:        p.ProductName,
+		Description: p.ProductDescription,
+		Price:       p.Price,
+		Currency:    p.Currency,
+		Version:     p.Version,
 	}
-	s.memory.Set(ctx, s.memory.KeyAllProducts(), resp, s.cfg.Redis.DefaultTTL)
-	return resp, nil
 }
@@ -0,0 +1,496 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-worker/internal/poller/job"
+	"go-worker/internal/product/dto"
+	"go-worker/internal/storage/sql/sqlc"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ImportService is the dispatcher service that runs product imports
+const ImportService = "product_import"
+
+// formats of import and export files
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// import statuses
+const (
+	ImportQueued  = "queued"
+	ImportRunning = "running"
+	ImportDone    = "done"
+	ImportFailed  = "failed"
+)
+
+const (
+	// importBatchSize is how many rows are written with a single statement
+	importBatchSize = 500
+	// exportBatchSize is how many products are read at a time while exporting
+	exportBatchSize = 500
+	// maxImportErrors caps the row errors kept in an import status
+	maxImportErrors = 1000
+	// importStatusTTL is how long an import status is kept, in minutes
+	importStatusTTL = 24 * 60
+)
+
+var (
+	ErrUnknownFormat  = Invalid("unknown format, use csv or jsonl")
+	ErrImportNotFound = NotFound("import not found")
+)
+
+// csvColumns is the header of CSV files, id, is_active and currency are optional on import
+var csvColumns = []string{"id", "name", "description", "price", "is_active", "currency"}
+
+// importJob runs an uploaded file through the product_import dispatcher service
+type importJob struct {
+	job.BaseJob
+	svc      *Product
+	importID string
+	adminID  string // recorded in the history of every imported product
+	format   string
+	data     []byte
+}
+
+func (j *importJob) Execute(ctx context.Context) error {
+	return j.svc.runImport(ctx, j)
+}
+
+// Import queues data for import by adminID on behalf of tenantID and returns
+// its status, the import itself runs as a job of the product_import service
+func (s *Product) Import(ctx context.Context, adminID, tenantID, format string, data []byte) (dto.ProductImportResponse, error) {
+	if format != FormatCSV && format != FormatJSONL {
+		return dto.ProductImportResponse{}, ErrUnknownFormat
+	}
+	id, err := newImportID()
+	if err != nil {
+		return dto.ProductImportResponse{}, err
+	}
+
+	status := dto.ProductImportResponse{
+		ID:        id,
+		Tenant:    tenantID,
+		Format:    format,
+		Status:    ImportQueued,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.memory.Set(ctx, s.memory.KeyProductImport(id), status, importStatusTTL); err != nil {
+		return dto.ProductImportResponse{}, err
+	}
+
+	j := &importJob{
+		BaseJob: job.BaseJob{
+			JobID:       "import-" + id,
+			ServiceName: ImportService,
+			TenantID:    tenantID,
+			CreatedAt:   status.CreatedAt,
+		},
+		svc:      s,
+		importID: id,
+		adminID:  adminID,
+		format:   format,
+		data:     data,
+	}
+	if err := s.dispatcher.Dispatch(j); err != nil {
+		s.memory.Delete(ctx, s.memory.KeyProductImport(id))
+		return dto.ProductImportResponse{}, err
+	}
+	s.log.Info("Product import queued", zap.String("id", id), zap.String("tenant", tenantID))
+	return status, nil
+}
+
+// ImportStatus returns an import, tenants only see their own imports
+func (s *Product) ImportStatus(ctx context.Context, tenantID, id string) (dto.ProductImportResponse, error) {
+	var status dto.ProductImportResponse
+	if err := s.memory.Get(ctx, s.memory.KeyProductImport(id), &status); err != nil {
+		return dto.ProductImportResponse{}, ErrImportNotFound
+	}
+	if tenantID != "" && status.Tenant != tenantID {
+		return dto.ProductImportResponse{}, ErrImportNotFound
+	}
+	return status, nil
+}
+
+// runImport validates every row and writes them in batches. Rejected rows are
+// reported in the status and do not stop the import, batches already written
+// are kept when a later one fails.
+func (s *Product) runImport(ctx context.Context, j *importJob) error {
+	key := s.memory.KeyProductImport(j.importID)
+	var status dto.ProductImportResponse
+	if err := s.memory.Get(ctx, key, &status); err != nil {
+		return err
+	}
+	status.Status = ImportRunning
+	s.memory.Set(ctx, key, status, importStatusTTL)
+
+	batch := make([]importRow, 0, importBatchSize)
+	flush := func() {
+		s.writeBatch(ctx, j.adminID, batch, &status)
+		batch = batch[:0]
+		s.memory.Set(ctx, key, status, importStatusTTL)
+	}
+	err := readRows(j.format, j.data, func(row importRow, rowErr error) {
+		if rowErr != nil {
+			rejectRow(&status, row.line, rowErr)
+			return
+		}
+		batch = append(batch, row)
+		if len(batch) == importBatchSize {
+			flush()
+		}
+	})
+	if len(batch) > 0 {
+		flush()
+	}
+
+	finished := time.Now().UTC()
+	status.FinishedAt = &finished
+	status.Status = ImportDone
+	if err != nil {
+		status.Status = ImportFailed
+		status.Error = err.Error()
+	}
+	if status.Created > 0 || status.Updated > 0 {
+		s.invalidateLists(ctx)
+	}
+	s.memory.Set(ctx, key, status, importStatusTTL)
+	s.log.Info("Product import finished",
+		zap.String("id", status.ID),
+		zap.String("status", status.Status),
+		zap.Int("created", status.Created),
+		zap.Int("updated", status.Updated),
+		zap.Int("failed", status.Failed))
+	return err
+}
+
+// writeBatch creates the rows without an id and updates the others with
+// their history and events, a failed statement rejects every row it contained
+func (s *Product) writeBatch(ctx context.Context, adminID string, rows []importRow, status *dto.ProductImportResponse) {
+	var create sqlc.CreateProductsBatchParams
+	var update sqlc.UpdateProductsBatchParams
+	var created, updated []importRow
+	for _, row := range rows {
+		if row.ID == 0 {
+			create.Names = append(create.Names, row.Name)
+			create.Descriptions = append(create.Descriptions, row.Description)
+			create.Prices = append(create.Prices, row.Price)
+			create.IsActive = append(create.IsActive, *row.IsActive)
+			currency := row.Currency
+			if currency == "" {
+				currency = DefaultCurrency
+			}
+			create.Currencies = append(create.Currencies, currency)
+			created = append(created, row)
+			continue
+		}
+		update.Ids = append(update.Ids, row.ID)
+		update.Names = append(update.Names, row.Name)
+		update.Descriptions = append(update.Descriptions, row.Description)
+		update.Prices = append(update.Prices, row.Price)
+		update.IsActive = append(update.IsActive, *row.IsActive)
+		update.Currencies = append(update.Currencies, row.Currency)
+		updated = append(updated, row)
+	}
+
+	if len(created) > 0 {
+		var products []sqlc.Product
+		err := s.tx.WithTx(ctx, func(ctx context.Context, q *sqlc.Queries) error {
+			var err error
+			if products, err = q.CreateProductsBatch(ctx, create); err != nil {
+				return err
+			}
+			for i := range products {
+				if err := recordHistory(ctx, q, HistoryCreate, adminID, nil, &products[i]); err != nil {
+					return err
+				}
+			}
+			return addEvents(ctx, q, EventProductCreated, products)
+		})
+		if err != nil {
+			for _, row := range created {
+				rejectRow(status, row.line, err)
+			}
+		} else {
+			status.Created += len(products)
+			for _, product := range products {
+				// drop a not found entry cached before the product existed
+				s.forgetProduct(ctx, product.ID)
+			}
+		}
+	}
+
+	if len(updated) > 0 {
+		var products []sqlc.Product
+		err := s.tx.WithTx(ctx, func(ctx context.Context, q *sqlc.Queries) error {
+			// locked so history records the state each update replaces
+			locked, err := q.LockProducts(ctx, update.Ids)
+			if err != nil {
+				return err
+			}
+			before := make(map[int32]sqlc.Product, len(locked))
+			for _, p := range locked {
+				before[p.ID] = p
+			}
+			if products, err = q.UpdateProductsBatch(ctx, update); err != nil {
+				return err
+			}
+			for i := range products {
+				previous := before[products[i].ID]
+				if err := recordHistory(ctx, q, HistoryUpdate, adminID, &previous, &products[i]); err != nil {
+					return err
+				}
+			}
+			return addEvents(ctx, q, EventProductUpdated, products)
+		})
+		if err != nil {
+			for _, row := range updated {
+				rejectRow(status, row.line, err)
+			}
+			return
+		}
+		found := make(map[int32]bool, len(products))
+		for _, product := range products {
+			found[product.ID] = true
+			s.forgetProduct(ctx, product.ID)
+		}
+		for _, row := range updated {
+			if !found[row.ID] {
+				rejectRow(status, row.line, fmt.Errorf("product %d not found", row.ID))
+			}
+		}
+		status.Updated += len(products)
+	}
+}
+
+// Export writes every product to w in format, reading them in batches so the
+// whole catalog is never held in memory
+func (s *Product) Export(ctx context.Context, w io.Writer, format string) error {
+	var write func(dto.ProductRow) error
+	var flush func() error
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvColumns); err != nil {
+			return err
+		}
+		write = func(row dto.ProductRow) error {
+			return cw.Write([]string{
+				strconv.Itoa(int(row.ID)),
+				row.Name,
+				row.Description,
+				strconv.FormatInt(row.Price, 10),
+				strconv.FormatBool(*row.IsActive),
+				row.Currency,
+			})
+		}
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	case FormatJSONL:
+		enc := json.NewEncoder(w)
+		write = func(row dto.ProductRow) error {
+			return enc.Encode(row)
+		}
+		flush = func() error { return nil }
+	default:
+		return ErrUnknownFormat
+	}
+
+	var after int32
+	for {
+		products, err := s.query.ExportProducts(ctx, sqlc.ExportProductsParams{
+			AfterID:   after,
+			BatchSize: exportBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+		for _, p := range products {
+			active := p.IsActive
+			row := dto.ProductRow{
+				ID:          p.ID,
+				Name:        p.ProductName,
+				Description: p.ProductDescription,
+				Price:       p.Price,
+				IsActive:    &active,
+				Currency:    p.Currency,
+			}
+			if err := write(row); err != nil {
+				return err
+			}
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		if len(products) < exportBatchSize {
+			return nil
+		}
+		after = products[len(products)-1].ID
+	}
+}
+
+// importRow is a row read from an import file and the line it was read from
+type importRow struct {
+	dto.ProductRow
+	line int
+}
+
+// readRows calls fn with every row of data, rowErr is set for rows that are
+// rejected. It returns an error only when the file cannot be read at all.
+func readRows(format string, data []byte, fn func(row importRow, rowErr error)) error {
+	switch format {
+	case FormatCSV:
+		return readCSV(data, fn)
+	case FormatJSONL:
+		return readJSONL(data, fn)
+	}
+	return ErrUnknownFormat
+}
+
+func readCSV(data []byte, fn func(row importRow, rowErr error)) error {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return fmt.Errorf("read csv header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"name", "price"} {
+		if _, ok := columns[name]; !ok {
+			return fmt.Errorf("csv header has no %s column", name)
+		}
+	}
+
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return err
+			}
+			fn(importRow{line: parseErr.StartLine}, err)
+			continue
+		}
+		line, _ := r.FieldPos(0)
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := importRow{line: line}
+		row.Name = field("name")
+		row.Description = field("description")
+		if v := field("id"); v != "" {
+			id, err := strconv.ParseInt(v, 10, 32)
+			if err != nil {
+				fn(row, fmt.Errorf("invalid id %q", v))
+				continue
+			}
+			row.ID = int32(id)
+		}
+		price, err := strconv.ParseInt(field("price"), 10, 64)
+		if err != nil {
+			fn(row, fmt.Errorf("invalid price %q", field("price")))
+			continue
+		}
+		row.Price = price
+		if v := field("is_active"); v != "" {
+			active, err := strconv.ParseBool(v)
+			if err != nil {
+				fn(row, fmt.Errorf("invalid is_active %q", v))
+				continue
+			}
+			row.IsActive = &active
+		}
+		row.Currency = field("currency")
+		fn(row, validateRow(&row))
+	}
+}
+
+func readJSONL(data []byte, fn func(row importRow, rowErr error)) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		row := importRow{line: line}
+		dec := json.NewDecoder(bytes.NewReader(text))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&row.ProductRow); err != nil {
+			fn(row, err)
+			continue
+		}
+		fn(row, validateRow(&row))
+	}
+	return scanner.Err()
+}
+
+// validateRow checks a row and fills its defaults
+func validateRow(row *importRow) error {
+	row.Name = strings.TrimSpace(row.Name)
+	if row.Name == "" {
+		return errors.New("name is required")
+	}
+	if row.Price < 0 {
+		return errors.New("price must be 0 or more")
+	}
+	if row.ID < 0 {
+		return errors.New("id must be positive")
+	}
+	if row.IsActive == nil {
+		active := true
+		row.IsActive = &active
+	}
+	if row.Currency != "" {
+		currency, err := normalizeCurrency(row.Currency)
+		if err != nil {
+			return err
+		}
+		row.Currency = currency
+	}
+	return nil
+}
+
+// rejectRow counts a rejected row and keeps its error while there is room
+func rejectRow(status *dto.ProductImportResponse, line int, err error) {
+	status.Failed++
+	if len(status.Errors) < maxImportErrors {
+		status.Errors = append(status.Errors, dto.ImportRowError{Row: line, Error: err.Error()})
+	}
+}
+
+func newImportID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
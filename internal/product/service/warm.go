@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-worker/internal/poller"
+	"go-worker/internal/poller/job"
+	"go-worker/internal/product/dto"
+	"go-worker/internal/storage/cache"
+	"go-worker/internal/storage/sql/sqlc"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// WarmService is the dispatcher service that warms the product cache
+const WarmService = "product_cache_warm"
+
+const (
+	// warmInterval is how often the poller dispatches a warm-up job, the first
+	// one is dispatched on startup
+	warmInterval = 5 * time.Minute
+	// warmProducts is how many of the most read products are kept warm
+	warmProducts = 200
+	// warmHotKeys is how many of the most read keys are looked at to find them
+	warmHotKeys = 1000
+	// refreshAhead refreshes entries that would go stale before the next warm-up
+	refreshAhead = cacheStale + warmInterval
+)
+
+// warmJob fills the product cache and refreshes the entries about to expire
+type warmJob struct {
+	job.BaseJob
+	svc *Product
+}
+
+func (j *warmJob) Execute(ctx context.Context) error {
+	_, err := j.svc.WarmCache(ctx)
+	return err
+}
+
+// RegisterWarmUp has the poller dispatch a warm-up job on startup and every warmInterval
+func RegisterWarmUp(p *poller.Poller, s *Product) {
+	p.Every(warmInterval, func() job.Job {
+		now := time.Now().UTC()
+		return &warmJob{
+			BaseJob: job.BaseJob{
+				JobID:       "product-cache-warm-" + strconv.FormatInt(now.UnixNano(), 10),
+				ServiceName: WarmService,
+				CreatedAt:   now,
+			},
+			svc: s,
+		}
+	})
+}
+
+// WarmCache loads the most read products and the first page of every sort of
+// the listings when they are missing or about to expire, and returns how
+// many entries were loaded
+func (s *Product) WarmCache(ctx context.Context) (int, error) {
+	hot, err := s.memory.HotKeys(ctx, warmHotKeys)
+	if err != nil {
+		return 0, err
+	}
+
+	// refresh stores a fresh copy of each key
+	refresh := map[string]func(ctx context.Context) error{}
+	var keys []string
+	for _, key := range hot {
+		view, id, ok := s.memory.ProductKey(key)
+		if !ok {
+			continue
+		}
+		refresh[key] = func(ctx context.Context) error {
+			var product sqlc.Product
+			return s.memory.Refresh(ctx, key, &product, s.memory.DefaultTTL(),
+				s.productLoader(view, id), productLoadOptions(id)...)
+		}
+		if keys = append(keys, key); len(keys) == warmProducts {
+			break
+		}
+	}
+	for _, view := range []string{cache.ViewClient, cache.ViewAdmin} {
+		for _, sort := range Sorts {
+			req := dto.ListProductsRequest{Sort: sort}
+			if view == cache.ViewClient {
+				active := true
+				req.Active = &active
+			}
+			key, load, err := s.pageLoader(view, req)
+			if err != nil {
+				return 0, err
+			}
+			refresh[key] = func(ctx context.Context) error {
+				var page productPage
+				return s.memory.Refresh(ctx, key, &page, s.memory.DefaultTTL(), load,
+					cache.WithStale(cacheStale), cache.WithTags(cache.TagProducts))
+			}
+			keys = append(keys, key)
+		}
+	}
+
+	expiring, err := s.memory.Expiring(ctx, keys, refreshAhead)
+	if err != nil {
+		return 0, err
+	}
+	for i, key := range expiring {
+		// a product deleted since it was read is cached as not found
+		if err := refresh[key](ctx); err != nil && !errors.Is(err, ErrProductNotFound) {
+			return i, err
+		}
+	}
+	if len(expiring) > 0 {
+		s.log.Info("Product cache warmed", zap.Int("entries", len(expiring)))
+	}
+	return len(expiring), nil
+}
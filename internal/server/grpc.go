@@ -9,20 +9,50 @@ import (
 	"go.uber.org/fx"
 	"google.golang.org/grpc"
 
+	dispatcherpb "go-worker/api/proto/dispatcher/v1"
+	inventorypb "go-worker/api/proto/inventory/v1"
 	pb "go-worker/api/proto/product/v1"
 	"go-worker/internal/config"
+	"go-worker/internal/middleware"
 )
 
 type Params struct {
 	fx.In
-	Lifecycle fx.Lifecycle
-	Product   pb.ProductServiceServer
-	Config    *config.Config
+	Lifecycle  fx.Lifecycle
+	Product    pb.ProductServiceServer
+	Inventory  inventorypb.InventoryServiceServer
+	Dispatcher dispatcherpb.DispatcherServiceServer
+	Config     *config.Config
+}
+
+// adminGRPCMethods need an admin token, like the admin HTTP routes they mirror
+var adminGRPCMethods = []string{
+	"/" + dispatcherpb.DispatcherService_ServiceDesc.ServiceName + "/",
+	"/" + inventorypb.InventoryService_ServiceDesc.ServiceName + "/",
+	pb.ProductService_CreateProduct_FullMethodName,
+	pb.ProductService_UpdateProduct_FullMethodName,
+	pb.ProductService_PatchProduct_FullMethodName,
+	pb.ProductService_DeleteProduct_FullMethodName,
+	pb.ProductService_RestoreProduct_FullMethodName,
+	pb.ProductService_GetProductHistory_FullMethodName,
+	pb.ProductService_CreateCategory_FullMethodName,
+	pb.ProductService_UpdateCategory_FullMethodName,
+	pb.ProductService_DeleteCategory_FullMethodName,
+	pb.ProductService_SetProductCategories_FullMethodName,
+	pb.ProductService_SetProductTags_FullMethodName,
+	pb.ProductService_ListProductPrices_FullMethodName,
+	pb.ProductService_AddProductPrice_FullMethodName,
+	pb.ProductService_DeleteProductPrice_FullMethodName,
+	pb.ProductService_GetProductPriceHistory_FullMethodName,
 }
 
 func CreateGRPCServer(p Params) *grpc.Server {
-	server := grpc.NewServer()
+	server := grpc.NewServer(grpc.UnaryInterceptor(
+		middleware.GRPCAuth(p.Config, adminGRPCMethods...),
+	))
 	pb.RegisterProductServiceServer(server, p.Product)
+	inventorypb.RegisterInventoryServiceServer(server, p.Inventory)
+	dispatcherpb.RegisterDispatcherServiceServer(server, p.Dispatcher)
 	return server
 }
 
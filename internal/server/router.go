@@ -5,6 +5,8 @@ import (
 	"go-worker/docs"
 	"go-worker/internal/config"
 	"go-worker/internal/health"
+	inventoryController "go-worker/internal/inventory/controller"
+	dispatcherController "go-worker/internal/poller/controller"
 	"go-worker/internal/product/controller"
 	"log"
 	"net/http"
@@ -19,7 +21,11 @@ func RegisterRoutes(engine *gin.Engine,
 	health *health.Health,
 	cfg *config.Config,
 	adminProduct *controller.AdminProduct,
-	clientProduct *controller.ClientProduct) {
+	clientProduct *controller.ClientProduct,
+	adminCategory *controller.AdminCategory,
+	clientCategory *controller.ClientCategory,
+	adminInventory *inventoryController.AdminInventory,
+	adminDispatcher *dispatcherController.AdminDispatcher) {
 	log.Println("🚀 Registering routes...")
 	//health
 	engine.GET("/health", health.Handle)
@@ -29,6 +35,15 @@ func RegisterRoutes(engine *gin.Engine,
 	//Client Product routes
 	clientGroup := engine.Group("/api/v1/products")
 	clientProduct.RegisterRoutes(clientGroup)
+	//Admin Category routes
+	adminCategory.RegisterRoutes(engine.Group("/api/v1/admin/categories"), cfg)
+	//Client Category routes
+	clientCategory.RegisterRoutes(engine.Group("/api/v1/categories"))
+	//Admin Inventory routes
+	adminInventory.RegisterRoutes(engine.Group("/api/v1/admin/inventory"), cfg)
+	//Admin Dispatcher routes
+	dispatcherGroup := engine.Group("/api/v1/admin/dispatcher/services")
+	adminDispatcher.RegisterRoutes(dispatcherGroup, cfg)
 	// Swagger
 	docs.SwaggerInfo.Title = "My API"
 	docs.SwaggerInfo.Version = "1.0"
@@ -0,0 +1,143 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// accessDecay weighs the counts of the previous period, so keys that are
+	// no longer read fall out of the hot keys
+	accessDecay = 0.5
+	// accessDecayEvery is how often the counts decay, whatever the number of replicas
+	accessDecayEvery = 5 * time.Minute
+	// accessKeep bounds how many keys the counts are kept for, in redis and
+	// in each replica between flushes
+	accessKeep = 10000
+	// accessFlushChunk bounds how many keys one call of the script adds
+	accessFlushChunk = 500
+)
+
+// flushAccessScript decays the counts at most once per period, adds the
+// reads of this replica and drops all but the most read keys.
+// KEYS[1] is the counts and KEYS[2] the decay marker, ARGV are the decay
+// period in milliseconds, the weight, the number of keys kept and then key
+// and count pairs.
+var flushAccessScript = redis.NewScript(`
+if redis.call("SET", KEYS[2], 1, "NX", "PX", ARGV[1]) then
+	redis.call("ZUNIONSTORE", KEYS[1], 1, KEYS[1], "WEIGHTS", ARGV[2])
+end
+for i = 4, #ARGV, 2 do
+	redis.call("ZINCRBY", KEYS[1], ARGV[i + 1], ARGV[i])
+end
+redis.call("ZREMRANGEBYRANK", KEYS[1], 0, -tonumber(ARGV[3]) - 1)
+return 1`)
+
+// access counts the reads of keys since they were last flushed to redis
+type access struct {
+	mu     sync.Mutex
+	counts map[string]int64
+	hot    []string // last hot keys read, kept for when redis lost the counts
+}
+
+// track counts a read of key when it is a product key, listings are warmed
+// without counts. Keys first read once accessKeep keys are counted wait for
+// the next flush.
+func (r *Store) track(key string) {
+	if _, _, ok := r.ProductKey(key); !ok {
+		return
+	}
+	r.access.mu.Lock()
+	defer r.access.mu.Unlock()
+	if r.access.counts == nil {
+		r.access.counts = make(map[string]int64)
+	}
+	if _, ok := r.access.counts[key]; !ok && len(r.access.counts) >= accessKeep {
+		return
+	}
+	r.access.counts[key]++
+}
+
+// FlushAccess adds the reads counted by this replica to the counts every
+// replica shares in redis
+func (r *Store) FlushAccess(ctx context.Context) error {
+	r.access.mu.Lock()
+	counts := r.access.counts
+	r.access.counts = nil
+	r.access.mu.Unlock()
+
+	pending := make([]string, 0, len(counts))
+	for key := range counts {
+		pending = append(pending, key)
+	}
+	keys := []string{r.KeyAccess(), r.KeyAccess() + ":decay"}
+	// the first call also decays the counts when there is nothing to add
+	for first := true; first || len(pending) > 0; first = false {
+		chunk := pending[:min(len(pending), accessFlushChunk)]
+		args := make([]interface{}, 0, 3+2*len(chunk))
+		args = append(args, accessDecayEvery.Milliseconds(), accessDecay, accessKeep)
+		for _, key := range chunk {
+			args = append(args, key, counts[key])
+		}
+		if err := flushAccessScript.Run(ctx, r.client, keys, args...).Err(); err != nil {
+			// keep the reads that were not added for the next flush
+			r.access.mu.Lock()
+			for _, key := range pending {
+				if r.access.counts == nil {
+					r.access.counts = make(map[string]int64)
+				}
+				r.access.counts[key] += counts[key]
+			}
+			r.access.mu.Unlock()
+			return err
+		}
+		pending = pending[len(chunk):]
+	}
+	return nil
+}
+
+// HotKeys flushes the reads of this replica and returns up to n of the most
+// read keys, most read first. When redis lost the counts, e.g. after a flush,
+// the hot keys of the previous call are returned.
+func (r *Store) HotKeys(ctx context.Context, n int) ([]string, error) {
+	if err := r.FlushAccess(ctx); err != nil {
+		return nil, err
+	}
+	keys, err := r.client.ZRevRange(ctx, r.KeyAccess(), 0, int64(n)-1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	r.access.mu.Lock()
+	defer r.access.mu.Unlock()
+	if len(keys) == 0 && len(r.access.hot) > 0 {
+		return r.access.hot, nil
+	}
+	r.access.hot = keys
+	return keys, nil
+}
+
+// Expiring returns the keys that are missing or expire within d
+func (r *Store) Expiring(ctx context.Context, keys []string, d time.Duration) ([]string, error) {
+	cmds := make([]*redis.DurationCmd, len(keys))
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.PTTL(ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var expiring []string
+	for i, cmd := range cmds {
+		// PTTL is -2 for a missing key and -1 for a key without expiry
+		if remaining := cmd.Val(); remaining == -2 || (remaining >= 0 && remaining < d) {
+			expiring = append(expiring, keys[i])
+		}
+	}
+	return expiring, nil
+}
@@ -1,14 +1,68 @@
 package cache
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"go-worker/internal/config"
+	"os"
+	"strings"
 
 	"github.com/redis/go-redis/v9"
 )
 
-func NewClient(cfg *config.Config) *redis.Client {
-	return redis.NewClient(&redis.Options{
-		Addr: cfg.Redis.DSN,
-		DB:   cfg.Redis.DB,
-	})
+// NewClient connects to the single node, the sentinel monitored master or the
+// cluster REDIS_MODE names, REDIS_DSN holds its address or addresses
+func NewClient(cfg *config.Config) (redis.UniversalClient, error) {
+	addrs := strings.Split(cfg.Redis.DSN, ",")
+	for i := range addrs {
+		addrs[i] = strings.TrimSpace(addrs[i])
+	}
+	opts := &redis.UniversalOptions{
+		Addrs:            addrs,
+		DB:               cfg.Redis.DB,
+		MasterName:       cfg.Redis.MasterName,
+		Username:         cfg.Redis.Username,
+		Password:         cfg.Redis.Password,
+		SentinelPassword: cfg.Redis.SentinelPassword,
+		PoolSize:         cfg.Redis.PoolSize,
+		MinIdleConns:     cfg.Redis.MinIdleConns,
+		DialTimeout:      cfg.Redis.DialTimeout,
+		ReadTimeout:      cfg.Redis.ReadTimeout,
+		WriteTimeout:     cfg.Redis.WriteTimeout,
+	}
+	if cfg.Redis.TLS {
+		tlsConfig, err := newTLSConfig(cfg.Redis.TLSCAFile)
+		if err != nil {
+			return nil, err
+		}
+		opts.TLSConfig = tlsConfig
+	}
+
+	switch cfg.Redis.Mode {
+	case "sentinel":
+		return redis.NewFailoverClient(opts.Failover()), nil
+	case "cluster":
+		return redis.NewClusterClient(opts.Cluster()), nil
+	default:
+		return redis.NewClient(opts.Simple()), nil
+	}
+}
+
+// newTLSConfig trusts the CAs of caFile, or the system CAs when it is empty
+func newTLSConfig(caFile string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile == "" {
+		return tlsConfig, nil
+	}
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading REDIS_TLS_CA_FILE: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("REDIS_TLS_CA_FILE %s holds no PEM certificate", caFile)
+	}
+	tlsConfig.RootCAs = pool
+	return tlsConfig, nil
 }
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Codec encodes the values kept in the cache
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// codec ids written in the value header, never reuse one
+const (
+	codecJSON    byte = 1
+	codecMsgpack byte = 2
+)
+
+var (
+	// JSON encodes values with encoding/json, it is the default codec
+	JSON Codec = jsonCodec{}
+	// Msgpack encodes values with MessagePack, fields are named by their json
+	// tags and times are read back in the local zone, as pgx reads them
+	Msgpack Codec = msgpackCodec{}
+)
+
+// codecs by id, a value is decoded with the codec it was stored with
+var codecs = map[byte]Codec{
+	codecJSON:    JSON,
+	codecMsgpack: Msgpack,
+}
+
+// codecNames are the values REDIS_CODEC accepts
+var codecNames = map[string]byte{
+	"":        codecJSON,
+	"json":    codecJSON,
+	"msgpack": codecMsgpack,
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(v)
+}
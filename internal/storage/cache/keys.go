@@ -1,10 +1,88 @@
 package cache
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
-func (s *Store) KeyProduct(ID int32) string {
-	return s.prefix + ":product:" + fmt.Sprint(ID)
+// TagProducts is carried by every cached product listing
+const TagProducts = "products"
+
+// TagProduct is carried by every cached copy of one product
+func TagProduct(id int32) string {
+	return "product:" + fmt.Sprint(id)
+}
+
+// Views a product is cached for, admins see products a client must not see
+// so each view has its own keys
+const (
+	ViewAdmin  = "admin"
+	ViewClient = "client"
+)
+
+func (s *Store) KeyProduct(view string, ID int32) string {
+	return s.prefix + ":product:" + view + ":" + fmt.Sprint(ID)
+}
+
+// ProductKey returns the view and id of a key made by KeyProduct
+func (s *Store) ProductKey(key string) (view string, id int32, ok bool) {
+	rest, found := strings.CutPrefix(key, s.prefix+":product:")
+	if !found {
+		return "", 0, false
+	}
+	view, idText, found := strings.Cut(rest, ":")
+	if !found {
+		return "", 0, false
+	}
+	n, err := strconv.ParseInt(idText, 10, 32)
+	if err != nil {
+		return "", 0, false
+	}
+	return view, int32(n), true
+}
+func (s *Store) KeyProductsPage(view string, shape string) string {
+	return s.prefix + ":products:page:" + view + ":" + shape
+}
+func (s *Store) KeyProductsCount(view string, shape string) string {
+	return s.prefix + ":products:count:" + view + ":" + shape
+}
+func (s *Store) KeyProductsSearch(shape string) string {
+	return s.prefix + ":products:search:" + shape
+}
+func (s *Store) KeyTag(tag string) string {
+	if s.cluster {
+		// keeps the set and its copy being invalidated in one hash slot
+		return s.prefix + ":tag:{" + tag + "}"
+	}
+	return s.prefix + ":tag:" + tag
+}
+func (s *Store) KeyInvalidation() string {
+	return s.prefix + ":cache:invalidate"
+}
+func (s *Store) KeyAccess() string {
+	// the hash tag keeps the counts and their decay marker in one cluster slot
+	return "{" + s.prefix + ":cache:access}"
+}
+func (s *Store) KeyLoadLock(key string) string {
+	return key + ":lock"
+}
+func (s *Store) KeyServicePaused(service string) string {
+	return s.prefix + ":dispatcher:paused:" + service
+}
+func (s *Store) KeyOverflowQueue(service string) string {
+	if s.cluster {
+		// keeps the queue and its processing list in one hash slot
+		return s.prefix + ":dispatcher:overflow:{" + service + "}"
+	}
+	return s.prefix + ":dispatcher:overflow:" + service
+}
+func (s *Store) KeyOverflowProcessing(service string) string {
+	return s.KeyOverflowQueue(service) + ":processing"
+}
+func (s *Store) KeyEventStream(aggregateType string) string {
+	return s.prefix + ":events:" + aggregateType
 }
-func (s *Store) KeyAllProducts() string {
-	return s.prefix + ":products:all"
+func (s *Store) KeyProductImport(id string) string {
+	return s.prefix + ":products:import:" + id
 }
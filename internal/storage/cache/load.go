@@ -0,0 +1,283 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// loadLockTTL bounds how long one replica holds a key while it refills it
+	loadLockTTL = 5 * time.Second
+	// loadPollInterval is how often a replica that lost the lock checks for the refilled key
+	loadPollInterval = 50 * time.Millisecond
+	// loadTimeout bounds a load shared by several callers or run in the background
+	loadTimeout = 10 * time.Second
+)
+
+// tombstone is stored for a key whose value is known to be missing, it is
+// not valid JSON so it cannot be mistaken for a value
+var tombstone = []byte("\x00missing")
+
+// errLockReleased tells a waiting replica the lock holder gave up without filling the key
+var errLockReleased = errors.New("cache: lock released before the key was filled")
+
+// unlockScript deletes a lock only if it is still held with the given token
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// Loader reads a value from the source of truth when it is not cached
+type Loader func(ctx context.Context) (interface{}, error)
+
+// LoadOption configures a GetOrLoad call
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	stale       time.Duration
+	tags        []string
+	missing     error
+	negativeTTL time.Duration
+	shape       uint32 // of dest, loaded values are stored as read back
+}
+
+// WithStale keeps a value for stale after its ttl. A read in that window gets
+// the stale value at once while the value is refreshed in the background.
+func WithStale(stale time.Duration) LoadOption {
+	return func(o *loadOptions) {
+		o.stale = stale
+	}
+}
+
+// WithTags stores a loaded value with tags, see Set
+func WithTags(tags ...string) LoadOption {
+	return func(o *loadOptions) {
+		o.tags = append(o.tags, tags...)
+	}
+}
+
+// WithNegative caches a load that fails with missing for ttl, reads in that
+// time get missing without calling load
+func WithNegative(ttl time.Duration, missing error) LoadOption {
+	return func(o *loadOptions) {
+		o.negativeTTL = ttl
+		o.missing = missing
+	}
+}
+
+// GetOrLoad retrieves key into dest (must be a pointer), on a miss it stores
+// the result of load with a TTL, ttl is in minutes. Concurrent misses of the
+// same key share one load in a process, and a short Redis lock lets a single
+// replica load it while the others wait for the refilled key. Errors of load
+// are returned and not cached; when Redis is down load is called directly.
+// A value that no longer decodes into dest, e.g. one stored by the previous
+// release, is loaded again.
+func (r *Store) GetOrLoad(ctx context.Context, key string, dest interface{}, ttl int, load Loader, opts ...LoadOption) error {
+	o := loadOptions{shape: shapeOf(reflect.TypeOf(dest))}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	r.track(key)
+
+	if data, ok := r.local.get(key); ok {
+		if err := o.decode(data, dest); err == nil || err == o.missing {
+			return err
+		}
+		r.local.delete(key)
+	}
+	data, remaining, err := r.getWithTTL(ctx, key)
+	if err == nil {
+		err = o.decode(data, dest)
+		if err == nil || err == o.missing {
+			if bytes.Equal(data, tombstone) {
+				r.local.set(key, data, remaining)
+			} else if o.stale > 0 && remaining > 0 && remaining < o.stale {
+				r.refresh(ctx, key, ttl, o, load)
+			} else {
+				// the local copy must be gone before the stale window starts
+				r.local.set(key, data, remaining-o.stale)
+			}
+			return err
+		}
+	}
+
+	ch := r.loads.DoChan(key, func() (interface{}, error) {
+		// the load is shared, so the caller that started it must not cancel it for the others
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+		return r.fill(ctx, key, ttl, o, load)
+	})
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return res.Err
+		}
+		return o.decode(res.Val.([]byte), dest)
+	}
+}
+
+// decode un marshals data into dest, a tombstone is the missing error
+func (o loadOptions) decode(data []byte, dest interface{}) error {
+	if o.missing != nil && bytes.Equal(data, tombstone) {
+		return o.missing
+	}
+	return decode(data, dest)
+}
+
+// getWithTTL reads key and how long it has left in one round trip
+func (r *Store) getWithTTL(ctx context.Context, key string) ([]byte, time.Duration, error) {
+	pipe := r.client.Pipeline()
+	get := pipe.Get(ctx, key)
+	pttl := pipe.PTTL(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, 0, err
+	}
+	data, err := get.Bytes()
+	return data, pttl.Val(), err
+}
+
+// fill loads key under the replica lock, or waits for the replica holding it
+func (r *Store) fill(ctx context.Context, key string, ttl int, o loadOptions, load Loader) ([]byte, error) {
+	lockKey := r.KeyLoadLock(key)
+	token, locked, err := r.lock(ctx, lockKey)
+	if err == nil && !locked {
+		data, err := r.waitFor(ctx, key, lockKey)
+		if err == nil && hasShape(data, o.shape) {
+			return data, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		// the holder failed, is too slow or runs another release, load it here
+	}
+	if locked {
+		defer r.unlock(ctx, lockKey, token)
+	}
+	return r.loadAndStore(ctx, key, ttl, o, load)
+}
+
+// refresh reloads a stale key in the background, once per process and only
+// on the replica that gets the lock
+func (r *Store) refresh(ctx context.Context, key string, ttl int, o loadOptions, load Loader) {
+	r.loads.DoChan("refresh:"+key, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+		lockKey := r.KeyLoadLock(key)
+		token, locked, err := r.lock(ctx, lockKey)
+		if err != nil || !locked {
+			return nil, err
+		}
+		defer r.unlock(ctx, lockKey, token)
+		if _, err := r.loadAndStore(ctx, key, ttl, o, load); err != nil {
+			log.Printf("⚠️ cache: refreshing %s failed: %v\n", key, err)
+			return nil, err
+		}
+		return nil, nil
+	})
+}
+
+// Refresh stores the result of load under key whatever is cached, dest only
+// tells the type it is read back into. Concurrent refreshes of key share one
+// load and only the replica that gets the lock loads it, the others return nil.
+func (r *Store) Refresh(ctx context.Context, key string, dest interface{}, ttl int, load Loader, opts ...LoadOption) error {
+	o := loadOptions{shape: shapeOf(reflect.TypeOf(dest))}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	_, err, _ := r.loads.Do("refresh:"+key, func() (interface{}, error) {
+		lockKey := r.KeyLoadLock(key)
+		token, locked, err := r.lock(ctx, lockKey)
+		if err != nil || !locked {
+			return nil, err
+		}
+		defer r.unlock(ctx, lockKey, token)
+		_, err = r.loadAndStore(ctx, key, ttl, o, load)
+		return nil, err
+	})
+	return err
+}
+
+// loadAndStore calls load and caches its result for ttl minutes plus the stale window
+func (r *Store) loadAndStore(ctx context.Context, key string, ttl int, o loadOptions, load Loader) ([]byte, error) {
+	value, err := load(ctx)
+	if err != nil {
+		if o.missing != nil && errors.Is(err, o.missing) && o.negativeTTL > 0 {
+			_ = r.setRaw(ctx, key, tombstone, o.negativeTTL, o.tags)
+		}
+		return nil, err
+	}
+	data, err := r.encode(value, o.shape)
+	if err != nil {
+		return nil, err
+	}
+	fresh := time.Duration(ttl) * time.Minute
+	_ = r.setRaw(ctx, key, data, fresh+o.stale, o.tags)
+	if fresh > 0 {
+		r.local.set(key, data, fresh)
+	} else {
+		r.local.delete(key)
+	}
+	return data, nil
+}
+
+// waitFor polls key until it is filled, the lock is released or loadLockTTL passes
+func (r *Store) waitFor(ctx context.Context, key, lockKey string) ([]byte, error) {
+	ticker := time.NewTicker(loadPollInterval)
+	defer ticker.Stop()
+	deadline := time.NewTimer(loadLockTTL)
+	defer deadline.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-deadline.C:
+			return nil, context.DeadlineExceeded
+		case <-ticker.C:
+			pipe := r.client.Pipeline()
+			get := pipe.Get(ctx, key)
+			held := pipe.Exists(ctx, lockKey)
+			if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+				return nil, err
+			}
+			if data, err := get.Bytes(); err == nil {
+				return data, nil
+			}
+			if held.Val() == 0 {
+				return nil, errLockReleased
+			}
+		}
+	}
+}
+
+func (r *Store) lock(ctx context.Context, lockKey string) (string, bool, error) {
+	token, err := newToken()
+	if err != nil {
+		return "", false, err
+	}
+	locked, err := r.client.SetNX(ctx, lockKey, token, loadLockTTL).Result()
+	return token, locked, err
+}
+
+// newToken returns a random token no other caller holds
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (r *Store) unlock(ctx context.Context, lockKey, token string) {
+	_ = unlockScript.Run(ctx, r.client, []string{lockKey}, token).Err()
+}
@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+)
+
+// DefaultLocalTTL is how long a local entry is trusted when REDIS_LOCAL_TTL is not set
+const DefaultLocalTTL = 30 * time.Second
+
+// local is a bounded LRU of encoded values kept in process in front of redis.
+// A nil local is a disabled tier, every method is a no-op.
+type local struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	items map[string]*list.Element
+	order *list.List // most recently used at the front
+}
+
+type localEntry struct {
+	key     string
+	data    []byte
+	expires time.Time
+}
+
+func newLocal(size int, ttl time.Duration) *local {
+	if size <= 0 {
+		return nil
+	}
+	if ttl <= 0 {
+		ttl = DefaultLocalTTL
+	}
+	return &local{
+		size:  size,
+		ttl:   ttl,
+		items: make(map[string]*list.Element, size),
+		order: list.New(),
+	}
+}
+
+func (l *local) get(key string) ([]byte, bool) {
+	if l == nil {
+		return nil, false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	el, ok := l.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*localEntry)
+	if time.Now().After(entry.expires) {
+		l.order.Remove(el)
+		delete(l.items, key)
+		return nil, false
+	}
+	l.order.MoveToFront(el)
+	return entry.data, true
+}
+
+// set keeps data for ttl, capped by the tier ttl, a ttl of zero or less means the tier ttl
+func (l *local) set(key string, data []byte, ttl time.Duration) {
+	if l == nil {
+		return
+	}
+	if ttl <= 0 || ttl > l.ttl {
+		ttl = l.ttl
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if el, ok := l.items[key]; ok {
+		el.Value = &localEntry{key: key, data: data, expires: time.Now().Add(ttl)}
+		l.order.MoveToFront(el)
+		return
+	}
+	l.items[key] = l.order.PushFront(&localEntry{key: key, data: data, expires: time.Now().Add(ttl)})
+	for l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.items, oldest.Value.(*localEntry).key)
+	}
+}
+
+func (l *local) delete(keys ...string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		if el, ok := l.items[key]; ok {
+			l.order.Remove(el)
+			delete(l.items, key)
+		}
+	}
+}
+
+// invalidation is published when keys change so every replica drops its local copy
+type invalidation struct {
+	Node string   `json:"node"`
+	Keys []string `json:"keys"`
+}
+
+// publish queues an invalidation of keys on pipe
+func (r *Store) publish(ctx context.Context, pipe redis.Pipeliner, keys ...string) {
+	data, _ := json.Marshal(invalidation{Node: r.node, Keys: keys})
+	pipe.Publish(ctx, r.KeyInvalidation(), data)
+}
+
+// RegisterInvalidation drops the local copies of the keys other replicas change.
+// Messages missed while the subscription reconnects leave local copies stale
+// for at most REDIS_LOCAL_TTL.
+func RegisterInvalidation(lc fx.Lifecycle, store *Store) {
+	if store.local == nil {
+		return
+	}
+	var sub *redis.PubSub
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			sub = store.client.Subscribe(context.Background(), store.KeyInvalidation())
+			// wait for the subscription so no change made after start is missed,
+			// the subscription keeps reconnecting when redis is not up yet
+			if _, err := sub.Receive(ctx); err != nil {
+				log.Printf("⚠️ cache: subscribing to invalidations: %v\n", err)
+			}
+			go func() {
+				for msg := range sub.Channel() {
+					var inv invalidation
+					if err := json.Unmarshal([]byte(msg.Payload), &inv); err != nil {
+						log.Printf("⚠️ cache: invalid invalidation message: %v\n", err)
+						continue
+					}
+					if inv.Node != store.node {
+						store.local.delete(inv.Keys...)
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			if sub != nil {
+				return sub.Close()
+			}
+			return nil
+		},
+	})
+}
@@ -2,47 +2,159 @@ package cache
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"go-worker/internal/config"
+	"log"
+	"reflect"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
 )
 
+// Store caches values in redis, with an optional in-process tier in front of
+// it that replicas keep in sync through redis pub/sub
 type Store struct {
-	client *redis.Client
-	prefix string
+	client     redis.UniversalClient
+	cluster    bool // scripts must only touch keys of one hash slot
+	prefix     string
+	defaultTTL atomic.Int64
+	loads      singleflight.Group // GetOrLoad calls in flight, by key
+	local      *local
+	access     access // reads by key, for the warm-up of the most read keys
+	node       string // tells this replica's invalidations apart from the others
+
+	codec             byte // codec of new values, values are read with the codec they were stored with
+	compression       byte
+	compressThreshold int // smallest encoded value that is compressed, in bytes
 }
 
-func NewCacheStore(client *redis.Client, cfg *config.Config) *Store {
-	return &Store{
-		client: client,
-		prefix: cfg.Redis.Prefix,
+func NewCacheStore(client redis.UniversalClient, cfg *config.Config) *Store {
+	node := make([]byte, 8)
+	_, _ = rand.Read(node)
+	_, cluster := client.(*redis.ClusterClient)
+	s := &Store{
+		client:  client,
+		cluster: cluster,
+		prefix:  cfg.Redis.Prefix,
+		local:   newLocal(cfg.Redis.LocalSize, cfg.Redis.LocalTTL),
+		node:    hex.EncodeToString(node),
+
+		codec:             codecNames[cfg.Redis.Codec],
+		compression:       compressionNames[cfg.Redis.Compression],
+		compressThreshold: cfg.Redis.CompressThreshold,
+	}
+	if s.compressThreshold <= 0 {
+		s.compressThreshold = DefaultCompressThreshold
 	}
+	s.SetDefaultTTL(cfg.Redis.DefaultTTL)
+	return s
 }
 
-// Set stores any serializable value with a TTL, ttl is in minutes
-func (r *Store) Set(ctx context.Context, key string, value interface{}, ttl int) error {
+// DefaultTTL returns the default ttl in minutes, it follows config reloads
+func (r *Store) DefaultTTL() int {
+	return int(r.defaultTTL.Load())
+}
+
+// SetDefaultTTL sets the default ttl in minutes
+func (r *Store) SetDefaultTTL(ttl int) {
+	r.defaultTTL.Store(int64(ttl))
+}
+
+// RegisterReload applies REDIS_DEFAULT_TTL changes to the store
+func RegisterReload(w *config.Watcher, store *Store) {
+	w.OnChange(func(old, new *config.Config) {
+		if old.Redis.DefaultTTL != new.Redis.DefaultTTL {
+			store.SetDefaultTTL(new.Redis.DefaultTTL)
+			log.Printf("✅ Cache default TTL set to %d minutes\n", new.Redis.DefaultTTL)
+		}
+	})
+}
+
+// Set stores any serializable value with a TTL, ttl is in minutes.
+// InvalidateTag with any of tags removes it again.
+func (r *Store) Set(ctx context.Context, key string, value interface{}, ttl int, tags ...string) error {
 	ttlDuration := time.Duration(ttl) * time.Minute
-	data, err := json.Marshal(value)
+	data, err := r.encode(value, shapeOf(reflect.TypeOf(value)))
+	if err != nil {
+		return err
+	}
+	return r.setRaw(ctx, key, data, ttlDuration, tags)
+}
+
+// setRaw stores encoded data in both tiers and evicts it from the other replicas
+func (r *Store) setRaw(ctx context.Context, key string, data []byte, ttl time.Duration, tags []string) error {
+	var err error
+	if len(tags) > 0 {
+		err = r.setTagged(ctx, key, data, ttl, tags)
+	} else {
+		_, err = r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, ttl)
+			r.publish(ctx, pipe, key)
+			return nil
+		})
+	}
 	if err != nil {
+		r.local.delete(key)
 		return err
 	}
-	return r.client.Set(ctx, key, data, ttlDuration).Err()
+	r.local.set(key, data, ttl)
+	return nil
 }
 
-// Get retrieves a value and un marshals it into dest (must be a pointer)
+// Get retrieves a value and un marshals it into dest (must be a pointer),
+// it returns ErrVersionMismatch for a value stored with another shape of dest
 func (r *Store) Get(ctx context.Context, key string, dest interface{}) error {
-	data, err := r.client.Get(ctx, key).Bytes()
+	r.track(key)
+	if data, ok := r.local.get(key); ok {
+		return decode(data, dest)
+	}
+	if r.local == nil {
+		data, err := r.client.Get(ctx, key).Bytes()
+		if err != nil {
+			return err
+		}
+		return decode(data, dest)
+	}
+	data, remaining, err := r.getWithTTL(ctx, key)
 	if err != nil {
 		return err
 	}
-	return json.Unmarshal(data, dest)
+	if err := decode(data, dest); err != nil {
+		return err
+	}
+	r.local.set(key, data, remaining)
+	return nil
 }
 
-// Delete removes a key from the cache
+// Delete removes a key from the cache of every replica
 func (r *Store) Delete(ctx context.Context, key string) error {
-	return r.client.Del(ctx, key).Err()
+	r.local.delete(key)
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		r.publish(ctx, pipe, key)
+		return nil
+	})
+	return err
+}
+
+// Incr increments the counter stored at key and returns its new value,
+// the counter can be read back with Get into an int64
+func (r *Store) Incr(ctx context.Context, key string) (int64, error) {
+	r.local.delete(key)
+	var incr *redis.IntCmd
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		r.publish(ctx, pipe, key)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
 }
 
 // Exists checks if a key exists
@@ -50,3 +162,72 @@ func (r *Store) Exists(ctx context.Context, key string) (bool, error) {
 	count, err := r.client.Exists(ctx, key).Result()
 	return count > 0, err
 }
+
+// Push appends a serializable value to the tail of the list stored at key
+func (r *Store) Push(ctx context.Context, key string, value interface{}) error {
+	data, err := r.encode(value, shapeOf(reflect.TypeOf(value)))
+	if err != nil {
+		return err
+	}
+	return r.client.RPush(ctx, key, data).Err()
+}
+
+// Move moves the head of the list stored at src to the tail of the list stored
+// at dst and un marshals it into dest. It returns the entry as stored, for
+// Remove and MoveBack, and redis.Nil when src is empty. The entry stays in dst
+// when it cannot be un marshalled.
+func (r *Store) Move(ctx context.Context, src, dst string, dest interface{}) (string, error) {
+	entry, err := r.client.LMove(ctx, src, dst, "LEFT", "RIGHT").Result()
+	if err != nil {
+		return "", err
+	}
+	return entry, decode([]byte(entry), dest)
+}
+
+// Remove removes entry, as returned by Move, from the list stored at key
+func (r *Store) Remove(ctx context.Context, key, entry string) error {
+	return r.client.LRem(ctx, key, 1, entry).Err()
+}
+
+// MoveBack removes entry, as returned by Move, from the list stored at src and
+// puts it back at the head of the list stored at dst
+func (r *Store) MoveBack(ctx context.Context, src, dst, entry string) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LRem(ctx, src, 1, entry)
+		pipe.LPush(ctx, dst, entry)
+		return nil
+	})
+	return err
+}
+
+// MoveAllBack moves every entry of the list stored at src back to the head of
+// the list stored at dst, in order, and returns how many were moved
+func (r *Store) MoveAllBack(ctx context.Context, src, dst string) (int, error) {
+	moved := 0
+	for {
+		err := r.client.LMove(ctx, src, dst, "RIGHT", "LEFT").Err()
+		if errors.Is(err, redis.Nil) {
+			return moved, nil
+		}
+		if err != nil {
+			return moved, err
+		}
+		moved++
+	}
+}
+
+// Len returns the length of the list stored at key
+func (r *Store) Len(ctx context.Context, key string) (int64, error) {
+	return r.client.LLen(ctx, key).Result()
+}
+
+// AddToStream appends an entry of fields to the stream stored at key, trimming
+// the stream to about maxLen entries unless it is 0, and returns the entry id
+func (r *Store) AddToStream(ctx context.Context, key string, fields map[string]interface{}, maxLen int64) (string, error) {
+	return r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: maxLen,
+		Approx: true,
+		Values: fields,
+	}).Result()
+}
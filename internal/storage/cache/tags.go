@@ -0,0 +1,161 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// setTaggedScript stores a value, adds it to its tag sets and publishes the
+// change in one step. The tag sets live as long as their longest member.
+// KEYS[1] is the key and KEYS[2..] its tag sets, ARGV are the value, the ttl
+// in milliseconds (0 keeps it), the invalidation channel and message.
+var setTaggedScript = redis.NewScript(`
+local ttl = tonumber(ARGV[2])
+if ttl > 0 then
+	redis.call("SET", KEYS[1], ARGV[1], "PX", ttl)
+else
+	redis.call("SET", KEYS[1], ARGV[1])
+end
+for i = 2, #KEYS do
+	local existed = redis.call("EXISTS", KEYS[i])
+	redis.call("SADD", KEYS[i], KEYS[1])
+	local left = redis.call("PTTL", KEYS[i])
+	if ttl == 0 then
+		redis.call("PERSIST", KEYS[i])
+	elseif existed == 0 or (left >= 0 and left < ttl) then
+		redis.call("PEXPIRE", KEYS[i], ttl)
+	end
+end
+redis.call("PUBLISH", ARGV[3], ARGV[4])
+return 1`)
+
+// invalidateScript deletes the tag sets in KEYS and every key they hold, then
+// publishes the deleted keys. ARGV are the invalidation channel and node.
+var invalidateScript = redis.NewScript(`
+local deleted = {}
+for i = 1, #KEYS do
+	for _, key in ipairs(redis.call("SMEMBERS", KEYS[i])) do
+		redis.call("DEL", key)
+		table.insert(deleted, key)
+	end
+	redis.call("DEL", KEYS[i])
+end
+if #deleted > 0 then
+	redis.call("PUBLISH", ARGV[1], cjson.encode({node = ARGV[2], keys = deleted}))
+end
+return deleted`)
+
+// tagKeyScript adds ARGV[1] to the tag set KEYS[1] and extends the set like
+// setTaggedScript, ARGV[2] is the ttl of the key in milliseconds
+var tagKeyScript = redis.NewScript(`
+local ttl = tonumber(ARGV[2])
+local existed = redis.call("EXISTS", KEYS[1])
+redis.call("SADD", KEYS[1], ARGV[1])
+local left = redis.call("PTTL", KEYS[1])
+if ttl == 0 then
+	redis.call("PERSIST", KEYS[1])
+elseif existed == 0 or (left >= 0 and left < ttl) then
+	redis.call("PEXPIRE", KEYS[1], ttl)
+end
+return 1`)
+
+// takeTagScript renames the tag set KEYS[1] to KEYS[2] if it exists, keys
+// tagged while the renamed set is invalidated go to a new set
+var takeTagScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 1 then
+	redis.call("RENAME", KEYS[1], KEYS[2])
+	return 1
+end
+return 0`)
+
+// setTagged stores data under key and records key in the set of every tag
+func (r *Store) setTagged(ctx context.Context, key string, data []byte, ttl time.Duration, tags []string) error {
+	if r.cluster {
+		return r.setTaggedCluster(ctx, key, data, ttl, tags)
+	}
+	keys := make([]string, 0, len(tags)+1)
+	keys = append(keys, key)
+	for _, tag := range tags {
+		keys = append(keys, r.KeyTag(tag))
+	}
+	message, _ := json.Marshal(invalidation{Node: r.node, Keys: []string{key}})
+	return setTaggedScript.Run(ctx, r.client, keys,
+		data, ttl.Milliseconds(), r.KeyInvalidation(), message).Err()
+}
+
+// InvalidateTag removes every key stored with any of tags from every replica,
+// the keys of one call are removed atomically unless Redis runs as a cluster
+func (r *Store) InvalidateTag(ctx context.Context, tags ...string) error {
+	if r.cluster {
+		return r.invalidateTagCluster(ctx, tags)
+	}
+	keys := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		keys = append(keys, r.KeyTag(tag))
+	}
+	deleted, err := invalidateScript.Run(ctx, r.client, keys, r.KeyInvalidation(), r.node).StringSlice()
+	if err != nil {
+		return err
+	}
+	r.local.delete(deleted...)
+	return nil
+}
+
+// setTaggedCluster is setTagged for a cluster, where a script cannot touch a
+// key and tag sets of other hash slots. The key is stored before it is
+// tagged, so an invalidation in between leaves it in the new tag set.
+func (r *Store) setTaggedCluster(ctx context.Context, key string, data []byte, ttl time.Duration, tags []string) error {
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, key, data, ttl)
+		for _, tag := range tags {
+			tagKeyScript.Eval(ctx, pipe, []string{r.KeyTag(tag)}, key, ttl.Milliseconds())
+		}
+		r.publish(ctx, pipe, key)
+		return nil
+	})
+	return err
+}
+
+// invalidateTagCluster is InvalidateTag for a cluster. Each tag set is
+// renamed before its keys are deleted, so every key tagged before the call
+// is removed, but the tags of one call are not removed atomically.
+func (r *Store) invalidateTagCluster(ctx context.Context, tags []string) error {
+	token, err := newToken()
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		set := r.KeyTag(tag)
+		taken := set + ":invalidating:" + token
+		found, err := takeTagScript.Run(ctx, r.client, []string{set, taken}).Bool()
+		if err != nil {
+			return err
+		}
+		if !found {
+			continue
+		}
+		keys, err := r.client.SMembers(ctx, taken).Result()
+		if err != nil {
+			return err
+		}
+		_, err = r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			// one DEL per key, the keys are spread over hash slots
+			for _, key := range keys {
+				pipe.Del(ctx, key)
+			}
+			pipe.Del(ctx, taken)
+			if len(keys) > 0 {
+				r.publish(ctx, pipe, keys...)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		r.local.delete(keys...)
+	}
+	return nil
+}
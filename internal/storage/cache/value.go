@@ -0,0 +1,189 @@
+package cache
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// DefaultCompressThreshold is the smallest value compressed when REDIS_COMPRESS_THRESHOLD is not set
+const DefaultCompressThreshold = 1024
+
+// Every stored value starts with a header: the magic byte, the header
+// version, the codec, the compression and the shape of the stored type.
+// Values without a header were stored as plain JSON before headers existed.
+const (
+	headerMagic   byte = 0xCA // never the first byte of a JSON document
+	headerVersion byte = 1
+	headerSize         = 8
+)
+
+// compression ids written in the value header, never reuse one
+const (
+	compressNone   byte = 0
+	compressZstd   byte = 1
+	compressSnappy byte = 2
+)
+
+// compressionNames are the values REDIS_COMPRESSION accepts
+var compressionNames = map[string]byte{
+	"":       compressNone,
+	"none":   compressNone,
+	"zstd":   compressZstd,
+	"snappy": compressSnappy,
+}
+
+// ErrVersionMismatch is returned for a value stored with another shape of
+// its type, e.g. by a replica running the previous release. Callers treat it
+// as a miss and store the value again.
+var ErrVersionMismatch = errors.New("cache: value was stored with another version of its type")
+
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// encode marshals value with a header, shape is the shape of the type it is read back into
+func (r *Store) encode(value interface{}, shape uint32) ([]byte, error) {
+	id := r.codec
+	body, err := codecs[id].Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	compression := compressNone
+	if r.compression != compressNone && len(body) >= r.compressThreshold {
+		compression = r.compression
+	}
+	data := make([]byte, headerSize, headerSize+len(body))
+	data[0], data[1], data[2], data[3] = headerMagic, headerVersion, id, compression
+	binary.BigEndian.PutUint32(data[4:], shape)
+	switch compression {
+	case compressZstd:
+		return zstdEncoder.EncodeAll(body, data), nil
+	case compressSnappy:
+		return append(data, snappy.Encode(nil, body)...), nil
+	}
+	return append(data, body...), nil
+}
+
+// decode un marshals data into dest (must be a pointer) with the codec it was stored with
+func decode(data []byte, dest interface{}) error {
+	if len(data) == 0 || data[0] != headerMagic {
+		return json.Unmarshal(data, dest)
+	}
+	if !hasShape(data, shapeOf(reflect.TypeOf(dest))) {
+		return ErrVersionMismatch
+	}
+	codec, ok := codecs[data[2]]
+	if !ok {
+		return fmt.Errorf("cache: unknown codec %d", data[2])
+	}
+
+	body := data[headerSize:]
+	var err error
+	switch data[3] {
+	case compressNone:
+	case compressZstd:
+		body, err = zstdDecoder.DecodeAll(body, nil)
+	case compressSnappy:
+		body, err = snappy.Decode(nil, body)
+	default:
+		err = fmt.Errorf("cache: unknown compression %d", data[3])
+	}
+	if err != nil {
+		return err
+	}
+	return codec.Unmarshal(body, dest)
+}
+
+// hasShape reports whether data can be read into a type of shape, values
+// without a header are assumed to
+func hasShape(data []byte, shape uint32) bool {
+	if len(data) == 0 || data[0] != headerMagic {
+		return true
+	}
+	return len(data) >= headerSize && data[1] == headerVersion &&
+		binary.BigEndian.Uint32(data[4:]) == shape
+}
+
+var (
+	shapes        sync.Map // reflect.Type to its shape
+	jsonMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// shapeOf hashes the encoded layout of t: the exported fields of structs with
+// their names, tags and types. Pointers are ignored and all integers, like
+// all floats, share a shape, so only changes that break decoding change it.
+func shapeOf(t reflect.Type) uint32 {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return 0
+	}
+	if shape, ok := shapes.Load(t); ok {
+		return shape.(uint32)
+	}
+	h := fnv.New32a()
+	writeShape(h, t, map[reflect.Type]bool{})
+	shape := h.Sum32()
+	shapes.Store(t, shape)
+	return shape
+}
+
+func writeShape(w io.Writer, t reflect.Type, seen map[reflect.Type]bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	ptr := reflect.PointerTo(t)
+	if t.Name() != "" && (ptr.Implements(jsonMarshaler) || ptr.Implements(textMarshaler)) {
+		fmt.Fprintf(w, "%s.%s", t.PkgPath(), t.Name())
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		io.WriteString(w, "int")
+	case reflect.Float32, reflect.Float64:
+		io.WriteString(w, "float")
+	case reflect.Slice, reflect.Array:
+		io.WriteString(w, "[]")
+		writeShape(w, t.Elem(), seen)
+	case reflect.Map:
+		io.WriteString(w, "map[")
+		writeShape(w, t.Key(), seen)
+		io.WriteString(w, "]")
+		writeShape(w, t.Elem(), seen)
+	case reflect.Struct:
+		if seen[t] {
+			fmt.Fprintf(w, "%s", t)
+			return
+		}
+		seen[t] = true
+		io.WriteString(w, "{")
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fmt.Fprintf(w, "%s %q ", field.Name, field.Tag.Get("json"))
+			writeShape(w, field.Type, seen)
+			io.WriteString(w, ";")
+		}
+		io.WriteString(w, "}")
+	default:
+		io.WriteString(w, t.Kind().String())
+	}
+}
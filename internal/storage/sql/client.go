@@ -1,16 +1,88 @@
 package sql
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 	"go-worker/internal/config"
 	"go-worker/internal/storage/sql/sqlc"
 	"log"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"go.uber.org/fx"
+)
+
+// pool defaults used when DatabaseCfg leaves a value at zero
+const (
+	defaultMaxOpenConns    = 25
+	defaultMaxIdleConns    = 5
+	defaultConnMaxLifetime = 30 * time.Minute
+	defaultConnectTimeout  = 30 * time.Second
+)
+
+const (
+	// pingBackoff is the first wait between two pings at startup, it doubles up to pingMaxBackoff
+	pingBackoff    = 100 * time.Millisecond
+	pingMaxBackoff = 2 * time.Second
 )
 
-func InitialDB(cfg *config.Config) sqlc.DBTX {
-	sql, err := sql.Open("postgres", cfg.Database.DSN)
+// InitialDB opens a pgx connection pool, waits until the database answers
+// for at most DATABASE_CONNECT_TIMEOUT and closes the pool when the app stops
+func InitialDB(lc fx.Lifecycle, cfg *config.Config) (sqlc.DBTX, error) {
+	connConfig, err := pgx.ParseConfig(cfg.Database.DSN)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("parsing DATABASE_DSN: %w", err)
+	}
+	if timeout := cfg.Database.StatementTimeout; timeout > 0 {
+		connConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(timeout.Milliseconds(), 10)
+	}
+
+	db := stdlib.OpenDB(*connConfig)
+	db.SetMaxOpenConns(orDefault(cfg.Database.MaxOpenConns, defaultMaxOpenConns))
+	db.SetMaxIdleConns(orDefault(cfg.Database.MaxIdleConns, defaultMaxIdleConns))
+	db.SetConnMaxLifetime(orDefault(cfg.Database.ConnMaxLifetime, defaultConnMaxLifetime))
+
+	if err := ping(db, orDefault(cfg.Database.ConnectTimeout, defaultConnectTimeout)); err != nil {
+		db.Close()
+		return nil, err
+	}
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			log.Println("closing database pool...")
+			return db.Close()
+		},
+	})
+	return db, nil
+}
+
+// ping retries with a growing backoff until the database answers or timeout passes
+func ping(db *sql.DB, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	backoff := pingBackoff
+	for {
+		err := db.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+		log.Printf("⚠️ database is not reachable yet, retrying in %v: %v\n", backoff, err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("database not reachable within %v: %w", timeout, err)
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, pingMaxBackoff)
+	}
+}
+
+func orDefault[T comparable](value, fallback T) T {
+	var zero T
+	if value == zero {
+		return fallback
 	}
-	return sql
+	return value
 }
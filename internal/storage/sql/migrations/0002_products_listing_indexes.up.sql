@@ -0,0 +1,3 @@
+CREATE INDEX products_created_at_id_idx ON products (created_at, id);
+CREATE INDEX products_price_id_idx ON products (price, id);
+CREATE INDEX products_name_id_idx ON products (product_name, id);
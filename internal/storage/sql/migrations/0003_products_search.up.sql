@@ -0,0 +1,10 @@
+-- the searched document of a product, it is indexed rather than stored so
+-- product rows do not carry it
+CREATE FUNCTION product_search_vector(name TEXT, description TEXT) RETURNS tsvector
+LANGUAGE SQL IMMUTABLE PARALLEL SAFE
+AS $$
+  SELECT setweight(to_tsvector('english', name), 'A') ||
+    setweight(to_tsvector('english', description), 'B')
+$$;
+
+CREATE INDEX products_search_vector_idx ON products USING GIN (product_search_vector(product_name, product_description));
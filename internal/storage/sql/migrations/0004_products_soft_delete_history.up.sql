@@ -0,0 +1,13 @@
+ALTER TABLE products ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE TABLE product_history (
+  id BIGSERIAL PRIMARY KEY,
+  product_id INT NOT NULL REFERENCES products (id),
+  action TEXT NOT NULL,
+  admin_id TEXT NOT NULL,
+  before JSONB NOT NULL,
+  after JSONB NOT NULL,
+  created_at TIMESTAMP DEFAULT now() NOT NULL
+);
+
+CREATE INDEX product_history_product_id_idx ON product_history (product_id, id);
@@ -0,0 +1,25 @@
+CREATE TABLE categories (
+  id SERIAL PRIMARY KEY,
+  category_name TEXT NOT NULL,
+  slug TEXT NOT NULL UNIQUE,
+  parent_id INT REFERENCES categories (id),
+  created_at TIMESTAMP DEFAULT now() NOT NULL
+);
+
+CREATE INDEX categories_parent_id_idx ON categories (parent_id);
+
+CREATE TABLE product_categories (
+  product_id INT NOT NULL REFERENCES products (id),
+  category_id INT NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+  PRIMARY KEY (product_id, category_id)
+);
+
+CREATE INDEX product_categories_category_id_idx ON product_categories (category_id);
+
+CREATE TABLE product_tags (
+  product_id INT NOT NULL REFERENCES products (id),
+  tag TEXT NOT NULL,
+  PRIMARY KEY (product_id, tag)
+);
+
+CREATE INDEX product_tags_tag_idx ON product_tags (tag);
@@ -0,0 +1,35 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+ALTER TABLE products ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+CREATE TABLE product_prices (
+  id BIGSERIAL PRIMARY KEY,
+  product_id INT NOT NULL REFERENCES products (id),
+  currency CHAR(3) NOT NULL,
+  amount BIGINT NOT NULL CHECK (amount >= 0),
+  valid_from TIMESTAMP DEFAULT now() NOT NULL,
+  valid_to TIMESTAMP,
+  created_at TIMESTAMP DEFAULT now() NOT NULL,
+  CHECK (valid_to IS NULL OR valid_to > valid_from),
+  -- a product has at most one price per currency at any time
+  CONSTRAINT product_prices_no_overlap EXCLUDE USING gist (
+    product_id WITH =, currency WITH =, tsrange(valid_from, valid_to) WITH &&
+  )
+);
+
+CREATE INDEX product_prices_product_id_currency_idx ON product_prices (product_id, currency, valid_from);
+
+CREATE TABLE product_price_history (
+  id BIGSERIAL PRIMARY KEY,
+  product_id INT NOT NULL REFERENCES products (id),
+  price_id BIGINT NOT NULL,
+  action TEXT NOT NULL,
+  admin_id TEXT NOT NULL,
+  currency CHAR(3) NOT NULL,
+  amount BIGINT NOT NULL,
+  valid_from TIMESTAMP NOT NULL,
+  valid_to TIMESTAMP,
+  created_at TIMESTAMP DEFAULT now() NOT NULL
+);
+
+CREATE INDEX product_price_history_product_id_idx ON product_price_history (product_id, created_at DESC);
@@ -0,0 +1,24 @@
+CREATE TABLE inventory (
+  product_id INT PRIMARY KEY REFERENCES products (id),
+  on_hand INT NOT NULL DEFAULT 0 CHECK (on_hand >= 0),
+  reserved INT NOT NULL DEFAULT 0 CHECK (reserved >= 0),
+  updated_at TIMESTAMP DEFAULT now() NOT NULL,
+  CONSTRAINT inventory_reserved_on_hand CHECK (reserved <= on_hand)
+);
+
+CREATE TABLE stock_reservations (
+  id TEXT PRIMARY KEY,
+  status TEXT NOT NULL,
+  expires_at TIMESTAMP NOT NULL,
+  created_at TIMESTAMP DEFAULT now() NOT NULL,
+  updated_at TIMESTAMP DEFAULT now() NOT NULL
+);
+
+CREATE INDEX stock_reservations_pending_idx ON stock_reservations (expires_at) WHERE status = 'pending';
+
+CREATE TABLE stock_reservation_items (
+  reservation_id TEXT NOT NULL REFERENCES stock_reservations (id),
+  product_id INT NOT NULL REFERENCES products (id),
+  quantity INT NOT NULL CHECK (quantity > 0),
+  PRIMARY KEY (reservation_id, product_id)
+);
@@ -0,0 +1,16 @@
+CREATE TABLE outbox (
+  id BIGSERIAL PRIMARY KEY,
+  aggregate_type TEXT NOT NULL,
+  aggregate_id TEXT NOT NULL,
+  event_type TEXT NOT NULL,
+  payload JSONB NOT NULL,
+  created_at TIMESTAMP DEFAULT now() NOT NULL,
+  published_at TIMESTAMP,
+  attempts INT NOT NULL DEFAULT 0,
+  last_error TEXT,
+  locked_until TIMESTAMP,
+  parked_at TIMESTAMP
+);
+
+CREATE INDEX outbox_pending_idx ON outbox (aggregate_type, aggregate_id, id) WHERE published_at IS NULL AND parked_at IS NULL;
+CREATE INDEX outbox_published_at_idx ON outbox (published_at) WHERE published_at IS NOT NULL;
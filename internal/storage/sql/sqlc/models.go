@@ -5,9 +5,40 @@
 package sqlc
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 )
 
+type Category struct {
+	ID           int32
+	CategoryName string
+	Slug         string
+	ParentID     sql.NullInt32
+	CreatedAt    time.Time
+}
+
+type Inventory struct {
+	ProductID int32
+	OnHand    int32
+	Reserved  int32
+	UpdatedAt time.Time
+}
+
+type Outbox struct {
+	ID            int64
+	AggregateType string
+	AggregateID   string
+	EventType     string
+	Payload       json.RawMessage
+	CreatedAt     time.Time
+	PublishedAt   sql.NullTime
+	Attempts      int32
+	LastError     sql.NullString
+	LockedUntil   sql.NullTime
+	ParkedAt      sql.NullTime
+}
+
 type Product struct {
 	ID                 int32
 	ProductName        string
@@ -15,4 +46,64 @@ type Product struct {
 	Price              int64
 	IsActive           bool
 	CreatedAt          time.Time
+	DeletedAt          sql.NullTime
+	Version            int32
+	Currency           string
+}
+
+type ProductCategory struct {
+	ProductID  int32
+	CategoryID int32
+}
+
+type ProductHistory struct {
+	ID        int64
+	ProductID int32
+	Action    string
+	AdminID   string
+	Before    json.RawMessage
+	After     json.RawMessage
+	CreatedAt time.Time
+}
+
+type ProductPrice struct {
+	ID        int64
+	ProductID int32
+	Currency  string
+	Amount    int64
+	ValidFrom time.Time
+	ValidTo   sql.NullTime
+	CreatedAt time.Time
+}
+
+type ProductPriceHistory struct {
+	ID        int64
+	ProductID int32
+	PriceID   int64
+	Action    string
+	AdminID   string
+	Currency  string
+	Amount    int64
+	ValidFrom time.Time
+	ValidTo   sql.NullTime
+	CreatedAt time.Time
+}
+
+type ProductTag struct {
+	ProductID int32
+	Tag       string
+}
+
+type StockReservation struct {
+	ID        string
+	Status    string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type StockReservationItem struct {
+	ReservationID string
+	ProductID     int32
+	Quantity      int32
 }
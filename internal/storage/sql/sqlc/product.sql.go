@@ -7,12 +7,74 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
 )
 
+const countProducts = `-- name: CountProducts :one
+SELECT count(*) FROM products
+WHERE deleted_at IS NULL
+  AND ($1::boolean IS NULL OR is_active = $1::boolean)
+  AND ($2::bigint IS NULL OR price >= $2::bigint)
+  AND ($3::bigint IS NULL OR price <= $3::bigint)
+  AND ($4::text IS NULL OR product_name ILIKE '%' || $4::text || '%')
+  AND ($5::int IS NULL OR id IN (
+    SELECT pc.product_id FROM product_categories pc
+    WHERE pc.category_id IN (
+      WITH RECURSIVE tree AS (
+        SELECT c.id FROM categories c WHERE c.id = $5::int
+        UNION
+        SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+      )
+      SELECT tree.id FROM tree
+    )
+  ))
+  AND ($6::text IS NULL OR id IN (
+    SELECT pt.product_id FROM product_tags pt WHERE pt.tag = $6::text
+  ))
+`
+
+type CountProductsParams struct {
+	IsActive   sql.NullBool
+	MinPrice   sql.NullInt64
+	MaxPrice   sql.NullInt64
+	Name       sql.NullString
+	CategoryID sql.NullInt32
+	Tag        sql.NullString
+}
+
+func (q *Queries) CountProducts(ctx context.Context, arg CountProductsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countProducts,
+		arg.IsActive,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.Name,
+		arg.CategoryID,
+		arg.Tag,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countSearchProducts = `-- name: CountSearchProducts :one
+SELECT count(*) FROM products
+WHERE deleted_at IS NULL AND is_active
+  AND product_search_vector(product_name, product_description) @@ to_tsquery('english', $1::text)
+`
+
+func (q *Queries) CountSearchProducts(ctx context.Context, query string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSearchProducts, query)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createProduct = `-- name: CreateProduct :one
-INSERT INTO products (product_name, product_description, price, is_active)
-VALUES ($1, $2, $3, $4)
-RETURNING id, product_name, product_description, price, is_active, created_at
+INSERT INTO products (product_name, product_description, price, is_active, currency)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, product_name, product_description, price, is_active, created_at, deleted_at, version, currency
 `
 
 type CreateProductParams struct {
@@ -20,6 +82,7 @@ type CreateProductParams struct {
 	ProductDescription string
 	Price              int64
 	IsActive           bool
+	Currency           string
 }
 
 func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error) {
@@ -28,6 +91,7 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		arg.ProductDescription,
 		arg.Price,
 		arg.IsActive,
+		arg.Currency,
 	)
 	var i Product
 	err := row.Scan(
@@ -37,21 +101,160 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		&i.Price,
 		&i.IsActive,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Currency,
 	)
 	return i, err
 }
 
-const deleteProduct = `-- name: DeleteProduct :exec
-DELETE FROM products WHERE id = $1
+const createProductsBatch = `-- name: CreateProductsBatch :many
+INSERT INTO products (product_name, product_description, price, is_active, currency)
+SELECT unnest($1::text[]), unnest($2::text[]), unnest($3::bigint[]), unnest($4::boolean[]), unnest($5::text[])
+RETURNING id, product_name, product_description, price, is_active, created_at, deleted_at, version, currency
 `
 
-func (q *Queries) DeleteProduct(ctx context.Context, id int32) error {
-	_, err := q.db.ExecContext(ctx, deleteProduct, id)
-	return err
+type CreateProductsBatchParams struct {
+	Names        []string
+	Descriptions []string
+	Prices       []int64
+	IsActive     []bool
+	Currencies   []string
+}
+
+func (q *Queries) CreateProductsBatch(ctx context.Context, arg CreateProductsBatchParams) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, createProductsBatch,
+		pq.Array(arg.Names),
+		pq.Array(arg.Descriptions),
+		pq.Array(arg.Prices),
+		pq.Array(arg.IsActive),
+		pq.Array(arg.Currencies),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductName,
+			&i.ProductDescription,
+			&i.Price,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteProduct = `-- name: DeleteProduct :one
+UPDATE products SET deleted_at = now(), version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, product_name, product_description, price, is_active, created_at, deleted_at, version, currency
+`
+
+func (q *Queries) DeleteProduct(ctx context.Context, id int32) (Product, error) {
+	row := q.db.QueryRowContext(ctx, deleteProduct, id)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.ProductName,
+		&i.ProductDescription,
+		&i.Price,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Currency,
+	)
+	return i, err
+}
+
+const exportProducts = `-- name: ExportProducts :many
+SELECT id, product_name, product_description, price, is_active, created_at, deleted_at, version, currency FROM products
+WHERE deleted_at IS NULL AND id > $1::int
+ORDER BY id
+LIMIT $2::int
+`
+
+type ExportProductsParams struct {
+	AfterID   int32
+	BatchSize int32
+}
+
+func (q *Queries) ExportProducts(ctx context.Context, arg ExportProductsParams) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, exportProducts,
+		arg.AfterID,
+		arg.BatchSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductName,
+			&i.ProductDescription,
+			&i.Price,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeletedProduct = `-- name: GetDeletedProduct :one
+SELECT id, product_name, product_description, price, is_active, created_at, deleted_at, version, currency FROM products WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) GetDeletedProduct(ctx context.Context, id int32) (Product, error) {
+	row := q.db.QueryRowContext(ctx, getDeletedProduct, id)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.ProductName,
+		&i.ProductDescription,
+		&i.Price,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Currency,
+	)
+	return i, err
 }
 
 const getProduct = `-- name: GetProduct :one
-SELECT id, product_name, product_description, price, is_active, created_at FROM products WHERE id = $1
+SELECT id, product_name, product_description, price, is_active, created_at, deleted_at, version, currency FROM products WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetProduct(ctx context.Context, id int32) (Product, error) {
@@ -64,12 +267,15 @@ func (q *Queries) GetProduct(ctx context.Context, id int32) (Product, error) {
 		&i.Price,
 		&i.IsActive,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Currency,
 	)
 	return i, err
 }
 
 const listProducts = `-- name: ListProducts :many
-SELECT id, product_name, product_description, price, is_active, created_at FROM products ORDER BY created_at DESC
+SELECT id, product_name, product_description, price, is_active, created_at, deleted_at, version, currency FROM products WHERE deleted_at IS NULL ORDER BY created_at DESC
 `
 
 func (q *Queries) ListProducts(ctx context.Context) ([]Product, error) {
@@ -88,6 +294,721 @@ func (q *Queries) ListProducts(ctx context.Context) ([]Product, error) {
 			&i.Price,
 			&i.IsActive,
 			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductsByCreatedAtAsc = `-- name: ListProductsByCreatedAtAsc :many
+SELECT id, product_name, product_description, price, is_active, created_at, deleted_at, version, currency FROM products
+WHERE deleted_at IS NULL
+  AND ($1::boolean IS NULL OR is_active = $1::boolean)
+  AND ($2::bigint IS NULL OR price >= $2::bigint)
+  AND ($3::bigint IS NULL OR price <= $3::bigint)
+  AND ($4::text IS NULL OR product_name ILIKE '%' || $4::text || '%')
+  AND ($5::int IS NULL OR id IN (
+    SELECT pc.product_id FROM product_categories pc
+    WHERE pc.category_id IN (
+      WITH RECURSIVE tree AS (
+        SELECT c.id FROM categories c WHERE c.id = $5::int
+        UNION
+        SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+      )
+      SELECT tree.id FROM tree
+    )
+  ))
+  AND ($6::text IS NULL OR id IN (
+    SELECT pt.product_id FROM product_tags pt WHERE pt.tag = $6::text
+  ))
+  AND ($7::int IS NULL
+    OR (created_at, id) > ($8::timestamp, $7::int))
+ORDER BY created_at ASC, id ASC
+LIMIT $9::int
+`
+
+type ListProductsByCreatedAtAscParams struct {
+	IsActive        sql.NullBool
+	MinPrice        sql.NullInt64
+	MaxPrice        sql.NullInt64
+	Name            sql.NullString
+	CategoryID      sql.NullInt32
+	Tag             sql.NullString
+	CursorID        sql.NullInt32
+	CursorCreatedAt sql.NullTime
+	PageSize        int32
+}
+
+func (q *Queries) ListProductsByCreatedAtAsc(ctx context.Context, arg ListProductsByCreatedAtAscParams) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, listProductsByCreatedAtAsc,
+		arg.IsActive,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.Name,
+		arg.CategoryID,
+		arg.Tag,
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductName,
+			&i.ProductDescription,
+			&i.Price,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductsByCreatedAtDesc = `-- name: ListProductsByCreatedAtDesc :many
+SELECT id, product_name, product_description, price, is_active, created_at, deleted_at, version, currency FROM products
+WHERE deleted_at IS NULL
+  AND ($1::boolean IS NULL OR is_active = $1::boolean)
+  AND ($2::bigint IS NULL OR price >= $2::bigint)
+  AND ($3::bigint IS NULL OR price <= $3::bigint)
+  AND ($4::text IS NULL OR product_name ILIKE '%' || $4::text || '%')
+  AND ($5::int IS NULL OR id IN (
+    SELECT pc.product_id FROM product_categories pc
+    WHERE pc.category_id IN (
+      WITH RECURSIVE tree AS (
+        SELECT c.id FROM categories c WHERE c.id = $5::int
+        UNION
+        SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+      )
+      SELECT tree.id FROM tree
+    )
+  ))
+  AND ($6::text IS NULL OR id IN (
+    SELECT pt.product_id FROM product_tags pt WHERE pt.tag = $6::text
+  ))
+  AND ($7::int IS NULL
+    OR (created_at, id) < ($8::timestamp, $7::int))
+ORDER BY created_at DESC, id DESC
+LIMIT $9::int
+`
+
+type ListProductsByCreatedAtDescParams struct {
+	IsActive        sql.NullBool
+	MinPrice        sql.NullInt64
+	MaxPrice        sql.NullInt64
+	Name            sql.NullString
+	CategoryID      sql.NullInt32
+	Tag             sql.NullString
+	CursorID        sql.NullInt32
+	CursorCreatedAt sql.NullTime
+	PageSize        int32
+}
+
+func (q *Queries) ListProductsByCreatedAtDesc(ctx context.Context, arg ListProductsByCreatedAtDescParams) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, listProductsByCreatedAtDesc,
+		arg.IsActive,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.Name,
+		arg.CategoryID,
+		arg.Tag,
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductName,
+			&i.ProductDescription,
+			&i.Price,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductsByNameAsc = `-- name: ListProductsByNameAsc :many
+SELECT id, product_name, product_description, price, is_active, created_at, deleted_at, version, currency FROM products
+WHERE deleted_at IS NULL
+  AND ($1::boolean IS NULL OR is_active = $1::boolean)
+  AND ($2::bigint IS NULL OR price >= $2::bigint)
+  AND ($3::bigint IS NULL OR price <= $3::bigint)
+  AND ($4::text IS NULL OR product_name ILIKE '%' || $4::text || '%')
+  AND ($5::int IS NULL OR id IN (
+    SELECT pc.product_id FROM product_categories pc
+    WHERE pc.category_id IN (
+      WITH RECURSIVE tree AS (
+        SELECT c.id FROM categories c WHERE c.id = $5::int
+        UNION
+        SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+      )
+      SELECT tree.id FROM tree
+    )
+  ))
+  AND ($6::text IS NULL OR id IN (
+    SELECT pt.product_id FROM product_tags pt WHERE pt.tag = $6::text
+  ))
+  AND ($7::int IS NULL
+    OR (product_name, id) > ($8::text, $7::int))
+ORDER BY product_name ASC, id ASC
+LIMIT $9::int
+`
+
+type ListProductsByNameAscParams struct {
+	IsActive   sql.NullBool
+	MinPrice   sql.NullInt64
+	MaxPrice   sql.NullInt64
+	Name       sql.NullString
+	CategoryID sql.NullInt32
+	Tag        sql.NullString
+	CursorID   sql.NullInt32
+	CursorName sql.NullString
+	PageSize   int32
+}
+
+func (q *Queries) ListProductsByNameAsc(ctx context.Context, arg ListProductsByNameAscParams) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, listProductsByNameAsc,
+		arg.IsActive,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.Name,
+		arg.CategoryID,
+		arg.Tag,
+		arg.CursorID,
+		arg.CursorName,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductName,
+			&i.ProductDescription,
+			&i.Price,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductsByNameDesc = `-- name: ListProductsByNameDesc :many
+SELECT id, product_name, product_description, price, is_active, created_at, deleted_at, version, currency FROM products
+WHERE deleted_at IS NULL
+  AND ($1::boolean IS NULL OR is_active = $1::boolean)
+  AND ($2::bigint IS NULL OR price >= $2::bigint)
+  AND ($3::bigint IS NULL OR price <= $3::bigint)
+  AND ($4::text IS NULL OR product_name ILIKE '%' || $4::text || '%')
+  AND ($5::int IS NULL OR id IN (
+    SELECT pc.product_id FROM product_categories pc
+    WHERE pc.category_id IN (
+      WITH RECURSIVE tree AS (
+        SELECT c.id FROM categories c WHERE c.id = $5::int
+        UNION
+        SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+      )
+      SELECT tree.id FROM tree
+    )
+  ))
+  AND ($6::text IS NULL OR id IN (
+    SELECT pt.product_id FROM product_tags pt WHERE pt.tag = $6::text
+  ))
+  AND ($7::int IS NULL
+    OR (product_name, id) < ($8::text, $7::int))
+ORDER BY product_name DESC, id DESC
+LIMIT $9::int
+`
+
+type ListProductsByNameDescParams struct {
+	IsActive   sql.NullBool
+	MinPrice   sql.NullInt64
+	MaxPrice   sql.NullInt64
+	Name       sql.NullString
+	CategoryID sql.NullInt32
+	Tag        sql.NullString
+	CursorID   sql.NullInt32
+	CursorName sql.NullString
+	PageSize   int32
+}
+
+func (q *Queries) ListProductsByNameDesc(ctx context.Context, arg ListProductsByNameDescParams) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, listProductsByNameDesc,
+		arg.IsActive,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.Name,
+		arg.CategoryID,
+		arg.Tag,
+		arg.CursorID,
+		arg.CursorName,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductName,
+			&i.ProductDescription,
+			&i.Price,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductsByPriceAsc = `-- name: ListProductsByPriceAsc :many
+SELECT id, product_name, product_description, price, is_active, created_at, deleted_at, version, currency FROM products
+WHERE deleted_at IS NULL
+  AND ($1::boolean IS NULL OR is_active = $1::boolean)
+  AND ($2::bigint IS NULL OR price >= $2::bigint)
+  AND ($3::bigint IS NULL OR price <= $3::bigint)
+  AND ($4::text IS NULL OR product_name ILIKE '%' || $4::text || '%')
+  AND ($5::int IS NULL OR id IN (
+    SELECT pc.product_id FROM product_categories pc
+    WHERE pc.category_id IN (
+      WITH RECURSIVE tree AS (
+        SELECT c.id FROM categories c WHERE c.id = $5::int
+        UNION
+        SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+      )
+      SELECT tree.id FROM tree
+    )
+  ))
+  AND ($6::text IS NULL OR id IN (
+    SELECT pt.product_id FROM product_tags pt WHERE pt.tag = $6::text
+  ))
+  AND ($7::int IS NULL
+    OR (price, id) > ($8::bigint, $7::int))
+ORDER BY price ASC, id ASC
+LIMIT $9::int
+`
+
+type ListProductsByPriceAscParams struct {
+	IsActive    sql.NullBool
+	MinPrice    sql.NullInt64
+	MaxPrice    sql.NullInt64
+	Name        sql.NullString
+	CategoryID  sql.NullInt32
+	Tag         sql.NullString
+	CursorID    sql.NullInt32
+	CursorPrice sql.NullInt64
+	PageSize    int32
+}
+
+func (q *Queries) ListProductsByPriceAsc(ctx context.Context, arg ListProductsByPriceAscParams) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, listProductsByPriceAsc,
+		arg.IsActive,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.Name,
+		arg.CategoryID,
+		arg.Tag,
+		arg.CursorID,
+		arg.CursorPrice,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductName,
+			&i.ProductDescription,
+			&i.Price,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductsByPriceDesc = `-- name: ListProductsByPriceDesc :many
+SELECT id, product_name, product_description, price, is_active, created_at, deleted_at, version, currency FROM products
+WHERE deleted_at IS NULL
+  AND ($1::boolean IS NULL OR is_active = $1::boolean)
+  AND ($2::bigint IS NULL OR price >= $2::bigint)
+  AND ($3::bigint IS NULL OR price <= $3::bigint)
+  AND ($4::text IS NULL OR product_name ILIKE '%' || $4::text || '%')
+  AND ($5::int IS NULL OR id IN (
+    SELECT pc.product_id FROM product_categories pc
+    WHERE pc.category_id IN (
+      WITH RECURSIVE tree AS (
+        SELECT c.id FROM categories c WHERE c.id = $5::int
+        UNION
+        SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+      )
+      SELECT tree.id FROM tree
+    )
+  ))
+  AND ($6::text IS NULL OR id IN (
+    SELECT pt.product_id FROM product_tags pt WHERE pt.tag = $6::text
+  ))
+  AND ($7::int IS NULL
+    OR (price, id) < ($8::bigint, $7::int))
+ORDER BY price DESC, id DESC
+LIMIT $9::int
+`
+
+type ListProductsByPriceDescParams struct {
+	IsActive    sql.NullBool
+	MinPrice    sql.NullInt64
+	MaxPrice    sql.NullInt64
+	Name        sql.NullString
+	CategoryID  sql.NullInt32
+	Tag         sql.NullString
+	CursorID    sql.NullInt32
+	CursorPrice sql.NullInt64
+	PageSize    int32
+}
+
+func (q *Queries) ListProductsByPriceDesc(ctx context.Context, arg ListProductsByPriceDescParams) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, listProductsByPriceDesc,
+		arg.IsActive,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.Name,
+		arg.CategoryID,
+		arg.Tag,
+		arg.CursorID,
+		arg.CursorPrice,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductName,
+			&i.ProductDescription,
+			&i.Price,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockDeletedProduct = `-- name: LockDeletedProduct :one
+SELECT id, product_name, product_description, price, is_active, created_at, deleted_at, version, currency FROM products WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE
+`
+
+func (q *Queries) LockDeletedProduct(ctx context.Context, id int32) (Product, error) {
+	row := q.db.QueryRowContext(ctx, lockDeletedProduct, id)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.ProductName,
+		&i.ProductDescription,
+		&i.Price,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Currency,
+	)
+	return i, err
+}
+
+const lockProduct = `-- name: LockProduct :one
+SELECT id, product_name, product_description, price, is_active, created_at, deleted_at, version, currency FROM products WHERE id = $1 AND deleted_at IS NULL FOR UPDATE
+`
+
+func (q *Queries) LockProduct(ctx context.Context, id int32) (Product, error) {
+	row := q.db.QueryRowContext(ctx, lockProduct, id)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.ProductName,
+		&i.ProductDescription,
+		&i.Price,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Currency,
+	)
+	return i, err
+}
+
+const lockProducts = `-- name: LockProducts :many
+SELECT id, product_name, product_description, price, is_active, created_at, deleted_at, version, currency FROM products
+WHERE id = ANY($1::int[]) AND deleted_at IS NULL
+ORDER BY id
+FOR UPDATE
+`
+
+func (q *Queries) LockProducts(ctx context.Context, ids []int32) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, lockProducts, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductName,
+			&i.ProductDescription,
+			&i.Price,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const patchProduct = `-- name: PatchProduct :one
+UPDATE products
+SET product_name = COALESCE($1::text, product_name),
+    product_description = COALESCE($2::text, product_description),
+    price = COALESCE($3::bigint, price),
+    is_active = COALESCE($4::boolean, is_active),
+    currency = COALESCE($5::text, currency),
+    version = version + 1
+WHERE id = $6 AND deleted_at IS NULL
+  AND ($7::int = 0 OR version = $7::int)
+RETURNING id, product_name, product_description, price, is_active, created_at, deleted_at, version, currency
+`
+
+type PatchProductParams struct {
+	ProductName        sql.NullString
+	ProductDescription sql.NullString
+	Price              sql.NullInt64
+	IsActive           sql.NullBool
+	Currency           sql.NullString
+	ID                 int32
+	ExpectedVersion    int32
+}
+
+func (q *Queries) PatchProduct(ctx context.Context, arg PatchProductParams) (Product, error) {
+	row := q.db.QueryRowContext(ctx, patchProduct,
+		arg.ProductName,
+		arg.ProductDescription,
+		arg.Price,
+		arg.IsActive,
+		arg.Currency,
+		arg.ID,
+		arg.ExpectedVersion,
+	)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.ProductName,
+		&i.ProductDescription,
+		&i.Price,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Currency,
+	)
+	return i, err
+}
+
+const restoreProduct = `-- name: RestoreProduct :one
+UPDATE products SET deleted_at = NULL, version = version + 1
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, product_name, product_description, price, is_active, created_at, deleted_at, version, currency
+`
+
+func (q *Queries) RestoreProduct(ctx context.Context, id int32) (Product, error) {
+	row := q.db.QueryRowContext(ctx, restoreProduct, id)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.ProductName,
+		&i.ProductDescription,
+		&i.Price,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Currency,
+	)
+	return i, err
+}
+
+const searchProducts = `-- name: SearchProducts :many
+SELECT id, product_name, product_description, price, currency,
+  ts_rank(product_search_vector(product_name, product_description), to_tsquery('english', $1::text))::real AS rank,
+  ts_headline('english', product_name, to_tsquery('english', $1::text),
+    'StartSel=<mark>, StopSel=</mark>, HighlightAll=true')::text AS name_highlight,
+  ts_headline('english', product_description, to_tsquery('english', $1::text),
+    'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5')::text AS description_highlight
+FROM products
+WHERE deleted_at IS NULL AND is_active
+  AND product_search_vector(product_name, product_description) @@ to_tsquery('english', $1::text)
+ORDER BY rank DESC, id DESC
+LIMIT $2::int OFFSET $3::int
+`
+
+type SearchProductsParams struct {
+	Query      string
+	PageSize   int32
+	PageOffset int32
+}
+
+type SearchProductsRow struct {
+	ID                   int32
+	ProductName          string
+	ProductDescription   string
+	Price                int64
+	Currency             string
+	Rank                 float32
+	NameHighlight        string
+	DescriptionHighlight string
+}
+
+func (q *Queries) SearchProducts(ctx context.Context, arg SearchProductsParams) ([]SearchProductsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchProducts,
+		arg.Query,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchProductsRow
+	for rows.Next() {
+		var i SearchProductsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductName,
+			&i.ProductDescription,
+			&i.Price,
+			&i.Currency,
+			&i.Rank,
+			&i.NameHighlight,
+			&i.DescriptionHighlight,
 		); err != nil {
 			return nil, err
 		}
@@ -104,9 +1025,10 @@ func (q *Queries) ListProducts(ctx context.Context) ([]Product, error) {
 
 const updateProduct = `-- name: UpdateProduct :one
 UPDATE products
-SET product_name = $2, product_description = $3, price = $4, is_active = $5
-WHERE id = $1
-RETURNING id, product_name, product_description, price, is_active, created_at
+SET product_name = $2, product_description = $3, price = $4, is_active = $5, currency = $6, version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+  AND ($7::int = 0 OR version = $7::int)
+RETURNING id, product_name, product_description, price, is_active, created_at, deleted_at, version, currency
 `
 
 type UpdateProductParams struct {
@@ -115,6 +1037,8 @@ type UpdateProductParams struct {
 	ProductDescription string
 	Price              int64
 	IsActive           bool
+	Currency           string
+	ExpectedVersion    int32
 }
 
 func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (Product, error) {
@@ -124,6 +1048,8 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		arg.ProductDescription,
 		arg.Price,
 		arg.IsActive,
+		arg.Currency,
+		arg.ExpectedVersion,
 	)
 	var i Product
 	err := row.Scan(
@@ -133,6 +1059,72 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		&i.Price,
 		&i.IsActive,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Currency,
 	)
 	return i, err
 }
+
+const updateProductsBatch = `-- name: UpdateProductsBatch :many
+UPDATE products AS p
+SET product_name = u.product_name,
+    product_description = u.product_description,
+    price = u.price,
+    is_active = u.is_active,
+    currency = COALESCE(NULLIF(u.currency, ''), p.currency),
+    version = p.version + 1
+FROM unnest($1::int[], $2::text[], $3::text[], $4::bigint[], $5::boolean[], $6::text[])
+  AS u(id, product_name, product_description, price, is_active, currency)
+WHERE p.id = u.id AND p.deleted_at IS NULL
+RETURNING p.id, p.product_name, p.product_description, p.price, p.is_active, p.created_at, p.deleted_at, p.version, p.currency
+`
+
+type UpdateProductsBatchParams struct {
+	Ids          []int32
+	Names        []string
+	Descriptions []string
+	Prices       []int64
+	IsActive     []bool
+	Currencies   []string
+}
+
+func (q *Queries) UpdateProductsBatch(ctx context.Context, arg UpdateProductsBatchParams) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, updateProductsBatch,
+		pq.Array(arg.Ids),
+		pq.Array(arg.Names),
+		pq.Array(arg.Descriptions),
+		pq.Array(arg.Prices),
+		pq.Array(arg.IsActive),
+		pq.Array(arg.Currencies),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductName,
+			&i.ProductDescription,
+			&i.Price,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,60 @@
+-- name: CreateCategory :one
+INSERT INTO categories (category_name, slug, parent_id)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetCategory :one
+SELECT * FROM categories WHERE id = $1;
+
+-- name: ListCategories :many
+SELECT * FROM categories ORDER BY category_name, id;
+
+-- name: UpdateCategory :one
+UPDATE categories SET category_name = $2, slug = $3, parent_id = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteCategory :execrows
+DELETE FROM categories WHERE id = $1;
+
+-- name: CountChildCategories :one
+SELECT count(*) FROM categories WHERE parent_id = $1;
+
+-- name: ListCategoryAncestors :many
+WITH RECURSIVE ancestors AS (
+  SELECT c.id, c.parent_id FROM categories c WHERE c.id = $1
+  UNION
+  SELECT c.id, c.parent_id FROM categories c JOIN ancestors a ON c.id = a.parent_id
+)
+SELECT id FROM ancestors;
+
+-- name: LockCategory :one
+SELECT * FROM categories WHERE id = $1 FOR UPDATE;
+
+-- name: LockCategories :many
+SELECT id FROM categories WHERE id = ANY(@ids::int[]) ORDER BY id FOR UPDATE;
+
+-- name: ListProductCategories :many
+SELECT c.* FROM categories c
+JOIN product_categories pc ON pc.category_id = c.id
+WHERE pc.product_id = $1
+ORDER BY c.category_name, c.id;
+
+-- name: DeleteProductCategories :exec
+DELETE FROM product_categories WHERE product_id = $1;
+
+-- name: AddProductCategories :exec
+INSERT INTO product_categories (product_id, category_id)
+SELECT @product_id::int, unnest(@category_ids::int[])
+ON CONFLICT DO NOTHING;
+
+-- name: ListProductTags :many
+SELECT tag FROM product_tags WHERE product_id = $1 ORDER BY tag;
+
+-- name: DeleteProductTags :exec
+DELETE FROM product_tags WHERE product_id = $1;
+
+-- name: AddProductTags :exec
+INSERT INTO product_tags (product_id, tag)
+SELECT @product_id::int, unnest(@tags::text[])
+ON CONFLICT DO NOTHING;
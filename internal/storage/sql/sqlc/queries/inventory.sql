@@ -0,0 +1,54 @@
+-- name: SetStock :one
+INSERT INTO inventory (product_id, on_hand)
+VALUES ($1, $2)
+ON CONFLICT (product_id) DO UPDATE SET on_hand = EXCLUDED.on_hand, updated_at = now()
+RETURNING *;
+
+-- name: GetStock :one
+SELECT * FROM inventory WHERE product_id = $1;
+
+-- name: LockStock :many
+SELECT * FROM inventory WHERE product_id = ANY(@product_ids::int[])
+ORDER BY product_id
+FOR UPDATE;
+
+-- name: ReserveStock :exec
+UPDATE inventory SET reserved = reserved + @quantity, updated_at = now()
+WHERE product_id = @product_id;
+
+-- name: ReleaseStock :exec
+UPDATE inventory SET reserved = reserved - @quantity, updated_at = now()
+WHERE product_id = @product_id;
+
+-- name: CommitStock :exec
+UPDATE inventory SET on_hand = on_hand - @quantity, reserved = reserved - @quantity, updated_at = now()
+WHERE product_id = @product_id;
+
+-- name: CreateReservation :one
+INSERT INTO stock_reservations (id, status, expires_at)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: CreateReservationItems :exec
+INSERT INTO stock_reservation_items (reservation_id, product_id, quantity)
+SELECT @reservation_id::text, unnest(@product_ids::int[]), unnest(@quantities::int[]);
+
+-- name: GetReservation :one
+SELECT * FROM stock_reservations WHERE id = $1;
+
+-- name: LockReservation :one
+SELECT * FROM stock_reservations WHERE id = $1 FOR UPDATE;
+
+-- name: ListReservationItems :many
+SELECT * FROM stock_reservation_items WHERE reservation_id = $1 ORDER BY product_id;
+
+-- name: UpdateReservationStatus :one
+UPDATE stock_reservations SET status = $2, updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: ListExpiredReservations :many
+SELECT id FROM stock_reservations
+WHERE status = 'pending' AND expires_at <= @now::timestamp
+ORDER BY expires_at
+LIMIT @batch_size::int;
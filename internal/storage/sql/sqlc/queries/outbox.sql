@@ -0,0 +1,35 @@
+-- name: CreateOutboxEvent :exec
+INSERT INTO outbox (aggregate_type, aggregate_id, event_type, payload)
+VALUES ($1, $2, $3, $4);
+
+-- name: ClaimOutboxEvents :many
+UPDATE outbox SET locked_until = @locked_until::timestamp
+WHERE id IN (
+  SELECT heads.id FROM (
+    SELECT DISTINCT ON (aggregate_type, aggregate_id) o.id, o.locked_until
+    FROM outbox o
+    WHERE o.published_at IS NULL AND o.parked_at IS NULL
+    ORDER BY aggregate_type, aggregate_id, o.id
+  ) heads
+  WHERE heads.locked_until IS NULL OR heads.locked_until <= @now::timestamp
+  ORDER BY heads.id
+  LIMIT @batch_size::int
+)
+AND (locked_until IS NULL OR locked_until <= @now::timestamp)
+RETURNING *;
+
+-- name: MarkOutboxPublished :exec
+UPDATE outbox SET published_at = @now::timestamp, locked_until = NULL, last_error = NULL
+WHERE id = ANY(@ids::bigint[]);
+
+-- name: MarkOutboxFailed :one
+UPDATE outbox
+SET attempts = attempts + 1,
+    last_error = @last_error::text,
+    locked_until = @retry_at::timestamp,
+    parked_at = CASE WHEN attempts + 1 >= @max_attempts::int THEN @now::timestamp END
+WHERE id = @id::bigint
+RETURNING parked_at IS NOT NULL AS parked;
+
+-- name: DeletePublishedOutbox :execrows
+DELETE FROM outbox WHERE published_at < @before::timestamp;
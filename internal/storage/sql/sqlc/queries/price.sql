@@ -0,0 +1,23 @@
+-- name: CreateProductPrice :one
+INSERT INTO product_prices (product_id, currency, amount, valid_from, valid_to)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListProductPrices :many
+SELECT * FROM product_prices WHERE product_id = $1 ORDER BY currency, valid_from;
+
+-- name: ListCurrentProductPrices :many
+SELECT * FROM product_prices
+WHERE product_id = @product_id AND valid_from <= @at::timestamp AND (valid_to IS NULL OR valid_to > @at::timestamp)
+ORDER BY currency;
+
+-- name: DeleteProductPrice :one
+DELETE FROM product_prices WHERE id = $1 AND product_id = $2
+RETURNING *;
+
+-- name: CreatePriceHistory :exec
+INSERT INTO product_price_history (product_id, price_id, action, admin_id, currency, amount, valid_from, valid_to)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: ListPriceHistory :many
+SELECT * FROM product_price_history WHERE product_id = $1 ORDER BY id DESC;
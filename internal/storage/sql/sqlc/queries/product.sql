@@ -1,19 +1,274 @@
 -- name: CreateProduct :one
-INSERT INTO products (product_name, product_description, price, is_active)
-VALUES ($1, $2, $3, $4)
+INSERT INTO products (product_name, product_description, price, is_active, currency)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
 -- name: GetProduct :one
-SELECT * FROM products WHERE id = $1;
+SELECT * FROM products WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetDeletedProduct :one
+SELECT * FROM products WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: LockProduct :one
+SELECT * FROM products WHERE id = $1 AND deleted_at IS NULL FOR UPDATE;
+
+-- name: LockDeletedProduct :one
+SELECT * FROM products WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE;
+
+-- name: LockProducts :many
+SELECT * FROM products
+WHERE id = ANY(@ids::int[]) AND deleted_at IS NULL
+ORDER BY id
+FOR UPDATE;
 
 -- name: ListProducts :many
-SELECT * FROM products ORDER BY created_at DESC;
+SELECT * FROM products WHERE deleted_at IS NULL ORDER BY created_at DESC;
 
 -- name: UpdateProduct :one
 UPDATE products
-SET product_name = $2, product_description = $3, price = $4, is_active = $5
-WHERE id = $1
+SET product_name = $2, product_description = $3, price = $4, is_active = $5, currency = $6, version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+  AND (@expected_version::int = 0 OR version = @expected_version::int)
+RETURNING *;
+
+-- name: DeleteProduct :one
+UPDATE products SET deleted_at = now(), version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
--- name: DeleteProduct :exec
-DELETE FROM products WHERE id = $1;
\ No newline at end of file
+-- name: RestoreProduct :one
+UPDATE products SET deleted_at = NULL, version = version + 1
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: ListProductsByCreatedAtDesc :many
+SELECT * FROM products
+WHERE deleted_at IS NULL
+  AND (sqlc.narg('is_active')::boolean IS NULL OR is_active = sqlc.narg('is_active')::boolean)
+  AND (sqlc.narg('min_price')::bigint IS NULL OR price >= sqlc.narg('min_price')::bigint)
+  AND (sqlc.narg('max_price')::bigint IS NULL OR price <= sqlc.narg('max_price')::bigint)
+  AND (sqlc.narg('name')::text IS NULL OR product_name ILIKE '%' || sqlc.narg('name')::text || '%')
+  AND (sqlc.narg('category_id')::int IS NULL OR id IN (
+    SELECT pc.product_id FROM product_categories pc
+    WHERE pc.category_id IN (
+      WITH RECURSIVE tree AS (
+        SELECT c.id FROM categories c WHERE c.id = sqlc.narg('category_id')::int
+        UNION
+        SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+      )
+      SELECT tree.id FROM tree
+    )
+  ))
+  AND (sqlc.narg('tag')::text IS NULL OR id IN (
+    SELECT pt.product_id FROM product_tags pt WHERE pt.tag = sqlc.narg('tag')::text
+  ))
+  AND (sqlc.narg('cursor_id')::int IS NULL
+    OR (created_at, id) < (sqlc.narg('cursor_created_at')::timestamp, sqlc.narg('cursor_id')::int))
+ORDER BY created_at DESC, id DESC
+LIMIT @page_size::int;
+
+-- name: ListProductsByCreatedAtAsc :many
+SELECT * FROM products
+WHERE deleted_at IS NULL
+  AND (sqlc.narg('is_active')::boolean IS NULL OR is_active = sqlc.narg('is_active')::boolean)
+  AND (sqlc.narg('min_price')::bigint IS NULL OR price >= sqlc.narg('min_price')::bigint)
+  AND (sqlc.narg('max_price')::bigint IS NULL OR price <= sqlc.narg('max_price')::bigint)
+  AND (sqlc.narg('name')::text IS NULL OR product_name ILIKE '%' || sqlc.narg('name')::text || '%')
+  AND (sqlc.narg('category_id')::int IS NULL OR id IN (
+    SELECT pc.product_id FROM product_categories pc
+    WHERE pc.category_id IN (
+      WITH RECURSIVE tree AS (
+        SELECT c.id FROM categories c WHERE c.id = sqlc.narg('category_id')::int
+        UNION
+        SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+      )
+      SELECT tree.id FROM tree
+    )
+  ))
+  AND (sqlc.narg('tag')::text IS NULL OR id IN (
+    SELECT pt.product_id FROM product_tags pt WHERE pt.tag = sqlc.narg('tag')::text
+  ))
+  AND (sqlc.narg('cursor_id')::int IS NULL
+    OR (created_at, id) > (sqlc.narg('cursor_created_at')::timestamp, sqlc.narg('cursor_id')::int))
+ORDER BY created_at ASC, id ASC
+LIMIT @page_size::int;
+
+-- name: ListProductsByPriceAsc :many
+SELECT * FROM products
+WHERE deleted_at IS NULL
+  AND (sqlc.narg('is_active')::boolean IS NULL OR is_active = sqlc.narg('is_active')::boolean)
+  AND (sqlc.narg('min_price')::bigint IS NULL OR price >= sqlc.narg('min_price')::bigint)
+  AND (sqlc.narg('max_price')::bigint IS NULL OR price <= sqlc.narg('max_price')::bigint)
+  AND (sqlc.narg('name')::text IS NULL OR product_name ILIKE '%' || sqlc.narg('name')::text || '%')
+  AND (sqlc.narg('category_id')::int IS NULL OR id IN (
+    SELECT pc.product_id FROM product_categories pc
+    WHERE pc.category_id IN (
+      WITH RECURSIVE tree AS (
+        SELECT c.id FROM categories c WHERE c.id = sqlc.narg('category_id')::int
+        UNION
+        SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+      )
+      SELECT tree.id FROM tree
+    )
+  ))
+  AND (sqlc.narg('tag')::text IS NULL OR id IN (
+    SELECT pt.product_id FROM product_tags pt WHERE pt.tag = sqlc.narg('tag')::text
+  ))
+  AND (sqlc.narg('cursor_id')::int IS NULL
+    OR (price, id) > (sqlc.narg('cursor_price')::bigint, sqlc.narg('cursor_id')::int))
+ORDER BY price ASC, id ASC
+LIMIT @page_size::int;
+
+-- name: ListProductsByPriceDesc :many
+SELECT * FROM products
+WHERE deleted_at IS NULL
+  AND (sqlc.narg('is_active')::boolean IS NULL OR is_active = sqlc.narg('is_active')::boolean)
+  AND (sqlc.narg('min_price')::bigint IS NULL OR price >= sqlc.narg('min_price')::bigint)
+  AND (sqlc.narg('max_price')::bigint IS NULL OR price <= sqlc.narg('max_price')::bigint)
+  AND (sqlc.narg('name')::text IS NULL OR product_name ILIKE '%' || sqlc.narg('name')::text || '%')
+  AND (sqlc.narg('category_id')::int IS NULL OR id IN (
+    SELECT pc.product_id FROM product_categories pc
+    WHERE pc.category_id IN (
+      WITH RECURSIVE tree AS (
+        SELECT c.id FROM categories c WHERE c.id = sqlc.narg('category_id')::int
+        UNION
+        SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+      )
+      SELECT tree.id FROM tree
+    )
+  ))
+  AND (sqlc.narg('tag')::text IS NULL OR id IN (
+    SELECT pt.product_id FROM product_tags pt WHERE pt.tag = sqlc.narg('tag')::text
+  ))
+  AND (sqlc.narg('cursor_id')::int IS NULL
+    OR (price, id) < (sqlc.narg('cursor_price')::bigint, sqlc.narg('cursor_id')::int))
+ORDER BY price DESC, id DESC
+LIMIT @page_size::int;
+
+-- name: ListProductsByNameAsc :many
+SELECT * FROM products
+WHERE deleted_at IS NULL
+  AND (sqlc.narg('is_active')::boolean IS NULL OR is_active = sqlc.narg('is_active')::boolean)
+  AND (sqlc.narg('min_price')::bigint IS NULL OR price >= sqlc.narg('min_price')::bigint)
+  AND (sqlc.narg('max_price')::bigint IS NULL OR price <= sqlc.narg('max_price')::bigint)
+  AND (sqlc.narg('name')::text IS NULL OR product_name ILIKE '%' || sqlc.narg('name')::text || '%')
+  AND (sqlc.narg('category_id')::int IS NULL OR id IN (
+    SELECT pc.product_id FROM product_categories pc
+    WHERE pc.category_id IN (
+      WITH RECURSIVE tree AS (
+        SELECT c.id FROM categories c WHERE c.id = sqlc.narg('category_id')::int
+        UNION
+        SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+      )
+      SELECT tree.id FROM tree
+    )
+  ))
+  AND (sqlc.narg('tag')::text IS NULL OR id IN (
+    SELECT pt.product_id FROM product_tags pt WHERE pt.tag = sqlc.narg('tag')::text
+  ))
+  AND (sqlc.narg('cursor_id')::int IS NULL
+    OR (product_name, id) > (sqlc.narg('cursor_name')::text, sqlc.narg('cursor_id')::int))
+ORDER BY product_name ASC, id ASC
+LIMIT @page_size::int;
+
+-- name: ListProductsByNameDesc :many
+SELECT * FROM products
+WHERE deleted_at IS NULL
+  AND (sqlc.narg('is_active')::boolean IS NULL OR is_active = sqlc.narg('is_active')::boolean)
+  AND (sqlc.narg('min_price')::bigint IS NULL OR price >= sqlc.narg('min_price')::bigint)
+  AND (sqlc.narg('max_price')::bigint IS NULL OR price <= sqlc.narg('max_price')::bigint)
+  AND (sqlc.narg('name')::text IS NULL OR product_name ILIKE '%' || sqlc.narg('name')::text || '%')
+  AND (sqlc.narg('category_id')::int IS NULL OR id IN (
+    SELECT pc.product_id FROM product_categories pc
+    WHERE pc.category_id IN (
+      WITH RECURSIVE tree AS (
+        SELECT c.id FROM categories c WHERE c.id = sqlc.narg('category_id')::int
+        UNION
+        SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+      )
+      SELECT tree.id FROM tree
+    )
+  ))
+  AND (sqlc.narg('tag')::text IS NULL OR id IN (
+    SELECT pt.product_id FROM product_tags pt WHERE pt.tag = sqlc.narg('tag')::text
+  ))
+  AND (sqlc.narg('cursor_id')::int IS NULL
+    OR (product_name, id) < (sqlc.narg('cursor_name')::text, sqlc.narg('cursor_id')::int))
+ORDER BY product_name DESC, id DESC
+LIMIT @page_size::int;
+
+-- name: CountProducts :one
+SELECT count(*) FROM products
+WHERE deleted_at IS NULL
+  AND (sqlc.narg('is_active')::boolean IS NULL OR is_active = sqlc.narg('is_active')::boolean)
+  AND (sqlc.narg('min_price')::bigint IS NULL OR price >= sqlc.narg('min_price')::bigint)
+  AND (sqlc.narg('max_price')::bigint IS NULL OR price <= sqlc.narg('max_price')::bigint)
+  AND (sqlc.narg('name')::text IS NULL OR product_name ILIKE '%' || sqlc.narg('name')::text || '%')
+  AND (sqlc.narg('category_id')::int IS NULL OR id IN (
+    SELECT pc.product_id FROM product_categories pc
+    WHERE pc.category_id IN (
+      WITH RECURSIVE tree AS (
+        SELECT c.id FROM categories c WHERE c.id = sqlc.narg('category_id')::int
+        UNION
+        SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+      )
+      SELECT tree.id FROM tree
+    )
+  ))
+  AND (sqlc.narg('tag')::text IS NULL OR id IN (
+    SELECT pt.product_id FROM product_tags pt WHERE pt.tag = sqlc.narg('tag')::text
+  ));
+
+-- name: SearchProducts :many
+SELECT id, product_name, product_description, price, currency,
+  ts_rank(product_search_vector(product_name, product_description), to_tsquery('english', @query::text))::real AS rank,
+  ts_headline('english', product_name, to_tsquery('english', @query::text),
+    'StartSel=<mark>, StopSel=</mark>, HighlightAll=true')::text AS name_highlight,
+  ts_headline('english', product_description, to_tsquery('english', @query::text),
+    'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5')::text AS description_highlight
+FROM products
+WHERE deleted_at IS NULL AND is_active
+  AND product_search_vector(product_name, product_description) @@ to_tsquery('english', @query::text)
+ORDER BY rank DESC, id DESC
+LIMIT @page_size::int OFFSET @page_offset::int;
+
+-- name: CountSearchProducts :one
+SELECT count(*) FROM products
+WHERE deleted_at IS NULL AND is_active
+  AND product_search_vector(product_name, product_description) @@ to_tsquery('english', @query::text);
+
+-- name: PatchProduct :one
+UPDATE products
+SET product_name = COALESCE(sqlc.narg('product_name')::text, product_name),
+    product_description = COALESCE(sqlc.narg('product_description')::text, product_description),
+    price = COALESCE(sqlc.narg('price')::bigint, price),
+    is_active = COALESCE(sqlc.narg('is_active')::boolean, is_active),
+    currency = COALESCE(sqlc.narg('currency')::text, currency),
+    version = version + 1
+WHERE id = @id AND deleted_at IS NULL
+  AND (@expected_version::int = 0 OR version = @expected_version::int)
+RETURNING *;
+
+-- name: CreateProductsBatch :many
+INSERT INTO products (product_name, product_description, price, is_active, currency)
+SELECT unnest(@names::text[]), unnest(@descriptions::text[]), unnest(@prices::bigint[]), unnest(@is_active::boolean[]), unnest(@currencies::text[])
+RETURNING *;
+
+-- name: UpdateProductsBatch :many
+UPDATE products AS p
+SET product_name = u.product_name,
+    product_description = u.product_description,
+    price = u.price,
+    is_active = u.is_active,
+    currency = COALESCE(NULLIF(u.currency, ''), p.currency),
+    version = p.version + 1
+FROM unnest(@ids::int[], @names::text[], @descriptions::text[], @prices::bigint[], @is_active::boolean[], @currencies::text[])
+  AS u(id, product_name, product_description, price, is_active, currency)
+WHERE p.id = u.id AND p.deleted_at IS NULL
+RETURNING p.*;
+
+-- name: ExportProducts :many
+SELECT * FROM products
+WHERE deleted_at IS NULL AND id > @after_id::int
+ORDER BY id
+LIMIT @batch_size::int;
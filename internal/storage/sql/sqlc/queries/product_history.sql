@@ -0,0 +1,7 @@
+-- name: CreateProductHistory :one
+INSERT INTO product_history (product_id, action, admin_id, before, after)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListProductHistory :many
+SELECT * FROM product_history WHERE product_id = $1 ORDER BY id DESC;
@@ -7,3 +7,138 @@ CREATE TABLE products (
   created_at TIMESTAMP DEFAULT now() NOT NULL
 );
 
+
+CREATE INDEX products_created_at_id_idx ON products (created_at, id);
+CREATE INDEX products_price_id_idx ON products (price, id);
+CREATE INDEX products_name_id_idx ON products (product_name, id);
+
+-- the searched document of a product, it is indexed rather than stored so
+-- product rows do not carry it
+CREATE FUNCTION product_search_vector(name TEXT, description TEXT) RETURNS tsvector
+LANGUAGE SQL IMMUTABLE PARALLEL SAFE
+AS $$
+  SELECT setweight(to_tsvector('english', name), 'A') ||
+    setweight(to_tsvector('english', description), 'B')
+$$;
+
+CREATE INDEX products_search_vector_idx ON products USING GIN (product_search_vector(product_name, product_description));
+
+ALTER TABLE products ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE TABLE product_history (
+  id BIGSERIAL PRIMARY KEY,
+  product_id INT NOT NULL REFERENCES products (id),
+  action TEXT NOT NULL,
+  admin_id TEXT NOT NULL,
+  before JSONB NOT NULL,
+  after JSONB NOT NULL,
+  created_at TIMESTAMP DEFAULT now() NOT NULL
+);
+
+CREATE INDEX product_history_product_id_idx ON product_history (product_id, id);
+
+ALTER TABLE products ADD COLUMN version INT NOT NULL DEFAULT 1;
+
+CREATE TABLE categories (
+  id SERIAL PRIMARY KEY,
+  category_name TEXT NOT NULL,
+  slug TEXT NOT NULL UNIQUE,
+  parent_id INT REFERENCES categories (id),
+  created_at TIMESTAMP DEFAULT now() NOT NULL
+);
+
+CREATE INDEX categories_parent_id_idx ON categories (parent_id);
+
+CREATE TABLE product_categories (
+  product_id INT NOT NULL REFERENCES products (id),
+  category_id INT NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+  PRIMARY KEY (product_id, category_id)
+);
+
+CREATE INDEX product_categories_category_id_idx ON product_categories (category_id);
+
+CREATE TABLE product_tags (
+  product_id INT NOT NULL REFERENCES products (id),
+  tag TEXT NOT NULL,
+  PRIMARY KEY (product_id, tag)
+);
+
+CREATE INDEX product_tags_tag_idx ON product_tags (tag);
+
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+ALTER TABLE products ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+CREATE TABLE product_prices (
+  id BIGSERIAL PRIMARY KEY,
+  product_id INT NOT NULL REFERENCES products (id),
+  currency CHAR(3) NOT NULL,
+  amount BIGINT NOT NULL CHECK (amount >= 0),
+  valid_from TIMESTAMP DEFAULT now() NOT NULL,
+  valid_to TIMESTAMP,
+  created_at TIMESTAMP DEFAULT now() NOT NULL,
+  CHECK (valid_to IS NULL OR valid_to > valid_from),
+  -- a product has at most one price per currency at any time
+  CONSTRAINT product_prices_no_overlap EXCLUDE USING gist (
+    product_id WITH =, currency WITH =, tsrange(valid_from, valid_to) WITH &&
+  )
+);
+
+CREATE INDEX product_prices_product_id_currency_idx ON product_prices (product_id, currency, valid_from);
+
+CREATE TABLE product_price_history (
+  id BIGSERIAL PRIMARY KEY,
+  product_id INT NOT NULL REFERENCES products (id),
+  price_id BIGINT NOT NULL,
+  action TEXT NOT NULL,
+  admin_id TEXT NOT NULL,
+  currency CHAR(3) NOT NULL,
+  amount BIGINT NOT NULL,
+  valid_from TIMESTAMP NOT NULL,
+  valid_to TIMESTAMP,
+  created_at TIMESTAMP DEFAULT now() NOT NULL
+);
+
+CREATE INDEX product_price_history_product_id_idx ON product_price_history (product_id, created_at DESC);
+
+CREATE TABLE inventory (
+  product_id INT PRIMARY KEY REFERENCES products (id),
+  on_hand INT NOT NULL DEFAULT 0 CHECK (on_hand >= 0),
+  reserved INT NOT NULL DEFAULT 0 CHECK (reserved >= 0),
+  updated_at TIMESTAMP DEFAULT now() NOT NULL,
+  CONSTRAINT inventory_reserved_on_hand CHECK (reserved <= on_hand)
+);
+
+CREATE TABLE stock_reservations (
+  id TEXT PRIMARY KEY,
+  status TEXT NOT NULL,
+  expires_at TIMESTAMP NOT NULL,
+  created_at TIMESTAMP DEFAULT now() NOT NULL,
+  updated_at TIMESTAMP DEFAULT now() NOT NULL
+);
+
+CREATE INDEX stock_reservations_pending_idx ON stock_reservations (expires_at) WHERE status = 'pending';
+
+CREATE TABLE stock_reservation_items (
+  reservation_id TEXT NOT NULL REFERENCES stock_reservations (id),
+  product_id INT NOT NULL REFERENCES products (id),
+  quantity INT NOT NULL CHECK (quantity > 0),
+  PRIMARY KEY (reservation_id, product_id)
+);
+
+CREATE TABLE outbox (
+  id BIGSERIAL PRIMARY KEY,
+  aggregate_type TEXT NOT NULL,
+  aggregate_id TEXT NOT NULL,
+  event_type TEXT NOT NULL,
+  payload JSONB NOT NULL,
+  created_at TIMESTAMP DEFAULT now() NOT NULL,
+  published_at TIMESTAMP,
+  attempts INT NOT NULL DEFAULT 0,
+  last_error TEXT,
+  locked_until TIMESTAMP,
+  parked_at TIMESTAMP
+);
+
+CREATE INDEX outbox_pending_idx ON outbox (aggregate_type, aggregate_id, id) WHERE published_at IS NULL AND parked_at IS NULL;
+CREATE INDEX outbox_published_at_idx ON outbox (published_at) WHERE published_at IS NOT NULL;
@@ -0,0 +1,171 @@
+package tx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-worker/internal/storage/sql/sqlc"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrNoTransactions is returned by NewManager for a database that cannot begin transactions
+var ErrNoTransactions = errors.New("database does not support transactions")
+
+const (
+	// defaultMaxAttempts is how often a transaction is run when postgres keeps aborting it
+	defaultMaxAttempts = 3
+	// retryBackoff is the wait before the second attempt, it grows with every attempt
+	retryBackoff = 20 * time.Millisecond
+)
+
+// postgres error codes of transactions that succeed when they are run again
+const (
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+// beginner starts transactions, *sql.DB is one
+type beginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// Manager runs functions in a transaction with queries bound to it, so a
+// service can change several tables at once
+type Manager struct {
+	db    beginner
+	query *sqlc.Queries
+}
+
+func NewManager(db sqlc.DBTX, q *sqlc.Queries) (*Manager, error) {
+	b, ok := db.(beginner)
+	if !ok {
+		return nil, ErrNoTransactions
+	}
+	return &Manager{db: b, query: q}, nil
+}
+
+// Option configures a WithTx call
+type Option func(*options)
+
+type options struct {
+	isolation   sql.IsolationLevel
+	readOnly    bool
+	maxAttempts int
+}
+
+// WithIsolation runs the transaction at level instead of the database default
+func WithIsolation(level sql.IsolationLevel) Option {
+	return func(o *options) {
+		o.isolation = level
+	}
+}
+
+// ReadOnly runs a transaction that cannot write
+func ReadOnly() Option {
+	return func(o *options) {
+		o.readOnly = true
+	}
+}
+
+// WithMaxAttempts sets how often the transaction is run when postgres aborts it
+func WithMaxAttempts(n int) Option {
+	return func(o *options) {
+		o.maxAttempts = n
+	}
+}
+
+// txKey is the context key of the transaction a function runs in
+type txKey struct{}
+
+type txState struct {
+	tx         *sql.Tx
+	query      *sqlc.Queries
+	savepoints int // savepoints made so far, names them
+}
+
+// WithTx runs fn in a transaction that is committed when fn returns nil and
+// rolled back when it returns an error or panics.
+//
+// A WithTx called with the ctx fn got runs in a savepoint of the same
+// transaction instead, its error only rolls back what it changed and its
+// options are ignored. A transaction postgres aborts on a serialization
+// failure or deadlock is run again, so fn must only change the database.
+func (m *Manager) WithTx(ctx context.Context, fn func(ctx context.Context, q *sqlc.Queries) error, opts ...Option) error {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.savepoint(ctx, fn)
+	}
+
+	o := options{maxAttempts: defaultMaxAttempts}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	for attempt := 1; ; attempt++ {
+		err := m.run(ctx, o, fn)
+		if err == nil || !retryable(err) || attempt >= o.maxAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt) * retryBackoff):
+		}
+	}
+}
+
+// Queries returns the queries of the transaction ctx runs in, or queries
+// outside any transaction, so helpers join the transaction of their caller
+func (m *Manager) Queries(ctx context.Context) *sqlc.Queries {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.query
+	}
+	return m.query
+}
+
+func (m *Manager) run(ctx context.Context, o options, fn func(ctx context.Context, q *sqlc.Queries) error) (err error) {
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{Isolation: o.isolation, ReadOnly: o.readOnly})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	state := &txState{tx: tx, query: m.query.WithTx(tx)}
+	if err := fn(context.WithValue(ctx, txKey{}, state), state.query); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// savepoint runs fn in a savepoint of the transaction
+func (s *txState) savepoint(ctx context.Context, fn func(ctx context.Context, q *sqlc.Queries) error) error {
+	s.savepoints++
+	name := fmt.Sprintf("sp_%d", s.savepoints)
+	if _, err := s.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return err
+	}
+	if err := fn(ctx, s.query); err != nil {
+		if _, rollbackErr := s.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+		return err
+	}
+	_, err := s.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	return err
+}
+
+// retryable tells whether err aborted a transaction that can be run again
+func retryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected
+}
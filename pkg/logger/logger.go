@@ -8,8 +8,10 @@ import (
 	"go.uber.org/zap"
 )
 
-func NewLogger() (*zap.Logger, error) {
+// NewLogger builds the logger, level can be changed while it is running
+func NewLogger(level zap.AtomicLevel) (*zap.Logger, error) {
 	cfg := zap.NewProductionConfig()
+	cfg.Level = level
 	cfg.OutputPaths = []string{"stdout"}
 	return cfg.Build()
 }
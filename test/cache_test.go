@@ -0,0 +1,348 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-worker/internal/config"
+	"go-worker/internal/example"
+	"go-worker/internal/poller/dispatcher"
+	"go-worker/internal/poller/job"
+	"go-worker/internal/storage/cache"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/fx/fxtest"
+)
+
+func newTestStore(t *testing.T, opts ...func(*config.RedisCfg)) *cache.Store {
+	t.Helper()
+	// the redis of .env.test, built by hand to keep the process env clean
+	cfg := &config.Config{
+		Redis: config.RedisCfg{
+			DSN:        "localhost:6379",
+			DB:         1,
+			Prefix:     "go-worker-test",
+			DefaultTTL: 1,
+			LocalSize:  100,
+		},
+	}
+	for _, opt := range opts {
+		opt(&cfg.Redis)
+	}
+	client, err := cache.NewClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create the Redis client: %v", err)
+	}
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		t.Skipf("Redis is not available: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return cache.NewCacheStore(client, cfg)
+}
+
+func TestCacheGetOrLoadLoadsOnce(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	key := fmt.Sprintf("test:get-or-load:%d", time.Now().UnixNano())
+	defer store.Delete(ctx, key)
+
+	var loads atomic.Int32
+	load := func(ctx context.Context) (interface{}, error) {
+		loads.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		return "value", nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var value string
+			if err := store.GetOrLoad(ctx, key, &value, 1, load); err != nil {
+				t.Errorf("GetOrLoad failed: %v", err)
+				return
+			}
+			if value != "value" {
+				t.Errorf("Expected value, got %q", value)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := loads.Load(); got != 1 {
+		t.Errorf("Expected 1 load, got %d", got)
+	}
+}
+
+func TestCacheGetOrLoadServesStale(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	key := fmt.Sprintf("test:get-or-load-stale:%d", time.Now().UnixNano())
+	defer store.Delete(ctx, key)
+
+	var loads atomic.Int32
+	load := func(ctx context.Context) (interface{}, error) {
+		return loads.Add(1), nil
+	}
+
+	// a ttl of 0 minutes leaves only the stale window, every read is stale
+	var value int32
+	if err := store.GetOrLoad(ctx, key, &value, 0, load, cache.WithStale(time.Minute)); err != nil {
+		t.Fatalf("GetOrLoad failed: %v", err)
+	}
+	if err := store.GetOrLoad(ctx, key, &value, 0, load, cache.WithStale(time.Minute)); err != nil {
+		t.Fatalf("GetOrLoad failed: %v", err)
+	}
+	if value != 1 {
+		t.Errorf("Expected the stale value 1, got %d", value)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for value != 2 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+		_ = store.Get(ctx, key, &value)
+	}
+	if value != 2 {
+		t.Errorf("Expected the refreshed value 2, got %d", value)
+	}
+}
+
+func TestCacheLocalTierInvalidation(t *testing.T) {
+	writer := newTestStore(t)
+	reader := newTestStore(t)
+	ctx := context.Background()
+	key := fmt.Sprintf("test:local-tier:%d", time.Now().UnixNano())
+	defer writer.Delete(ctx, key)
+
+	lc := fxtest.NewLifecycle(t)
+	cache.RegisterInvalidation(lc, reader)
+	lc.RequireStart()
+	defer lc.RequireStop()
+
+	var value int
+	if err := writer.Set(ctx, key, 1, 1); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := reader.Get(ctx, key, &value); err != nil || value != 1 {
+		t.Fatalf("Expected 1, got %d (%v)", value, err)
+	}
+
+	// the reader now serves its local copy until the writer's change evicts it
+	if err := writer.Set(ctx, key, 2, 1); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for value != 2 && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+		_ = reader.Get(ctx, key, &value)
+	}
+	if value != 2 {
+		t.Errorf("Expected the local copy to be evicted, still got %d", value)
+	}
+}
+
+func TestCacheInvalidateTag(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	prefix := fmt.Sprintf("test:tags:%d:", time.Now().UnixNano())
+	tagA, tagB := prefix+"a", prefix+"b"
+	defer store.InvalidateTag(ctx, tagA, tagB)
+
+	if err := store.Set(ctx, prefix+"1", 1, 1, tagA); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := store.Set(ctx, prefix+"2", 2, 1, tagA, tagB); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := store.Set(ctx, prefix+"3", 3, 1, tagB); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	if err := store.InvalidateTag(ctx, tagA); err != nil {
+		t.Fatalf("InvalidateTag failed: %v", err)
+	}
+	var value int
+	for _, key := range []string{prefix + "1", prefix + "2"} {
+		if err := store.Get(ctx, key, &value); err == nil {
+			t.Errorf("Expected %s to be invalidated, got %d", key, value)
+		}
+	}
+	if err := store.Get(ctx, prefix+"3", &value); err != nil || value != 3 {
+		t.Errorf("Expected %s3 to be kept, got %d (%v)", prefix, value, err)
+	}
+}
+
+type cachedItem struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestCacheCodecs(t *testing.T) {
+	for _, codec := range []string{"json", "msgpack"} {
+		for _, compression := range []string{"none", "zstd", "snappy"} {
+			t.Run(codec+"/"+compression, func(t *testing.T) {
+				store := newTestStore(t, func(cfg *config.RedisCfg) {
+					cfg.Codec = codec
+					cfg.Compression = compression
+					cfg.CompressThreshold = 64
+					cfg.LocalSize = 0
+				})
+				ctx := context.Background()
+				key := fmt.Sprintf("test:codec:%s:%s:%d", codec, compression, time.Now().UnixNano())
+				defer store.Delete(ctx, key)
+
+				// long enough to be compressed
+				item := cachedItem{ID: 7, Name: strings.Repeat("product ", 20)}
+				if err := store.Set(ctx, key, item, 1); err != nil {
+					t.Fatalf("Set failed: %v", err)
+				}
+				var got cachedItem
+				if err := store.Get(ctx, key, &got); err != nil || got != item {
+					t.Fatalf("Expected %+v, got %+v (%v)", item, got, err)
+				}
+			})
+		}
+	}
+}
+
+func TestCacheVersionMismatch(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	key := fmt.Sprintf("test:version:%d", time.Now().UnixNano())
+	defer store.Delete(ctx, key)
+
+	if err := store.Set(ctx, key, cachedItem{ID: 1, Name: "old"}, 1); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	// the next release renamed a field
+	type cachedItemV2 struct {
+		ID    int64  `json:"id"`
+		Title string `json:"title"`
+	}
+	var item cachedItemV2
+	if err := store.Get(ctx, key, &item); !errors.Is(err, cache.ErrVersionMismatch) {
+		t.Fatalf("Expected ErrVersionMismatch, got %v", err)
+	}
+	err := store.GetOrLoad(ctx, key, &item, 1, func(ctx context.Context) (interface{}, error) {
+		return cachedItemV2{ID: 1, Title: "new"}, nil
+	})
+	if err != nil || item.Title != "new" {
+		t.Fatalf("Expected the value to be loaded again, got %+v (%v)", item, err)
+	}
+	if err := store.Get(ctx, key, &item); err != nil || item.Title != "new" {
+		t.Errorf("Expected the loaded value to be stored, got %+v (%v)", item, err)
+	}
+}
+
+func TestCacheHotKeysAndExpiring(t *testing.T) {
+	prefix := fmt.Sprintf("go-worker-test-%d", time.Now().UnixNano())
+	store := newTestStore(t, func(cfg *config.RedisCfg) { cfg.Prefix = prefix })
+	ctx := context.Background()
+	keys := []string{
+		store.KeyProduct(cache.ViewClient, 1),
+		store.KeyProduct(cache.ViewClient, 2),
+		store.KeyProduct(cache.ViewAdmin, 1),
+	}
+	defer store.Delete(ctx, store.KeyAccess())
+	for _, key := range keys {
+		defer store.Delete(ctx, key)
+	}
+
+	var value int
+	for i, key := range keys {
+		for n := 0; n < 3-i; n++ {
+			_ = store.Get(ctx, key, &value)
+		}
+	}
+	// only product keys are counted
+	for n := 0; n < 5; n++ {
+		_ = store.Get(ctx, store.KeyProductsPage(cache.ViewClient, "first"), &value)
+	}
+	hot, err := store.HotKeys(ctx, 2)
+	if err != nil {
+		t.Fatalf("HotKeys failed: %v", err)
+	}
+	if len(hot) != 2 || hot[0] != keys[0] || hot[1] != keys[1] {
+		t.Errorf("Expected %v, got %v", keys[:2], hot)
+	}
+
+	if err := store.Set(ctx, keys[0], 1, 60); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := store.Set(ctx, keys[1], 1, 1); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	expiring, err := store.Expiring(ctx, keys, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("Expiring failed: %v", err)
+	}
+	if len(expiring) != 2 || expiring[0] != keys[1] || expiring[1] != keys[2] {
+		t.Errorf("Expected %v, got %v", keys[1:], expiring)
+	}
+}
+
+func TestRedisOverflowStoreKeepsPoppedJobs(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	service := fmt.Sprintf("test-overflow-%d", time.Now().UnixNano())
+	defer store.Delete(ctx, store.KeyOverflowQueue(service))
+	defer store.Delete(ctx, store.KeyOverflowProcessing(service))
+
+	overflow := dispatcher.NewRedisOverflowStore(store)
+	failDecode := false
+	overflow.Decode(service, func(data []byte) (job.Job, error) {
+		if failDecode {
+			return nil, errors.New("bad job")
+		}
+		return example.DecodeExampleJob(data)
+	})
+	for _, id := range []string{"1", "2"} {
+		if err := overflow.Push(ctx, service, example.NewExampleJob(id, service)); err != nil {
+			t.Fatalf("Push failed: %v", err)
+		}
+	}
+
+	// a requeued job is popped first again
+	j, err := overflow.Pop(ctx, service)
+	if err != nil || j.ID() != "1" {
+		t.Fatalf("Expected job 1, got %v, %v", j, err)
+	}
+	if err := overflow.Requeue(ctx, service, j); err != nil {
+		t.Fatalf("Requeue failed: %v", err)
+	}
+	if j, _ := overflow.Pop(ctx, service); j.ID() != "1" {
+		t.Fatalf("Expected job 1 after the requeue, got %s", j.ID())
+	}
+
+	// a popped job that is not acked comes back on recover
+	if n, err := overflow.Recover(ctx, service); err != nil || n != 1 {
+		t.Fatalf("Expected 1 recovered job, got %d, %v", n, err)
+	}
+	j, _ = overflow.Pop(ctx, service)
+	if j.ID() != "1" {
+		t.Fatalf("Expected job 1 after the recover, got %s", j.ID())
+	}
+	if err := overflow.Ack(ctx, service, j); err != nil {
+		t.Fatalf("Ack failed: %v", err)
+	}
+
+	// a job that cannot be decoded is kept
+	failDecode = true
+	if _, err := overflow.Pop(ctx, service); err == nil {
+		t.Fatal("Expected a decode error")
+	}
+	failDecode = false
+	if n, _ := overflow.Recover(ctx, service); n != 1 {
+		t.Fatalf("Expected the undecoded job to be recovered, got %d", n)
+	}
+	if j, _ := overflow.Pop(ctx, service); j == nil || j.ID() != "2" {
+		t.Fatalf("Expected job 2, got %v", j)
+	}
+	t.Log("✅ Redis overflow store passed")
+}
@@ -0,0 +1,143 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go-worker/internal/auth"
+	"go-worker/internal/config"
+	"go-worker/internal/product/dto"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCategories(t *testing.T) {
+	WithHttpTestServer(t, func() {
+		cfg, err := config.NewConfig()
+		if err != nil {
+			t.Fatalf("Failed to load config: %v", err)
+		}
+		addr := fmt.Sprintf("http://%s:%d", cfg.HTTPAddress, cfg.HTTPPort)
+		token, err := auth.GenerateToken(cfg, "admin-123")
+		if err != nil {
+			t.Fatalf("Failed to generate token: %v", err)
+		}
+
+		var product dto.AdminProductResponse
+		adminCreateProduct(t, &product, addr+"/api/v1/admin/products", token)
+
+		// slugs are unique, keep runs apart
+		suffix := fmt.Sprint(time.Now().UnixNano())
+		var parent, child dto.CategoryResponse
+		t.Run("Create Categories", func(t *testing.T) {
+			status := sendJSON(t, http.MethodPost, addr+"/api/v1/admin/categories/", token,
+				dto.AdminCreateCategoryRequest{Name: "Parent " + suffix}, &parent)
+			if status != http.StatusCreated {
+				t.Fatalf("Expected status 201 Created, got %d", status)
+			}
+			status = sendJSON(t, http.MethodPost, addr+"/api/v1/admin/categories/", token,
+				dto.AdminCreateCategoryRequest{Name: "Child " + suffix, ParentID: &parent.ID}, &child)
+			if status != http.StatusCreated {
+				t.Fatalf("Expected status 201 Created, got %d", status)
+			}
+			if parent.Slug != "parent-"+suffix || child.ParentID == nil || *child.ParentID != parent.ID {
+				t.Errorf("Unexpected categories: %+v %+v", parent, child)
+			}
+		})
+
+		t.Run("Category Cycle", func(t *testing.T) {
+			status := sendJSON(t, http.MethodPut, fmt.Sprintf("%s/api/v1/admin/categories/%d", addr, parent.ID), token,
+				dto.AdminUpdateCategoryRequest{Name: parent.Name, ParentID: &child.ID}, nil)
+			if status != http.StatusConflict {
+				t.Errorf("Expected status 409 Conflict, got %d", status)
+			}
+		})
+
+		t.Run("Set Product Categories And Tags", func(t *testing.T) {
+			var categories []dto.CategoryResponse
+			status := sendJSON(t, http.MethodPut, fmt.Sprintf("%s/api/v1/admin/products/%d/categories", addr, product.ID), token,
+				dto.SetProductCategoriesRequest{CategoryIDs: []int32{child.ID}}, &categories)
+			if status != http.StatusOK || len(categories) != 1 || categories[0].ID != child.ID {
+				t.Fatalf("Unexpected categories (status %d): %+v", status, categories)
+			}
+
+			var tags dto.ProductTagsResponse
+			status = sendJSON(t, http.MethodPut, fmt.Sprintf("%s/api/v1/admin/products/%d/tags", addr, product.ID), token,
+				dto.SetProductTagsRequest{Tags: []string{" Sale ", "tag-" + suffix, "sale"}}, &tags)
+			if status != http.StatusOK || fmt.Sprint(tags.Tags) != "[sale tag-"+suffix+"]" {
+				t.Fatalf("Unexpected tags (status %d): %+v", status, tags)
+			}
+		})
+
+		t.Run("List Products By Category And Tag", func(t *testing.T) {
+			for _, query := range []string{
+				fmt.Sprintf("category_id=%d", parent.ID),
+				"tag=TAG-" + suffix,
+			} {
+				var products dto.ClientListProductsResponse
+				status := sendJSON(t, http.MethodGet, addr+"/api/v1/products?"+query, "", nil, &products)
+				if status != http.StatusOK {
+					t.Fatalf(ExpectedStatus200OKGotMessage, status)
+				}
+				if products.Total != 1 || len(products.Products) != 1 || products.Products[0].ID != product.ID {
+					t.Errorf("Expected only product %d for %s, got %+v", product.ID, query, products)
+				}
+			}
+		})
+
+		t.Run("Delete Categories", func(t *testing.T) {
+			parentURL := fmt.Sprintf("%s/api/v1/admin/categories/%d", addr, parent.ID)
+			if status := sendJSON(t, http.MethodDelete, parentURL, token, nil, nil); status != http.StatusConflict {
+				t.Errorf("Expected status 409 Conflict for a category with subcategories, got %d", status)
+			}
+			childURL := fmt.Sprintf("%s/api/v1/admin/categories/%d", addr, child.ID)
+			if status := sendJSON(t, http.MethodDelete, childURL, token, nil, nil); status != http.StatusNoContent {
+				t.Errorf("Expected status 204 No Content, got %d", status)
+			}
+			if status := sendJSON(t, http.MethodDelete, parentURL, token, nil, nil); status != http.StatusNoContent {
+				t.Errorf("Expected status 204 No Content, got %d", status)
+			}
+		})
+
+		adminDeleteProduct(t, product, addr+"/api/v1/admin/products", token)
+	})
+}
+
+// sendJSON sends body as JSON and decodes a successful response into out, it returns the status code
+func sendJSON(t *testing.T, method, url, token string, body any, out any) int {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("Failed to marshal request: %v", err)
+		}
+		reader = bytes.NewBuffer(data)
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", ApplicationJsonHeader)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		responseBody, _ := io.ReadAll(resp.Body)
+		t.Logf(ResponseBodyMessage, string(responseBody))
+	} else if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf(FailedToDecodeMessage, err)
+		}
+	}
+	return resp.StatusCode
+}
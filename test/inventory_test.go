@@ -0,0 +1,107 @@
+package test
+
+import (
+	"fmt"
+	"go-worker/internal/auth"
+	"go-worker/internal/config"
+	inventoryDTO "go-worker/internal/inventory/dto"
+	"go-worker/internal/product/dto"
+	"net/http"
+	"testing"
+)
+
+func TestInventory(t *testing.T) {
+	WithHttpTestServer(t, func() {
+		cfg, err := config.NewConfig()
+		if err != nil {
+			t.Fatalf("Failed to load config: %v", err)
+		}
+		addr := fmt.Sprintf("http://%s:%d", cfg.HTTPAddress, cfg.HTTPPort)
+		token, err := auth.GenerateToken(cfg, "admin-123")
+		if err != nil {
+			t.Fatalf("Failed to generate token: %v", err)
+		}
+
+		var product dto.AdminProductResponse
+		adminCreateProduct(t, &product, addr+"/api/v1/admin/products", token)
+		stockURL := fmt.Sprintf("%s/api/v1/admin/inventory/stock/%d", addr, product.ID)
+		reservationsURL := addr + "/api/v1/admin/inventory/reservations"
+
+		t.Run("Set Stock", func(t *testing.T) {
+			var stock inventoryDTO.StockResponse
+			status := sendJSON(t, http.MethodPut, stockURL, token, inventoryDTO.SetStockRequest{OnHand: 10}, &stock)
+			if status != http.StatusOK {
+				t.Fatalf(ExpectedStatus200OKGotMessage, status)
+			}
+			if stock.OnHand != 10 || stock.Reserved != 0 || stock.Available != 10 {
+				t.Errorf("Unexpected stock: %+v", stock)
+			}
+		})
+
+		items := []inventoryDTO.ReservationItem{{ProductID: product.ID, Quantity: 4}}
+		var first, second inventoryDTO.ReservationResponse
+		t.Run("Reserve", func(t *testing.T) {
+			status := sendJSON(t, http.MethodPost, reservationsURL, token,
+				inventoryDTO.ReserveRequest{Items: items, TTLSeconds: 60}, &first)
+			if status != http.StatusCreated {
+				t.Fatalf("Expected status 201 Created, got %d", status)
+			}
+			status = sendJSON(t, http.MethodPost, reservationsURL, token,
+				inventoryDTO.ReserveRequest{Items: items}, &second)
+			if status != http.StatusCreated {
+				t.Fatalf("Expected status 201 Created, got %d", status)
+			}
+			if first.Status != "pending" || len(first.Items) != 1 {
+				t.Errorf("Unexpected reservation: %+v", first)
+			}
+
+			// 10 held, 8 reserved
+			status = sendJSON(t, http.MethodPost, reservationsURL, token,
+				inventoryDTO.ReserveRequest{Items: items}, nil)
+			if status != http.StatusConflict {
+				t.Errorf("Expected status 409 Conflict, got %d", status)
+			}
+			status = sendJSON(t, http.MethodPut, stockURL, token, inventoryDTO.SetStockRequest{OnHand: 7}, nil)
+			if status != http.StatusConflict {
+				t.Errorf("Expected status 409 Conflict, got %d", status)
+			}
+		})
+
+		t.Run("Commit And Release", func(t *testing.T) {
+			var committed inventoryDTO.ReservationResponse
+			status := sendJSON(t, http.MethodPost, reservationsURL+"/"+first.ID+"/commit", token, nil, &committed)
+			if status != http.StatusOK {
+				t.Fatalf(ExpectedStatus200OKGotMessage, status)
+			}
+			if committed.Status != "committed" {
+				t.Errorf("Expected committed, got %s", committed.Status)
+			}
+			status = sendJSON(t, http.MethodPost, reservationsURL+"/"+second.ID+"/release", token, nil, nil)
+			if status != http.StatusOK {
+				t.Fatalf(ExpectedStatus200OKGotMessage, status)
+			}
+			status = sendJSON(t, http.MethodPost, reservationsURL+"/"+second.ID+"/commit", token, nil, nil)
+			if status != http.StatusConflict {
+				t.Errorf("Expected status 409 Conflict, got %d", status)
+			}
+
+			var stock inventoryDTO.StockResponse
+			status = sendJSON(t, http.MethodGet, stockURL, token, nil, &stock)
+			if status != http.StatusOK {
+				t.Fatalf(ExpectedStatus200OKGotMessage, status)
+			}
+			if stock.OnHand != 6 || stock.Reserved != 0 || stock.Available != 6 {
+				t.Errorf("Unexpected stock: %+v", stock)
+			}
+		})
+
+		t.Run("Unknown Reservation", func(t *testing.T) {
+			status := sendJSON(t, http.MethodGet, reservationsURL+"/missing", token, nil, nil)
+			if status != http.StatusNotFound {
+				t.Errorf("Expected status 404 Not Found, got %d", status)
+			}
+		})
+
+		adminDeleteProduct(t, product, addr+"/api/v1/admin/products", token)
+	})
+}
@@ -434,6 +434,44 @@ func TestValidateConfigInvalidDatabaseDSN(t *testing.T) {
 	t.Log("✅ TestValidateConfigInvalidDatabaseDSN passed")
 }
 
+// TestValidateConfigDatabaseIdleAboveOpen tests more idle than open database connections
+func TestValidateConfigDatabaseIdleAboveOpen(t *testing.T) {
+	cfg := &config.Config{
+		HTTPPort:       4000,
+		HTTPAddress:    "127.0.0.1",
+		GRPCPort:       9001,
+		ENV:            "development",
+		JWTSecret:      "secret-key-long-enough",
+		JWTExpiryHours: 72,
+		Database: config.DatabaseCfg{
+			DSN:          "postgresql://localhost/db",
+			MaxOpenConns: 5,
+			MaxIdleConns: 10,
+		},
+		Redis: config.RedisCfg{
+			DSN:        "localhost:6379",
+			DB:         0,
+			Prefix:     "go-worker",
+			DefaultTTL: 5,
+		},
+	}
+
+	// Test
+	err := config.ValidateConfig(cfg)
+
+	// Assert
+	if err == nil {
+		t.Fatalf("❌ Expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "invalid DATABASE_MAX_IDLE_CONNS") {
+		t.Fatalf("❌ Expected error containing 'invalid DATABASE_MAX_IDLE_CONNS', got: %v", err)
+	}
+
+	fmt.Println(err.Error())
+	t.Log("✅ TestValidateConfigDatabaseIdleAboveOpen passed")
+}
+
 // TestValidateConfigEmptyRedisDSN tests empty Redis DSN
 func TestValidateConfigEmptyRedisDSN(t *testing.T) {
 	cfg := &config.Config{
@@ -506,6 +544,80 @@ func TestValidateConfigInvalidRedisDSN(t *testing.T) {
 	t.Log("✅ TestValidateConfigInvalidRedisDSN passed")
 }
 
+// TestValidateConfigRedisSentinelWithoutMaster tests sentinel mode without a master name
+func TestValidateConfigRedisSentinelWithoutMaster(t *testing.T) {
+	cfg := &config.Config{
+		HTTPPort:       4000,
+		HTTPAddress:    "127.0.0.1",
+		GRPCPort:       9001,
+		ENV:            "development",
+		JWTSecret:      "secret-key-long-enough",
+		JWTExpiryHours: 72,
+		Database: config.DatabaseCfg{
+			DSN: "postgresql://localhost/db",
+		},
+		Redis: config.RedisCfg{
+			Mode:       "sentinel",
+			DSN:        "sentinel-1:26379,sentinel-2:26379",
+			DB:         0,
+			Prefix:     "go-worker",
+			DefaultTTL: 5,
+		},
+	}
+
+	// Test
+	err := config.ValidateConfig(cfg)
+
+	// Assert
+	if err == nil {
+		t.Fatalf("❌ Expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "REDIS_MASTER_NAME is empty") {
+		t.Fatalf("❌ Expected error containing 'REDIS_MASTER_NAME is empty', got: %v", err)
+	}
+
+	fmt.Println(err.Error())
+	t.Log("✅ TestValidateConfigRedisSentinelWithoutMaster passed")
+}
+
+// TestValidateConfigRedisClusterDB tests cluster mode with a database other than 0
+func TestValidateConfigRedisClusterDB(t *testing.T) {
+	cfg := &config.Config{
+		HTTPPort:       4000,
+		HTTPAddress:    "127.0.0.1",
+		GRPCPort:       9001,
+		ENV:            "development",
+		JWTSecret:      "secret-key-long-enough",
+		JWTExpiryHours: 72,
+		Database: config.DatabaseCfg{
+			DSN: "postgresql://localhost/db",
+		},
+		Redis: config.RedisCfg{
+			Mode:       "cluster",
+			DSN:        "node-1:6379,node-2:6379,node-3:6379",
+			DB:         1,
+			Prefix:     "go-worker",
+			DefaultTTL: 5,
+		},
+	}
+
+	// Test
+	err := config.ValidateConfig(cfg)
+
+	// Assert
+	if err == nil {
+		t.Fatalf("❌ Expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "Redis Cluster only has database 0") {
+		t.Fatalf("❌ Expected error containing 'Redis Cluster only has database 0', got: %v", err)
+	}
+
+	fmt.Println(err.Error())
+	t.Log("✅ TestValidateConfigRedisClusterDB passed")
+}
+
 // TestValidateConfigInvalidRedisDB tests invalid Redis DB
 func TestValidateConfigInvalidRedisDB(t *testing.T) {
 	cfg := &config.Config{
@@ -649,3 +761,217 @@ func TestValidateConfigInvalidRedisTTL(t *testing.T) {
 	fmt.Println(err.Error())
 	t.Log("✅ TestValidateConfigInvalidRedisTTL passed")
 }
+
+// TestValidateConfigInvalidRedisCodec tests an unknown cache codec
+func TestValidateConfigInvalidRedisCodec(t *testing.T) {
+	cfg := &config.Config{
+		HTTPPort:       4000,
+		HTTPAddress:    "127.0.0.1",
+		GRPCPort:       9001,
+		ENV:            "development",
+		JWTSecret:      "secret-key-long-enough",
+		JWTExpiryHours: 72,
+		Database: config.DatabaseCfg{
+			DSN: "postgresql://localhost/db",
+		},
+		Redis: config.RedisCfg{
+			DSN:        "localhost:6379",
+			DB:         0,
+			Prefix:     "go-worker",
+			DefaultTTL: 5,
+			Codec:      "xml",
+		},
+	}
+
+	// Test
+	err := config.ValidateConfig(cfg)
+
+	// Assert
+	if err == nil {
+		t.Fatalf("❌ Expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "invalid REDIS_CODEC") {
+		t.Fatalf("❌ Expected error containing 'invalid REDIS_CODEC', got: %v", err)
+	}
+
+	fmt.Println(err.Error())
+	t.Log("✅ TestValidateConfigInvalidRedisCodec passed")
+}
+
+// TestValidateConfigInvalidOutboxSink tests an unknown outbox sink
+func TestValidateConfigInvalidOutboxSink(t *testing.T) {
+	cfg := &config.Config{
+		HTTPPort:       4000,
+		HTTPAddress:    "127.0.0.1",
+		GRPCPort:       9001,
+		ENV:            "development",
+		JWTSecret:      "secret-key-long-enough",
+		JWTExpiryHours: 72,
+		Database: config.DatabaseCfg{
+			DSN: "postgresql://localhost/db",
+		},
+		Redis: config.RedisCfg{
+			DSN:        "localhost:6379",
+			DB:         0,
+			Prefix:     "go-worker",
+			DefaultTTL: 5,
+		},
+		Outbox: config.OutboxCfg{
+			Sink: "kafka",
+		},
+	}
+
+	// Test
+	err := config.ValidateConfig(cfg)
+
+	// Assert
+	if err == nil {
+		t.Fatalf("❌ Expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "invalid OUTBOX_SINK") {
+		t.Fatalf("❌ Expected error containing 'invalid OUTBOX_SINK', got: %v", err)
+	}
+
+	fmt.Println(err.Error())
+	t.Log("✅ TestValidateConfigInvalidOutboxSink passed")
+}
+
+// TestValidateConfigOutboxWebhookWithoutURL tests the webhook sink without a URL
+func TestValidateConfigOutboxWebhookWithoutURL(t *testing.T) {
+	cfg := &config.Config{
+		HTTPPort:       4000,
+		HTTPAddress:    "127.0.0.1",
+		GRPCPort:       9001,
+		ENV:            "development",
+		JWTSecret:      "secret-key-long-enough",
+		JWTExpiryHours: 72,
+		Database: config.DatabaseCfg{
+			DSN: "postgresql://localhost/db",
+		},
+		Redis: config.RedisCfg{
+			DSN:        "localhost:6379",
+			DB:         0,
+			Prefix:     "go-worker",
+			DefaultTTL: 5,
+		},
+		Outbox: config.OutboxCfg{
+			Sink: "webhook",
+		},
+	}
+
+	// Test
+	err := config.ValidateConfig(cfg)
+
+	// Assert
+	if err == nil {
+		t.Fatalf("❌ Expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "invalid OUTBOX_WEBHOOK_URL") {
+		t.Fatalf("❌ Expected error containing 'invalid OUTBOX_WEBHOOK_URL', got: %v", err)
+	}
+
+	fmt.Println(err.Error())
+	t.Log("✅ TestValidateConfigOutboxWebhookWithoutURL passed")
+}
+
+// TestValidateConfigInvalidServiceWorkers tests dispatcher service without workers
+func TestValidateConfigInvalidServiceWorkers(t *testing.T) {
+	cfg := &config.Config{
+		HTTPPort:       4000,
+		HTTPAddress:    "127.0.0.1",
+		GRPCPort:       9001,
+		ENV:            "development",
+		JWTSecret:      "secret-key-long-enough",
+		JWTExpiryHours: 72,
+		Database: config.DatabaseCfg{
+			DSN: "postgresql://localhost/db",
+		},
+		Redis: config.RedisCfg{
+			DSN:        "localhost:6379",
+			DB:         0,
+			Prefix:     "go-worker",
+			DefaultTTL: 5,
+		},
+		Dispatcher: config.DispatcherCfg{
+			Services: []config.ServiceCfg{
+				{Name: "email", Workers: 0, QueueSize: 100, Overflow: "block", Backend: "memory"},
+			},
+		},
+	}
+
+	// Test
+	err := config.ValidateConfig(cfg)
+
+	// Assert
+	if err == nil {
+		t.Fatalf("❌ Expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "invalid dispatcher.services.email.workers") ||
+		!strings.Contains(err.Error(), "APP_DISPATCHER_SERVICES_EMAIL_WORKERS") {
+		t.Fatalf("❌ Expected error naming dispatcher.services.email.workers, got: %v", err)
+	}
+
+	fmt.Println(err.Error())
+	t.Log("✅ TestValidateConfigInvalidServiceWorkers passed")
+}
+
+// TestValidateConfigInvalidServiceOverflow tests dispatcher service with unknown overflow policy
+func TestValidateConfigInvalidServiceOverflow(t *testing.T) {
+	cfg := &config.Config{
+		HTTPPort:       4000,
+		HTTPAddress:    "127.0.0.1",
+		GRPCPort:       9001,
+		ENV:            "development",
+		JWTSecret:      "secret-key-long-enough",
+		JWTExpiryHours: 72,
+		Database: config.DatabaseCfg{
+			DSN: "postgresql://localhost/db",
+		},
+		Redis: config.RedisCfg{
+			DSN:        "localhost:6379",
+			DB:         0,
+			Prefix:     "go-worker",
+			DefaultTTL: 5,
+		},
+		Dispatcher: config.DispatcherCfg{
+			Services: []config.ServiceCfg{
+				{Name: "email", Workers: 5, QueueSize: 100, Overflow: "queue", Backend: "memory"},
+			},
+		},
+	}
+
+	// Test
+	err := config.ValidateConfig(cfg)
+
+	// Assert
+	if err == nil {
+		t.Fatalf("❌ Expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "invalid dispatcher.services.email.overflow") {
+		t.Fatalf("❌ Expected error containing 'invalid dispatcher.services.email.overflow', got: %v", err)
+	}
+
+	fmt.Println(err.Error())
+	t.Log("✅ TestValidateConfigInvalidServiceOverflow passed")
+}
+
+func TestRedisConfigStringRedactsPasswords(t *testing.T) {
+	cfg := config.Config{Redis: config.RedisCfg{
+		DSN:              "localhost:6379",
+		Password:         "master-secret",
+		SentinelPassword: "sentinel-secret",
+	}}
+
+	logged := fmt.Sprintf("%+v", &cfg)
+	if strings.Contains(logged, "master-secret") || strings.Contains(logged, "sentinel-secret") {
+		t.Fatalf("❌ Expected the redis passwords to be redacted, got: %s", logged)
+	}
+	if !strings.Contains(logged, "localhost:6379") {
+		t.Fatalf("❌ Expected the rest of the redis config to be logged, got: %s", logged)
+	}
+}
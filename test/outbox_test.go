@@ -0,0 +1,186 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-worker/internal/config"
+	"go-worker/internal/outbox"
+	"go-worker/internal/storage/sql/sqlc"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// recordSink records the events of one aggregate type and fails the
+// aggregates in fail
+type recordSink struct {
+	mu            sync.Mutex
+	aggregateType string
+	fail          map[string]bool
+	events        []outbox.Event
+}
+
+func (s *recordSink) Publish(ctx context.Context, event outbox.Event) error {
+	if event.AggregateType != s.aggregateType {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail[event.AggregateID] {
+		return errors.New("sink is down")
+	}
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *recordSink) published() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var published []string
+	for _, e := range s.events {
+		published = append(published, e.AggregateID+":"+e.Type)
+	}
+	return published
+}
+
+func TestOutboxRelayOrderPerAggregate(t *testing.T) {
+	manager, q := newTestTxManager(t)
+	ctx := context.Background()
+	aggregateType := fmt.Sprintf("test-%d", time.Now().UnixNano())
+
+	// a and b get two events each, in two transactions
+	for _, eventType := range []string{"Created", "Updated"} {
+		err := manager.WithTx(ctx, func(ctx context.Context, q *sqlc.Queries) error {
+			for _, id := range []string{"a", "b"} {
+				if err := outbox.Add(ctx, q, aggregateType, id, eventType, map[string]string{"id": id}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Failed to add events: %v", err)
+		}
+	}
+	// an event whose transaction is rolled back is never published
+	_ = manager.WithTx(ctx, func(ctx context.Context, q *sqlc.Queries) error {
+		if err := outbox.Add(ctx, q, aggregateType, "c", "Created", nil); err != nil {
+			return err
+		}
+		return errors.New("failed")
+	})
+
+	sink := &recordSink{aggregateType: aggregateType, fail: map[string]bool{"a": true}}
+	cfg := &config.Config{Outbox: config.OutboxCfg{BatchSize: 1000}}
+	relay := outbox.NewRelay(q, sink, cfg, zap.NewNop())
+
+	if _, err := relay.Relay(ctx); err != nil {
+		t.Fatalf("Relay failed: %v", err)
+	}
+	// a failed, so its update waits while b goes on
+	if got := sink.published(); fmt.Sprint(got) != "[b:Created b:Updated]" {
+		t.Fatalf("Expected the events of b only, got %v", got)
+	}
+
+	sink.mu.Lock()
+	sink.fail = nil
+	sink.mu.Unlock()
+	// a is retried once its backoff is over
+	time.Sleep(1100 * time.Millisecond)
+	if _, err := relay.Relay(ctx); err != nil {
+		t.Fatalf("Relay failed: %v", err)
+	}
+	if got := sink.published(); fmt.Sprint(got) != "[b:Created b:Updated a:Created a:Updated]" {
+		t.Fatalf("Expected the events of a in order, got %v", got)
+	}
+
+	// published events are not published again
+	if _, err := relay.Relay(ctx); err != nil {
+		t.Fatalf("Relay failed: %v", err)
+	}
+	if got := sink.published(); len(got) != 4 {
+		t.Fatalf("Expected 4 events, got %v", got)
+	}
+	t.Log("✅ Outbox relay order passed")
+}
+
+func TestOutboxRelayParksFailedEvents(t *testing.T) {
+	manager, q := newTestTxManager(t)
+	ctx := context.Background()
+	aggregateType := fmt.Sprintf("test-%d", time.Now().UnixNano())
+
+	for _, eventType := range []string{"Created", "Updated"} {
+		err := manager.WithTx(ctx, func(ctx context.Context, q *sqlc.Queries) error {
+			return outbox.Add(ctx, q, aggregateType, "a", eventType, nil)
+		})
+		if err != nil {
+			t.Fatalf("Failed to add events: %v", err)
+		}
+	}
+
+	sink := &recordSink{aggregateType: aggregateType, fail: map[string]bool{"a": true}}
+	cfg := &config.Config{Outbox: config.OutboxCfg{BatchSize: 1000, MaxAttempts: 1}}
+	relay := outbox.NewRelay(q, sink, cfg, zap.NewNop())
+	if _, err := relay.Relay(ctx); err != nil {
+		t.Fatalf("Relay failed: %v", err)
+	}
+
+	// the create failed its only attempt and is parked, the update goes on
+	sink.mu.Lock()
+	sink.fail = nil
+	sink.mu.Unlock()
+	if _, err := relay.Relay(ctx); err != nil {
+		t.Fatalf("Relay failed: %v", err)
+	}
+	if got := sink.published(); fmt.Sprint(got) != "[a:Updated]" {
+		t.Fatalf("Expected the parked event to be skipped, got %v", got)
+	}
+	t.Log("✅ Outbox relay parking passed")
+}
+
+func TestOutboxWebhookSink(t *testing.T) {
+	var received outbox.Event
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if received.AggregateID == "down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	sink := outbox.NewWebhookSink(server.URL, time.Second)
+	event := outbox.Event{
+		ID:            7,
+		AggregateType: "product",
+		AggregateID:   "42",
+		Type:          "ProductCreated",
+		Payload:       json.RawMessage(`{"id":42}`),
+		CreatedAt:     time.Now().UTC(),
+	}
+	if err := sink.Publish(context.Background(), event); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if received.ID != 7 || received.Type != "ProductCreated" || string(received.Payload) != `{"id":42}` {
+		t.Errorf("Unexpected event: %+v", received)
+	}
+	if header.Get("X-Event-ID") != "7" || header.Get("X-Event-Type") != "ProductCreated" {
+		t.Errorf("Unexpected headers: %v", header)
+	}
+
+	event.AggregateID = "down"
+	if err := sink.Publish(context.Background(), event); err == nil {
+		t.Error("Expected an error when the webhook does not answer 2xx")
+	}
+	t.Log("✅ Outbox webhook sink passed")
+}
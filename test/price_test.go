@@ -0,0 +1,115 @@
+package test
+
+import (
+	"fmt"
+	"go-worker/internal/auth"
+	"go-worker/internal/config"
+	"go-worker/internal/product/dto"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestProductPrices(t *testing.T) {
+	WithHttpTestServer(t, func() {
+		cfg, err := config.NewConfig()
+		if err != nil {
+			t.Fatalf("Failed to load config: %v", err)
+		}
+		addr := fmt.Sprintf("http://%s:%d", cfg.HTTPAddress, cfg.HTTPPort)
+		token, err := auth.GenerateToken(cfg, "admin-123")
+		if err != nil {
+			t.Fatalf("Failed to generate token: %v", err)
+		}
+
+		var product dto.AdminProductResponse
+		adminCreateProduct(t, &product, addr+"/api/v1/admin/products", token)
+		if product.Currency != "USD" {
+			t.Errorf("Expected default currency USD, got %q", product.Currency)
+		}
+		pricesURL := fmt.Sprintf("%s/api/v1/admin/products/%d/prices", addr, product.ID)
+
+		now := time.Now().UTC().Truncate(time.Second)
+		later := now.Add(24 * time.Hour)
+		var current, scheduled dto.PriceResponse
+		t.Run("Add Prices", func(t *testing.T) {
+			status := sendJSON(t, http.MethodPost, pricesURL, token, dto.AdminCreatePriceRequest{
+				Price:     dto.Money{Currency: "eur", Amount: 950},
+				ValidFrom: &now,
+				ValidTo:   &later,
+			}, &current)
+			if status != http.StatusCreated {
+				t.Fatalf("Expected status 201 Created, got %d", status)
+			}
+			if current.Price != (dto.Money{Currency: "EUR", Amount: 950}) {
+				t.Errorf("Unexpected price: %+v", current.Price)
+			}
+
+			status = sendJSON(t, http.MethodPost, pricesURL, token, dto.AdminCreatePriceRequest{
+				Price:     dto.Money{Currency: "EUR", Amount: 900},
+				ValidFrom: &later,
+			}, &scheduled)
+			if status != http.StatusCreated {
+				t.Fatalf("Expected status 201 Created, got %d", status)
+			}
+		})
+
+		t.Run("Reject Overlapping And Invalid Prices", func(t *testing.T) {
+			status := sendJSON(t, http.MethodPost, pricesURL, token, dto.AdminCreatePriceRequest{
+				Price: dto.Money{Currency: "EUR", Amount: 1000},
+			}, nil)
+			if status != http.StatusConflict {
+				t.Errorf("Expected status 409 Conflict, got %d", status)
+			}
+			status = sendJSON(t, http.MethodPost, pricesURL, token, dto.AdminCreatePriceRequest{
+				Price: dto.Money{Currency: "EURO", Amount: 1000},
+			}, nil)
+			if status != http.StatusBadRequest {
+				t.Errorf("Expected status 400 Bad Request, got %d", status)
+			}
+		})
+
+		t.Run("List Prices", func(t *testing.T) {
+			var all, active []dto.PriceResponse
+			if status := sendJSON(t, http.MethodGet, pricesURL, token, nil, &all); status != http.StatusOK {
+				t.Fatalf(ExpectedStatus200OKGotMessage, status)
+			}
+			if len(all) != 2 {
+				t.Errorf("Expected 2 prices, got %+v", all)
+			}
+			if status := sendJSON(t, http.MethodGet, pricesURL+"?current=true", token, nil, &active); status != http.StatusOK {
+				t.Fatalf(ExpectedStatus200OKGotMessage, status)
+			}
+			if len(active) != 1 || active[0].ID != current.ID {
+				t.Errorf("Expected only price %d in effect, got %+v", current.ID, active)
+			}
+		})
+
+		t.Run("Price History", func(t *testing.T) {
+			priceURL := fmt.Sprintf("%s/%d", pricesURL, scheduled.ID)
+			if status := sendJSON(t, http.MethodDelete, priceURL, token, nil, nil); status != http.StatusNoContent {
+				t.Fatalf("Expected status 204 No Content, got %d", status)
+			}
+			if status := sendJSON(t, http.MethodDelete, priceURL, token, nil, nil); status != http.StatusNotFound {
+				t.Errorf("Expected status 404 Not Found, got %d", status)
+			}
+
+			var history []dto.PriceHistoryResponse
+			if status := sendJSON(t, http.MethodGet, pricesURL+"/history", token, nil, &history); status != http.StatusOK {
+				t.Fatalf(ExpectedStatus200OKGotMessage, status)
+			}
+			var actions []string
+			for _, e := range history {
+				actions = append(actions, e.Action)
+				if e.AdminID != "admin-123" {
+					t.Errorf("Expected admin admin-123, got %q", e.AdminID)
+				}
+			}
+			if fmt.Sprint(actions) != "[remove add add]" {
+				t.Errorf("Unexpected history actions: %v", actions)
+			}
+		})
+
+		adminDeleteProduct(t, product, addr+"/api/v1/admin/products", token)
+	})
+}
@@ -8,8 +8,11 @@ import (
 	"go-worker/internal/config"
 	"go-worker/internal/product/dto"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestProductsAdmin(t *testing.T) {
@@ -21,7 +24,7 @@ func TestProductsAdmin(t *testing.T) {
 		}
 		addr := fmt.Sprintf("http://%s:%d", cfg.HTTPAddress, cfg.HTTPPort)
 		addr += "/api/v1/admin/products"
-		product := dto.ProductResponse{}
+		product := dto.AdminProductResponse{}
 		adminUnAuthorizedCreateProduct(t, addr)
 		adminUnAuthorizedListProduct(t, addr)
 		token, err := auth.GenerateToken(cfg, "admin-123")
@@ -32,8 +35,15 @@ func TestProductsAdmin(t *testing.T) {
 		adminListProduct(t, product, addr, token)
 		adminGetProductByID(t, product, addr, token)
 		adminUpdateProduct(t, product, addr, token)
+		adminUpdateProductPreconditions(t, product, addr, token)
+		adminPatchProduct(t, product, addr, token)
 		adminDeleteProduct(t, product, addr, token)
 		adminVerifyProductCreated(t, product, addr, token)
+		adminRestoreProduct(t, product, addr, token)
+		adminProductHistory(t, product, addr, token)
+		adminDeleteProduct(t, product, addr, token)
+		adminImportProducts(t, addr, token)
+		adminExportProducts(t, addr, token)
 	})
 }
 
@@ -79,7 +89,7 @@ func adminUnAuthorizedListProduct(t *testing.T, addr string) {
 	})
 }
 
-func adminCreateProduct(t *testing.T, product *dto.ProductResponse, addr string, token string) {
+func adminCreateProduct(t *testing.T, product *dto.AdminProductResponse, addr string, token string) {
 	t.Run("Create Product", func(t *testing.T) {
 		productRequest := dto.AdminCreateProductRequest{
 			Name:        "Test Product",
@@ -117,7 +127,7 @@ func adminCreateProduct(t *testing.T, product *dto.ProductResponse, addr string,
 	})
 }
 
-func adminListProduct(t *testing.T, product dto.ProductResponse, addr string, token string) {
+func adminListProduct(t *testing.T, product dto.AdminProductResponse, addr string, token string) {
 	t.Run("List Products", func(t *testing.T) {
 		req, err := http.NewRequest(http.MethodGet, addr, nil)
 		if err != nil {
@@ -139,16 +149,16 @@ func adminListProduct(t *testing.T, product dto.ProductResponse, addr string, to
 			return
 		}
 
-		var products dto.ClientListProductsResponse
+		var products dto.AdminListProductsResponse
 		if err := json.NewDecoder(resp.Body).Decode(&products); err != nil {
 			t.Fatalf(FailedToDecodeMessage, err)
 		}
 
-		if len(products) == 0 {
+		if len(products.Products) == 0 {
 			t.Errorf("Expected at least one product, got 0")
 		}
 		findCreatedProduct := false
-		for index, p := range products {
+		for index, p := range products.Products {
 			if p.ID == product.ID {
 				findCreatedProduct = true
 				t.Logf("Found created product at index %d: %+v", index, p)
@@ -161,7 +171,7 @@ func adminListProduct(t *testing.T, product dto.ProductResponse, addr string, to
 	})
 }
 
-func adminGetProductByID(t *testing.T, product dto.ProductResponse, addr string, token string) {
+func adminGetProductByID(t *testing.T, product dto.AdminProductResponse, addr string, token string) {
 	t.Run("Get Product By ID", func(t *testing.T) {
 		url := fmt.Sprintf("%s/%d", addr, product.ID)
 		req, err := http.NewRequest(http.MethodGet, url, nil)
@@ -184,10 +194,13 @@ func adminGetProductByID(t *testing.T, product dto.ProductResponse, addr string,
 			return
 		}
 
-		var fetchedProduct dto.ProductResponse
+		var fetchedProduct dto.AdminProductResponse
 		if err := json.NewDecoder(resp.Body).Decode(&fetchedProduct); err != nil {
 			t.Fatalf(FailedToDecodeMessage, err)
 		}
+		if etag := resp.Header.Get("ETag"); etag != `"1"` {
+			t.Errorf("Expected ETag \"1\" for a new product, got %q", etag)
+		}
 
 		if fetchedProduct.ID != product.ID {
 			t.Errorf("Expected product ID %d, got %d", product.ID, fetchedProduct.ID)
@@ -195,10 +208,13 @@ func adminGetProductByID(t *testing.T, product dto.ProductResponse, addr string,
 		if fetchedProduct.Name != product.Name {
 			t.Errorf("Expected product name %q, got %q", product.Name, fetchedProduct.Name)
 		}
+		if !fetchedProduct.IsActive || fetchedProduct.CreatedAt.IsZero() || fetchedProduct.Version != 1 {
+			t.Errorf("Expected status and timestamps in the admin view, got %+v", fetchedProduct)
+		}
 	})
 }
 
-func adminUpdateProduct(t *testing.T, product dto.ProductResponse, addr string, token string) {
+func adminUpdateProduct(t *testing.T, product dto.AdminProductResponse, addr string, token string) {
 	t.Run("Update Product", func(t *testing.T) {
 		updateRequest := dto.AdminUpdateProductRequest{
 			Name:        "Updated Product",
@@ -218,6 +234,8 @@ func adminUpdateProduct(t *testing.T, product dto.ProductResponse, addr string,
 		}
 		req.Header.Set("Content-Type", ApplicationJsonHeader)
 		req.Header.Set("Authorization", "Bearer "+token)
+		// a new product is at version 1
+		req.Header.Set("If-Match", `"1"`)
 
 		client := &http.Client{}
 		resp, err := client.Do(req)
@@ -233,7 +251,7 @@ func adminUpdateProduct(t *testing.T, product dto.ProductResponse, addr string,
 			return
 		}
 
-		var updatedProduct dto.ProductResponse
+		var updatedProduct dto.AdminProductResponse
 		if err := json.NewDecoder(resp.Body).Decode(&updatedProduct); err != nil {
 			t.Fatalf("Failed to decode update response: %v", err)
 		}
@@ -245,7 +263,7 @@ func adminUpdateProduct(t *testing.T, product dto.ProductResponse, addr string,
 	})
 }
 
-func adminDeleteProduct(t *testing.T, product dto.ProductResponse, addr string, token string) {
+func adminDeleteProduct(t *testing.T, product dto.AdminProductResponse, addr string, token string) {
 	t.Run("Delete Product", func(t *testing.T) {
 		req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%d", addr, product.ID), nil)
 		if err != nil {
@@ -267,7 +285,7 @@ func adminDeleteProduct(t *testing.T, product dto.ProductResponse, addr string,
 		}
 	})
 }
-func adminVerifyProductCreated(t *testing.T, product dto.ProductResponse, addr string, token string) {
+func adminVerifyProductCreated(t *testing.T, product dto.AdminProductResponse, addr string, token string) {
 	t.Run("Verify Product Deleted", func(t *testing.T) {
 		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/%d", addr, product.ID), nil)
 		if err != nil {
@@ -286,3 +304,252 @@ func adminVerifyProductCreated(t *testing.T, product dto.ProductResponse, addr s
 		}
 	})
 }
+
+func adminRestoreProduct(t *testing.T, product dto.AdminProductResponse, addr string, token string) {
+	t.Run("Restore Product", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/%d/restore", addr, product.ID), nil)
+		if err != nil {
+			t.Fatalf("Failed to create POST request: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		client := &http.Client{}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to send POST request: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf(ExpectedStatus200OKGotMessage, resp.StatusCode)
+			responseBody, _ := io.ReadAll(resp.Body)
+			t.Logf(ResponseBodyMessage, string(responseBody))
+			return
+		}
+
+		var restored dto.AdminProductResponse
+		if err := json.NewDecoder(resp.Body).Decode(&restored); err != nil {
+			t.Fatalf(FailedToDecodeMessage, err)
+		}
+		if restored.ID != product.ID {
+			t.Errorf("Expected restored product %d, got %d", product.ID, restored.ID)
+		}
+	})
+}
+
+func adminProductHistory(t *testing.T, product dto.AdminProductResponse, addr string, token string) {
+	t.Run("Product History", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/%d/history", addr, product.ID), nil)
+		if err != nil {
+			t.Fatalf("Failed to create GET request: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		client := &http.Client{}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf(FailedToSendGetMessage, err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf(ExpectedStatus200OKGotMessage, resp.StatusCode)
+			responseBody, _ := io.ReadAll(resp.Body)
+			t.Logf(ResponseBodyMessage, string(responseBody))
+			return
+		}
+
+		var history dto.AdminProductHistoryResponse
+		if err := json.NewDecoder(resp.Body).Decode(&history); err != nil {
+			t.Fatalf(FailedToDecodeMessage, err)
+		}
+
+		var actions []string
+		for _, e := range history {
+			actions = append(actions, e.Action)
+			if e.AdminID != "admin-123" {
+				t.Errorf("Expected admin-123 in history, got %q", e.AdminID)
+			}
+		}
+		if fmt.Sprint(actions) != "[restore delete update update create]" {
+			t.Errorf("Unexpected history actions: %v", actions)
+		}
+	})
+}
+
+func adminPatchProduct(t *testing.T, product dto.AdminProductResponse, addr string, token string) {
+	t.Run("Patch Product", func(t *testing.T) {
+		body := []byte(`{"price": 1750}`)
+		req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/%d", addr, product.ID), bytes.NewBuffer(body))
+		if err != nil {
+			t.Fatalf("Failed to create PATCH request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		// the product was updated once
+		req.Header.Set("If-Match", `"2"`)
+
+		client := &http.Client{}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to send PATCH request: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf(ExpectedStatus200OKGotMessage, resp.StatusCode)
+			responseBody, _ := io.ReadAll(resp.Body)
+			t.Logf(ResponseBodyMessage, string(responseBody))
+			return
+		}
+		if got := resp.Header.Get("ETag"); got != `"3"` {
+			t.Errorf("Expected ETag \"3\", got %q", got)
+		}
+
+		var patched dto.AdminProductResponse
+		if err := json.NewDecoder(resp.Body).Decode(&patched); err != nil {
+			t.Fatalf(FailedToDecodeMessage, err)
+		}
+		if patched.Price != 1750 || patched.Name != "Updated Product" || patched.Description != "Updated description" {
+			t.Errorf("Patch should only change the price, got %+v", patched)
+		}
+	})
+}
+
+func adminUpdateProductPreconditions(t *testing.T, product dto.AdminProductResponse, addr string, token string) {
+	cases := []struct {
+		name    string
+		ifMatch string
+		status  int
+	}{
+		{name: "Update Product Stale Version", ifMatch: `"1"`, status: http.StatusPreconditionFailed},
+		{name: "Update Product Without If-Match", ifMatch: "", status: http.StatusPreconditionRequired},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(dto.AdminUpdateProductRequest{Name: "Lost Update", Price: 1})
+			req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/%d", addr, product.ID), bytes.NewBuffer(body))
+			if err != nil {
+				t.Fatalf("Failed to create PUT request: %v", err)
+			}
+			req.Header.Set("Content-Type", ApplicationJsonHeader)
+			req.Header.Set("Authorization", "Bearer "+token)
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			client := &http.Client{}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Failed to send PUT request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tc.status {
+				t.Errorf("Expected status %d, got %d", tc.status, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func adminImportProducts(t *testing.T, addr string, token string) {
+	t.Run("Import Products", func(t *testing.T) {
+		csv := "name,description,price,is_active\n" +
+			"Imported One,First imported product,100,true\n" +
+			",Missing name,200,true\n" +
+			"Imported Two,Second imported product,not-a-price,false\n" +
+			"Imported Three,Third imported product,300,\n"
+
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, err := form.CreateFormFile("file", "products.csv")
+		if err != nil {
+			t.Fatalf("Failed to create form file: %v", err)
+		}
+		part.Write([]byte(csv))
+		form.Close()
+
+		req, err := http.NewRequest(http.MethodPost, addr+"/import", &body)
+		if err != nil {
+			t.Fatalf("Failed to create POST request: %v", err)
+		}
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		client := &http.Client{}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to send POST request: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusAccepted {
+			t.Errorf("Expected status 202 Accepted, got %d", resp.StatusCode)
+			responseBody, _ := io.ReadAll(resp.Body)
+			t.Logf(ResponseBodyMessage, string(responseBody))
+			return
+		}
+		var status dto.ProductImportResponse
+		if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+			t.Fatalf(FailedToDecodeMessage, err)
+		}
+
+		deadline := time.Now().Add(10 * time.Second)
+		for status.Status != "done" && status.Status != "failed" && time.Now().Before(deadline) {
+			time.Sleep(100 * time.Millisecond)
+			req, _ := http.NewRequest(http.MethodGet, addr+"/import/"+status.ID, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf(FailedToSendGetMessage, err)
+			}
+			err = json.NewDecoder(resp.Body).Decode(&status)
+			resp.Body.Close()
+			if err != nil {
+				t.Fatalf(FailedToDecodeMessage, err)
+			}
+		}
+
+		if status.Status != "done" || status.Created != 2 || status.Failed != 2 {
+			t.Fatalf("Unexpected import result: %+v", status)
+		}
+		if len(status.Errors) != 2 || status.Errors[0].Row != 3 || status.Errors[1].Row != 4 {
+			t.Errorf("Expected rows 3 and 4 to be rejected, got %+v", status.Errors)
+		}
+	})
+}
+
+func adminExportProducts(t *testing.T, addr string, token string) {
+	t.Run("Export Products", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, addr+"/export?format=jsonl", nil)
+		if err != nil {
+			t.Fatalf("Failed to create GET request: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		client := &http.Client{}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf(FailedToSendGetMessage, err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf(ExpectedStatus200OKGotMessage, resp.StatusCode)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		var found bool
+		for _, line := range strings.Split(strings.TrimSpace(string(body)), "\n") {
+			var row dto.ProductRow
+			if err := json.Unmarshal([]byte(line), &row); err != nil {
+				t.Fatalf("Failed to decode export line %q: %v", line, err)
+			}
+			if row.Name == "Imported Three" {
+				found = row.Price == 300 && row.IsActive != nil && *row.IsActive
+			}
+		}
+		if !found {
+			t.Errorf("Imported product missing from export")
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"go-worker/internal/auth"
@@ -21,19 +22,22 @@ func TestProductsClient(t *testing.T) {
 		addr := fmt.Sprintf("http://%s:%d", cfg.HTTPAddress, cfg.HTTPPort)
 
 		// First, create a product via admin API so it's available to the client
-		var product dto.ProductResponse
+		var product dto.AdminProductResponse
 		token, err := auth.GenerateToken(cfg, "admin-123")
 		if err != nil {
 			t.Fatalf("Failed to generate token: %v", err)
 		}
 		adminCreateProduct(t, &product, addr+"/api/v1/admin/products", token)
 		clientListProducts(t, product, addr)
+		clientListProductsPage(t, product, addr)
+		clientSearchProducts(t, product, addr)
 		clientGetProductByID(t, product, addr)
+		clientHidesInactiveProduct(t, product, addr, token)
 		adminDeleteProduct(t, product, addr+"/api/v1/admin/products", token)
 	})
 }
 
-func clientListProducts(t *testing.T, product dto.ProductResponse, addr string) {
+func clientListProducts(t *testing.T, product dto.AdminProductResponse, addr string) {
 	t.Run("List Products (Client)", func(t *testing.T) {
 		resp, err := http.Get(addr + "/api/v1/products")
 		if err != nil {
@@ -54,7 +58,7 @@ func clientListProducts(t *testing.T, product dto.ProductResponse, addr string)
 		}
 
 		found := false
-		for _, p := range products {
+		for _, p := range products.Products {
 			if p.ID == product.ID {
 				found = true
 				t.Logf("Found product in client list: %+v", p)
@@ -67,7 +71,99 @@ func clientListProducts(t *testing.T, product dto.ProductResponse, addr string)
 	})
 }
 
-func clientGetProductByID(t *testing.T, product dto.ProductResponse, addr string) {
+func clientListProductsPage(t *testing.T, product dto.AdminProductResponse, addr string) {
+	t.Run("List Products Page (Client)", func(t *testing.T) {
+		url := fmt.Sprintf("%s/api/v1/products?limit=1&sort=created_at_desc&min_price=%d&max_price=%d",
+			addr, product.Price, product.Price)
+		resp, err := http.Get(url)
+		if err != nil {
+			t.Fatalf("Failed to send GET request: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected status 200 OK, got %d", resp.StatusCode)
+			responseBody, _ := io.ReadAll(resp.Body)
+			t.Logf(ResponseBodyMessage, string(responseBody))
+			return
+		}
+
+		var page dto.ClientListProductsResponse
+		if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+			t.Fatalf(FailedToDecodeMessage, err)
+		}
+		if len(page.Products) != 1 {
+			t.Fatalf("Expected 1 product on the page, got %d", len(page.Products))
+		}
+		if page.Total < 1 {
+			t.Errorf("Expected a total of at least 1, got %d", page.Total)
+		}
+		if page.Total > 1 && page.NextCursor == "" {
+			t.Errorf("Expected a next cursor when total is %d", page.Total)
+		}
+	})
+
+	t.Run("List Products Invalid Sort (Client)", func(t *testing.T) {
+		resp, err := http.Get(addr + "/api/v1/products?sort=random")
+		if err != nil {
+			t.Fatalf("Failed to send GET request: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected status 400 Bad Request, got %d", resp.StatusCode)
+		}
+	})
+}
+
+func clientSearchProducts(t *testing.T, product dto.AdminProductResponse, addr string) {
+	t.Run("Search Products (Client)", func(t *testing.T) {
+		// prefixes of "Test Product"
+		resp, err := http.Get(addr + "/api/v1/products/search?q=tes+produ&limit=100")
+		if err != nil {
+			t.Fatalf("Failed to send GET request: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected status 200 OK, got %d", resp.StatusCode)
+			responseBody, _ := io.ReadAll(resp.Body)
+			t.Logf(ResponseBodyMessage, string(responseBody))
+			return
+		}
+
+		var results dto.SearchProductsResponse
+		if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+			t.Fatalf(FailedToDecodeMessage, err)
+		}
+
+		found := false
+		for _, p := range results.Products {
+			if p.ID == product.ID {
+				found = true
+				t.Logf("Found product in search results: %+v", p)
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Product not found in search results")
+		}
+	})
+
+	t.Run("Search Products Empty Query (Client)", func(t *testing.T) {
+		resp, err := http.Get(addr + "/api/v1/products/search?q=%2B%2B")
+		if err != nil {
+			t.Fatalf("Failed to send GET request: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected status 400 Bad Request, got %d", resp.StatusCode)
+		}
+	})
+}
+
+func clientGetProductByID(t *testing.T, product dto.AdminProductResponse, addr string) {
 	t.Run("Get Product By ID (Client)", func(t *testing.T) {
 		resp, err := http.Get(fmt.Sprintf("%s/api/v1/products/%d", addr, product.ID))
 		if err != nil {
@@ -95,3 +191,50 @@ func clientGetProductByID(t *testing.T, product dto.ProductResponse, addr string
 		}
 	})
 }
+
+func clientHidesInactiveProduct(t *testing.T, product dto.AdminProductResponse, addr string, token string) {
+	t.Run("Inactive Product Is Hidden (Client)", func(t *testing.T) {
+		body := []byte(`{"is_active": false}`)
+		url := fmt.Sprintf("%s/api/v1/admin/products/%d", addr, product.ID)
+		req, err := http.NewRequest(http.MethodPatch, url, bytes.NewBuffer(body))
+		if err != nil {
+			t.Fatalf("Failed to create PATCH request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("If-Match", "*")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to send PATCH request: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf(ExpectedStatus200OKGotMessage, resp.StatusCode)
+		}
+
+		resp, err = http.Get(fmt.Sprintf("%s/api/v1/products/%d", addr, product.ID))
+		if err != nil {
+			t.Fatalf(FailedToSendGetMessage, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("Expected status 404 for an inactive product, got %d", resp.StatusCode)
+		}
+
+		resp, err = http.Get(addr + "/api/v1/products?active=false&limit=100")
+		if err != nil {
+			t.Fatalf(FailedToSendGetMessage, err)
+		}
+		defer resp.Body.Close()
+		var products dto.ClientListProductsResponse
+		if err := json.NewDecoder(resp.Body).Decode(&products); err != nil {
+			t.Fatalf(FailedToDecodeMessage, err)
+		}
+		for _, p := range products.Products {
+			if p.ID == product.ID {
+				t.Errorf("Inactive product %d listed to clients", product.ID)
+			}
+		}
+	})
+}
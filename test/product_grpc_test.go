@@ -7,9 +7,13 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	productv1 "go-worker/api/proto/product/v1"
+	"go-worker/internal/auth"
 	"go-worker/internal/config"
 	"go-worker/internal/product/dto"
 )
@@ -21,16 +25,46 @@ func TestProductGRPCGetProductByID(t *testing.T) {
 			t.Fatalf("Failed to load config: %v", err)
 		}
 		addr := cfg.HTTPAddress + ":" + strconv.Itoa(cfg.GRPCPort)
-		conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		conn, err := grpc.NewClient(addr,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(withAdminToken(t, cfg)),
+		)
 		assert.NoError(t, err)
 		defer conn.Close()
 		client := productv1.NewProductServiceClient(conn)
 		var product dto.ProductResponse
+		grpcCreateProductWithoutToken(t, addr)
 		grpcCreateProduct(t, &product, client)
 		grpcGetProductByID(t, product, client)
 		grpcListProducts(t, product, client)
 		grpcUpdateProduct(t, product, client)
 		grpcDeleteProduct(t, product, client)
+		grpcGetDeletedProduct(t, product, client)
+	})
+}
+
+// withAdminToken sends an admin token with every call, the admin methods need one
+func withAdminToken(t *testing.T, cfg *config.Config) grpc.UnaryClientInterceptor {
+	token, err := auth.GenerateToken(cfg, "admin-grpc-test")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func grpcCreateProductWithoutToken(t *testing.T, addr string) {
+	t.Run("create Product without a token (gRPC)", func(t *testing.T) {
+		conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		assert.NoError(t, err)
+		defer conn.Close()
+		_, err = productv1.NewProductServiceClient(conn).CreateProduct(context.Background(), &productv1.CreateProductRequest{
+			Name:  "Unauthenticated Product",
+			Price: 30,
+		})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
 	})
 }
 
@@ -130,3 +164,13 @@ func grpcDeleteProduct(t *testing.T, product dto.ProductResponse, client product
 		t.Logf("gRPC Deleted product ID: %d", resp.Id)
 	})
 }
+
+func grpcGetDeletedProduct(t *testing.T, product dto.ProductResponse, client productv1.ProductServiceClient) {
+	t.Run("Get Deleted Product (gRPC)", func(t *testing.T) {
+		// the second read is answered by the not found cache entry
+		for i := 0; i < 2; i++ {
+			_, err := client.GetProductByID(context.Background(), &productv1.ProductRequest{Id: product.ID})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		}
+	})
+}